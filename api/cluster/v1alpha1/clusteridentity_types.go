@@ -48,6 +48,24 @@ type ClusterIdentitySpec struct {
 	// AdoptsRegions is a list of orphan regions that this cluster should manage
 	// +optional
 	AdoptsRegions []string `json:"adoptsRegions,omitempty"`
+
+	// SourceHostTemplate is the Go text/template used to build service hostnames
+	// Available variables: .Service, .EnvironmentLetter, .Environment, .Application, .Region, .Cluster, .Domain
+	// Defaults to "{{.Service}}-ns-{{.EnvironmentLetter}}-{{.Environment}}-{{.Application}}.{{.Domain}}"
+	// +optional
+	SourceHostTemplate string `json:"sourceHostTemplate,omitempty"`
+
+	// TargetHostTemplate is the Go text/template used to build gateway target hostnames
+	// Available variables: .Cluster, .Region, .Postfix, .EnvironmentLetter, .Domain
+	// Defaults to "{{.Cluster}}-{{.Region}}-{{.Postfix}}.{{.Domain}}"
+	// +optional
+	TargetHostTemplate string `json:"targetHostTemplate,omitempty"`
+
+	// MigrationGracePeriod is how long the hostnames of the previous identity stay published after
+	// region, cluster, domain, environmentLetter or a host template changes, so clients with cached
+	// answers keep resolving the old names. Unset or zero switches to the new hostnames immediately.
+	// +optional
+	MigrationGracePeriod *metav1.Duration `json:"migrationGracePeriod,omitempty"`
 }
 
 // ClusterIdentityStatus defines the observed state of ClusterIdentity
@@ -56,10 +74,32 @@ type ClusterIdentityStatus struct {
 	// +kubebuilder:validation:Enum=Pending;Active;Failed
 	Phase string `json:"phase,omitempty"`
 
+	// PublishedIdentity is the identity the hostnames are currently published for.
+	// A change of the hostname fields of the spec starts a hostname migration from it.
+	// +optional
+	PublishedIdentity *ClusterIdentitySpec `json:"publishedIdentity,omitempty"`
+
+	// Migrations lists the previous identities whose hostnames are still published, oldest first
+	// +optional
+	Migrations []ClusterIdentityMigration `json:"migrations,omitempty"`
+
 	// Conditions represent the latest available observations
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ClusterIdentityMigration is a hostname migration from a previous identity.
+// The hostnames of the previous identity are published next to the current ones until ExpiresAt.
+type ClusterIdentityMigration struct {
+	// Identity is the previous identity
+	Identity ClusterIdentitySpec `json:"identity"`
+
+	// StartedAt is when the identity changed
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// ExpiresAt is when the hostnames of the previous identity are withdrawn
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=ci
@@ -25,6 +25,33 @@ type DNSConfigurationSpec struct {
 	// ExternalDNSControllers lists all ExternalDNS controllers in the infrastructure
 	// +kubebuilder:validation:MinItems=1
 	ExternalDNSControllers []ExternalDNSController `json:"externalDNSControllers"`
+
+	// ServiceTTL is the default TTL in seconds of the service CNAME records
+	// DNSPolicies and ServiceRoutes may override it
+	// If unset, the DNS provider default applies
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ServiceTTL *int64 `json:"serviceTTL,omitempty"`
+
+	// GatewayTTL is the default TTL in seconds of the gateway A records (default 300)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	GatewayTTL *int64 `json:"gatewayTTL,omitempty"`
+
+	// PreFailover lowers the TTL of every published record ahead of a planned region switch,
+	// so resolvers follow the switch quickly. Remove it once the switch is complete.
+	// +optional
+	PreFailover *PreFailover `json:"preFailover,omitempty"`
+}
+
+// PreFailover configures the pre-failover mode
+type PreFailover struct {
+	// TTL caps the TTL in seconds of every published record (default 30)
+	// Records with a lower TTL keep it
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
 }
 
 // ExternalDNSController defines an ExternalDNS controller configuration
@@ -37,6 +64,32 @@ type ExternalDNSController struct {
 	// In RegionBound mode, only controllers matching the cluster's region will be active
 	// +kubebuilder:validation:Required
 	Region string `json:"region"`
+
+	// Annotation is the value of the external-dns.alpha.kubernetes.io/controller annotation
+	// on the DNSEndpoints of this controller, matching its ExternalDNS annotation filter
+	// Defaults to "external-dns-<region>"
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+
+	// DomainFilters lists the domains this controller manages, like the ExternalDNS --domain-filter flag
+	// Records outside these domains are not published to this controller
+	// If empty, records of every domain are published
+	// +optional
+	DomainFilters []string `json:"domainFilters,omitempty"`
+
+	// RecordTypes lists the record types this controller manages
+	// Records of other types are not published to this controller
+	// If empty, records of every type are published
+	// +kubebuilder:validation:items:Enum=A;AAAA;CNAME;TXT
+	// +optional
+	RecordTypes []string `json:"recordTypes,omitempty"`
+
+	// TTL is the TTL in seconds of the records published to this controller
+	// It takes precedence over the serviceTTL and gatewayTTL defaults,
+	// DNSPolicy and ServiceRoute overrides take precedence over it
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
 }
 
 // DNSConfigurationStatus defines the observed state of DNSConfiguration
@@ -84,6 +84,24 @@ func (in *ClusterIdentityList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIdentityMigration) DeepCopyInto(out *ClusterIdentityMigration) {
+	*out = *in
+	in.Identity.DeepCopyInto(&out.Identity)
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIdentityMigration.
+func (in *ClusterIdentityMigration) DeepCopy() *ClusterIdentityMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIdentityMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterIdentitySpec) DeepCopyInto(out *ClusterIdentitySpec) {
 	*out = *in
@@ -92,6 +110,11 @@ func (in *ClusterIdentitySpec) DeepCopyInto(out *ClusterIdentitySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MigrationGracePeriod != nil {
+		in, out := &in.MigrationGracePeriod, &out.MigrationGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIdentitySpec.
@@ -107,6 +130,18 @@ func (in *ClusterIdentitySpec) DeepCopy() *ClusterIdentitySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterIdentityStatus) DeepCopyInto(out *ClusterIdentityStatus) {
 	*out = *in
+	if in.PublishedIdentity != nil {
+		in, out := &in.PublishedIdentity, &out.PublishedIdentity
+		*out = new(ClusterIdentitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = make([]ClusterIdentityMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -191,7 +226,24 @@ func (in *DNSConfigurationSpec) DeepCopyInto(out *DNSConfigurationSpec) {
 	if in.ExternalDNSControllers != nil {
 		in, out := &in.ExternalDNSControllers, &out.ExternalDNSControllers
 		*out = make([]ExternalDNSController, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceTTL != nil {
+		in, out := &in.ServiceTTL, &out.ServiceTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GatewayTTL != nil {
+		in, out := &in.GatewayTTL, &out.GatewayTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PreFailover != nil {
+		in, out := &in.PreFailover, &out.PreFailover
+		*out = new(PreFailover)
+		**out = **in
 	}
 }
 
@@ -230,6 +282,21 @@ func (in *DNSConfigurationStatus) DeepCopy() *DNSConfigurationStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSController) DeepCopyInto(out *ExternalDNSController) {
 	*out = *in
+	if in.DomainFilters != nil {
+		in, out := &in.DomainFilters, &out.DomainFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecordTypes != nil {
+		in, out := &in.RecordTypes, &out.RecordTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSController.
@@ -241,3 +308,18 @@ func (in *ExternalDNSController) DeepCopy() *ExternalDNSController {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreFailover) DeepCopyInto(out *PreFailover) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreFailover.
+func (in *PreFailover) DeepCopy() *PreFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(PreFailover)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterDNSPolicySpec defines the desired state of ClusterDNSPolicy
+type ClusterDNSPolicySpec struct {
+	// DNSPolicySpec holds the policy settings, with the same meaning as in a DNSPolicy
+	DNSPolicySpec `json:",inline"`
+
+	// NamespaceSelector selects the namespaces the policy applies to
+	// A namespace with its own DNSPolicy always uses that DNSPolicy
+	// If empty, the policy applies to every namespace
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Priority decides between ClusterDNSPolicies selecting the same namespace
+	// The highest priority wins, equal priorities are decided by name in alphabetical order
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cdnsp
+// +kubebuilder:storageversion
+
+// ClusterDNSPolicy is the Schema for the clusterdnspolicies API.
+// It is the default DNS policy of the namespaces it selects that have no DNSPolicy of their own.
+type ClusterDNSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDNSPolicySpec `json:"spec,omitempty"`
+	Status DNSPolicyStatus      `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterDNSPolicyList contains a list of ClusterDNSPolicy
+type ClusterDNSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDNSPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDNSPolicy{}, &ClusterDNSPolicyList{})
+}
@@ -25,8 +25,8 @@ import (
 
 // DNSPolicySpec defines the desired state of DNSPolicy
 type DNSPolicySpec struct {
-	// Mode defines how DNS records are managed (Active, RegionBound)
-	// +kubebuilder:validation:Enum=Active;RegionBound
+	// Mode defines how DNS records are managed (Active, RegionBound, Failover)
+	// +kubebuilder:validation:Enum=Active;RegionBound;Failover
 	// +kubebuilder:default=Active
 	Mode string `json:"mode,omitempty"`
 
@@ -43,6 +43,109 @@ type DNSPolicySpec struct {
 	// If empty, the policy is considered active regardless of cluster name
 	// +optional
 	SourceCluster string `json:"sourceCluster,omitempty"`
+
+	// Failover configures the peer regions taken over in Failover mode
+	// Required when Mode is Failover
+	// +optional
+	Failover *DNSPolicyFailover `json:"failover,omitempty"`
+
+	// Controllers overrides the controllers computed from the mode with an explicit list
+	// Each name must be an ExternalDNS controller defined in DNSConfiguration
+	// Mutually exclusive with RegionSelector, not supported in Failover mode
+	// +optional
+	Controllers []string `json:"controllers,omitempty"`
+
+	// RegionSelector narrows the controllers computed from the mode to these regions
+	// Each region must have an ExternalDNS controller defined in DNSConfiguration
+	// Mutually exclusive with Controllers
+	// +optional
+	RegionSelector []string `json:"regionSelector,omitempty"`
+
+	// RouteSelector selects the ServiceRoutes in the namespace this policy applies to, by label
+	// A policy with a RouteSelector takes precedence over a policy without one
+	// If empty, the policy applies to every ServiceRoute not selected by another DNSPolicy
+	// Not supported in a ClusterDNSPolicy
+	// +optional
+	RouteSelector *metav1.LabelSelector `json:"routeSelector,omitempty"`
+
+	// TTL is the TTL in seconds of the CNAME records of the ServiceRoutes this policy applies to
+	// Overrides the DNSConfiguration defaults, a ServiceRoute may override it in turn
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+}
+
+// DNSPolicyFailover configures the Failover mode.
+// The cluster manages its own region like in Active mode, and additionally activates the
+// controllers of a peer region while the health signal of that region reports unhealthy.
+type DNSPolicyFailover struct {
+	// Peers are the regions this cluster takes over when they become unhealthy
+	// +kubebuilder:validation:MinItems=1
+	Peers []DNSPolicyFailoverPeer `json:"peers"`
+
+	// ProbeInterval is the time between two health checks of a peer (default 10s)
+	// +optional
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed checks before a peer region is taken over
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold is the number of consecutive successful checks before a peer region is handed back
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// MinHoldTime is the minimum time a takeover or handback is kept before the next switch (default 5m)
+	// +optional
+	MinHoldTime *metav1.Duration `json:"minHoldTime,omitempty"`
+}
+
+// DNSPolicyFailoverPeer defines a peer region and the health signal it is judged by.
+// Exactly one of HTTPGet and Lease must be set.
+type DNSPolicyFailoverPeer struct {
+	// Region is the peer region, as used by the ExternalDNS controllers in DNSConfiguration
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// HTTPGet probes a URL served by the peer region, any 2xx or 3xx response is healthy.
+	// Only supported in a ClusterDNSPolicy.
+	// +optional
+	HTTPGet *DNSPolicyHTTPGetProbe `json:"httpGet,omitempty"`
+
+	// Lease judges the peer region by a heartbeat Lease it renews,
+	// the peer is unhealthy once the Lease has not been renewed within its lease duration
+	// +optional
+	Lease *DNSPolicyLeaseProbe `json:"lease,omitempty"`
+}
+
+// DNSPolicyHTTPGetProbe is an HTTP health check of a peer region
+type DNSPolicyHTTPGetProbe struct {
+	// URL is the http or https URL to probe
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Timeout is the timeout of a single probe (default 5s)
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DNSPolicyLeaseProbe is a heartbeat Lease renewed by a peer region
+type DNSPolicyLeaseProbe struct {
+	// Name is the name of the Lease
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Lease, defaults to the DNSPolicy namespace.
+	// A DNSPolicy can only use a Lease in its own namespace, a ClusterDNSPolicy must set it.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // DNSPolicyStatus defines the observed state of DNSPolicy
@@ -59,10 +162,43 @@ type DNSPolicyStatus struct {
 	// Will be empty if the policy is not active
 	ActiveControllers []string `json:"activeControllers,omitempty"`
 
+	// Peers reports the health of the peer regions in Failover mode
+	// +optional
+	Peers []DNSPolicyPeerStatus `json:"peers,omitempty"`
+
 	// Conditions represent the latest available observations
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// DNSPolicyPeerStatus is the observed health of a peer region in Failover mode
+type DNSPolicyPeerStatus struct {
+	// Region is the peer region
+	Region string `json:"region"`
+
+	// TakenOver is true while this cluster publishes the records of the peer region
+	TakenOver bool `json:"takenOver"`
+
+	// ConsecutiveFailures is the number of failed health checks in a row, capped at the failure threshold
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// ConsecutiveSuccesses is the number of successful health checks in a row, capped at the success threshold
+	// +optional
+	ConsecutiveSuccesses int32 `json:"consecutiveSuccesses,omitempty"`
+
+	// LastProbeTime is the time of the last health check, the next one runs ProbeInterval later
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// LastTransitionTime is the time of the last takeover or handback
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Message is the result of the last health check
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=dnsp
@@ -40,6 +40,19 @@ type GatewaySpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	TargetPostfix string `json:"targetPostfix"`
+
+	// Implementation selects the generated gateway resource (Istio, GatewayAPI)
+	// Istio emits a networking.istio.io Gateway selected by the controller label,
+	// GatewayAPI emits a gateway.networking.k8s.io Gateway with one HTTPS listener per host
+	// +kubebuilder:validation:Enum=Istio;GatewayAPI
+	// +kubebuilder:default=Istio
+	// +optional
+	Implementation string `json:"implementation,omitempty"`
+
+	// GatewayClassName is the Gateway API GatewayClass to use
+	// Required when implementation is GatewayAPI
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
 }
 
 // GatewayStatus defines the observed state of Gateway
@@ -47,6 +47,74 @@ type ServiceRouteSpec struct {
 	// Application is the application name (used in DNS)
 	// +kubebuilder:validation:Required
 	Application string `json:"application"`
+
+	// Aliases are additional hostnames published alongside the generated hostname.
+	// As in DNS, names with a trailing dot are fully-qualified (e.g., "portal.example.com."),
+	// all other names are relative to the ClusterIdentity domain (e.g., "portal" or "api.v2")
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*\.?$`
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Backend configures an Istio VirtualService (or a Gateway API HTTPRoute, depending on the
+	// Gateway implementation) that routes the ServiceRoute hostnames to a Kubernetes Service.
+	// When omitted, no route is generated.
+	// +optional
+	Backend *ServiceRouteBackend `json:"backend,omitempty"`
+
+	// HealthGated withholds the DNS records until the backend Service has ready endpoints
+	// and the Gateway reports DNSReady. Published records are withdrawn again when the
+	// backend loses its last ready endpoint. Requires Backend to be set.
+	// +optional
+	HealthGated bool `json:"healthGated,omitempty"`
+
+	// TTL is the TTL in seconds of the CNAME records of this ServiceRoute
+	// Overrides the TTL of the DNSPolicy and the DNSConfiguration defaults
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+}
+
+// ServiceRouteBackend defines the Kubernetes Service traffic is routed to
+type ServiceRouteBackend struct {
+	// ServiceName is the name of the Kubernetes Service in the ServiceRoute namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ServiceName string `json:"serviceName"`
+
+	// Port is the Service port to route to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// PathPrefixes restricts routing to requests matching one of these URI prefixes
+	// If empty, all paths are routed
+	// +optional
+	PathPrefixes []string `json:"pathPrefixes,omitempty"`
+
+	// Timeout is the overall request timeout (e.g., "30s")
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries configures the retry policy for requests to the backend.
+	// Not supported when the Gateway uses the GatewayAPI implementation.
+	// +optional
+	Retries *ServiceRouteRetries `json:"retries,omitempty"`
+}
+
+// ServiceRouteRetries defines the retry policy for a backend
+type ServiceRouteRetries struct {
+	// Attempts is the number of retries for a request
+	// +kubebuilder:validation:Minimum=0
+	Attempts int32 `json:"attempts"`
+
+	// PerTryTimeout is the timeout per attempt (e.g., "2s")
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// RetryOn specifies the conditions under which retry takes place (e.g., "5xx,connect-failure")
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
 }
 
 // ServiceRouteStatus defines the observed state of ServiceRoute
@@ -55,13 +123,76 @@ type ServiceRouteStatus struct {
 	// +kubebuilder:validation:Enum=Pending;Active;Failed
 	Phase string `json:"phase,omitempty"`
 
-	// DNSEndpoint is the name of the generated DNSEndpoint resource
+	// DNSEndpoint is the name of the first generated DNSEndpoint resource
+	// Kept for compatibility, DNSEndpoints lists every generated DNSEndpoint
 	DNSEndpoint string `json:"dnsEndpoint,omitempty"`
 
+	// DNSEndpoints lists every generated DNSEndpoint with the DNS chain it publishes
+	// +optional
+	DNSEndpoints []ServiceRouteDNSEndpoint `json:"dnsEndpoints,omitempty"`
+
+	// DNSPolicyRef is the DNSPolicy or ClusterDNSPolicy in effect for the ServiceRoute
+	// +optional
+	DNSPolicyRef *ServiceRouteDNSPolicyReference `json:"dnsPolicyRef,omitempty"`
+
+	// LoadBalancerIP is the current LoadBalancer IP of the referenced Gateway,
+	// the address the target hostname resolves to
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// VirtualService is the name of the generated Istio VirtualService, if a backend is configured
+	// +optional
+	VirtualService string `json:"virtualService,omitempty"`
+
+	// HTTPRoute is the name of the generated Gateway API HTTPRoute, if a backend is configured
+	// on a Gateway that uses the GatewayAPI implementation
+	// +optional
+	HTTPRoute string `json:"httpRoute,omitempty"`
+
 	// Conditions represent the latest available observations
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ServiceRouteDNSEndpoint describes a generated DNSEndpoint and the records it publishes
+type ServiceRouteDNSEndpoint struct {
+	// Name is the name of the DNSEndpoint resource
+	Name string `json:"name"`
+
+	// Controller is the ExternalDNS controller that processes the DNSEndpoint
+	Controller string `json:"controller"`
+
+	// Region is the region of the ExternalDNS controller
+	Region string `json:"region"`
+
+	// SourceHost is the service hostname published as a CNAME
+	SourceHost string `json:"sourceHost"`
+
+	// Aliases are the additional hostnames published as CNAMEs to the same target
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// TargetHost is the gateway hostname the CNAMEs point to
+	TargetHost string `json:"targetHost"`
+
+	// Migrating is true when the DNSEndpoint publishes the hostnames of a previous cluster identity,
+	// which are withdrawn when the hostname migration ends
+	// +optional
+	Migrating bool `json:"migrating,omitempty"`
+}
+
+// ServiceRouteDNSPolicyReference identifies the policy a ServiceRoute publishes its records with
+type ServiceRouteDNSPolicyReference struct {
+	// Kind is DNSPolicy or ClusterDNSPolicy
+	Kind string `json:"kind"`
+
+	// Name is the name of the policy
+	Name string `json:"name"`
+
+	// Namespace is the namespace of a DNSPolicy, empty for a ClusterDNSPolicy
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=sr
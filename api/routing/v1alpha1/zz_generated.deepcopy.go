@@ -25,12 +25,92 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDNSPolicy) DeepCopyInto(out *ClusterDNSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNSPolicy.
+func (in *ClusterDNSPolicy) DeepCopy() *ClusterDNSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDNSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDNSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDNSPolicyList) DeepCopyInto(out *ClusterDNSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDNSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNSPolicyList.
+func (in *ClusterDNSPolicyList) DeepCopy() *ClusterDNSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDNSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDNSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDNSPolicySpec) DeepCopyInto(out *ClusterDNSPolicySpec) {
+	*out = *in
+	in.DNSPolicySpec.DeepCopyInto(&out.DNSPolicySpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNSPolicySpec.
+func (in *ClusterDNSPolicySpec) DeepCopy() *ClusterDNSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDNSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSPolicy) DeepCopyInto(out *DNSPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -52,6 +132,98 @@ func (in *DNSPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyFailover) DeepCopyInto(out *DNSPolicyFailover) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]DNSPolicyFailoverPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinHoldTime != nil {
+		in, out := &in.MinHoldTime, &out.MinHoldTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyFailover.
+func (in *DNSPolicyFailover) DeepCopy() *DNSPolicyFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyFailoverPeer) DeepCopyInto(out *DNSPolicyFailoverPeer) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(DNSPolicyHTTPGetProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lease != nil {
+		in, out := &in.Lease, &out.Lease
+		*out = new(DNSPolicyLeaseProbe)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyFailoverPeer.
+func (in *DNSPolicyFailoverPeer) DeepCopy() *DNSPolicyFailoverPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyFailoverPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyHTTPGetProbe) DeepCopyInto(out *DNSPolicyHTTPGetProbe) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyHTTPGetProbe.
+func (in *DNSPolicyHTTPGetProbe) DeepCopy() *DNSPolicyHTTPGetProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyHTTPGetProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyLeaseProbe) DeepCopyInto(out *DNSPolicyLeaseProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyLeaseProbe.
+func (in *DNSPolicyLeaseProbe) DeepCopy() *DNSPolicyLeaseProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyLeaseProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSPolicyList) DeepCopyInto(out *DNSPolicyList) {
 	*out = *in
@@ -84,9 +256,57 @@ func (in *DNSPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyPeerStatus) DeepCopyInto(out *DNSPolicyPeerStatus) {
+	*out = *in
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyPeerStatus.
+func (in *DNSPolicyPeerStatus) DeepCopy() *DNSPolicyPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSPolicySpec) DeepCopyInto(out *DNSPolicySpec) {
 	*out = *in
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(DNSPolicyFailover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RegionSelector != nil {
+		in, out := &in.RegionSelector, &out.RegionSelector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicySpec.
@@ -107,6 +327,13 @@ func (in *DNSPolicyStatus) DeepCopyInto(out *DNSPolicyStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]DNSPolicyPeerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -227,7 +454,7 @@ func (in *ServiceRoute) DeepCopyInto(out *ServiceRoute) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -249,6 +476,71 @@ func (in *ServiceRoute) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRouteBackend) DeepCopyInto(out *ServiceRouteBackend) {
+	*out = *in
+	if in.PathPrefixes != nil {
+		in, out := &in.PathPrefixes, &out.PathPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(ServiceRouteRetries)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouteBackend.
+func (in *ServiceRouteBackend) DeepCopy() *ServiceRouteBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRouteBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRouteDNSEndpoint) DeepCopyInto(out *ServiceRouteDNSEndpoint) {
+	*out = *in
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouteDNSEndpoint.
+func (in *ServiceRouteDNSEndpoint) DeepCopy() *ServiceRouteDNSEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRouteDNSEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRouteDNSPolicyReference) DeepCopyInto(out *ServiceRouteDNSPolicyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouteDNSPolicyReference.
+func (in *ServiceRouteDNSPolicyReference) DeepCopy() *ServiceRouteDNSPolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRouteDNSPolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceRouteList) DeepCopyInto(out *ServiceRouteList) {
 	*out = *in
@@ -281,9 +573,44 @@ func (in *ServiceRouteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRouteRetries) DeepCopyInto(out *ServiceRouteRetries) {
+	*out = *in
+	if in.PerTryTimeout != nil {
+		in, out := &in.PerTryTimeout, &out.PerTryTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouteRetries.
+func (in *ServiceRouteRetries) DeepCopy() *ServiceRouteRetries {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRouteRetries)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceRouteSpec) DeepCopyInto(out *ServiceRouteSpec) {
 	*out = *in
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(ServiceRouteBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRouteSpec.
@@ -299,6 +626,18 @@ func (in *ServiceRouteSpec) DeepCopy() *ServiceRouteSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceRouteStatus) DeepCopyInto(out *ServiceRouteStatus) {
 	*out = *in
+	if in.DNSEndpoints != nil {
+		in, out := &in.DNSEndpoints, &out.DNSEndpoints
+		*out = make([]ServiceRouteDNSEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSPolicyRef != nil {
+		in, out := &in.DNSPolicyRef, &out.DNSPolicyRef
+		*out = new(ServiceRouteDNSPolicyReference)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
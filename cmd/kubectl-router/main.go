@@ -0,0 +1,288 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-router is a kubectl plugin explaining the routing state of the operator.
+// Installed on the PATH it runs as "kubectl router".
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	routingcontroller "github.com/AshwinSarimin/service-router-operator/internal/controller/routing"
+	"github.com/AshwinSarimin/service-router-operator/internal/diagnose"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+)
+
+const usage = `Explain why ServiceRoutes are or are not serving.
+
+Usage:
+  kubectl router explain SERVICEROUTE [-n NAMESPACE] [-o json]
+      Walk the dependency chain of a ServiceRoute and print the first blocking reason
+  kubectl router hosts [-n NAMESPACE]
+      List the hostnames published by the ServiceRoutes
+  kubectl router gateways [-n NAMESPACE]
+      List the Gateways with their LoadBalancer IP
+  kubectl router policies [-n NAMESPACE]
+      List the DNSPolicies and ClusterDNSPolicies with their active controllers
+
+Every command accepts --kubeconfig. Tables list all namespaces unless -n is given.
+explain resolves Gateways without namespace in --default-router-gateway-namespace (istio-system).
+explain takes the --dns-backend, --rfc2136-* and --ownership-* flags of the manager, pass the values it runs with.
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(clusterv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(routingv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(externaldnsv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "explain":
+		os.Exit(explain(os.Args[2:]))
+	case "hosts", "gateways", "policies":
+		os.Exit(list(os.Args[1], os.Args[2:]))
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		os.Exit(0)
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+	os.Exit(2)
+}
+
+// options are the flags shared by every command
+type options struct {
+	fs        *flag.FlagSet
+	namespace string
+}
+
+// newOptions returns the flag set of a command with the namespace and kubeconfig flags
+func newOptions(command string) *options {
+	o := &options{fs: flag.NewFlagSet(command, flag.ContinueOnError)}
+	o.fs.Usage = func() { fmt.Fprint(o.fs.Output(), usage) }
+	o.fs.StringVar(&o.namespace, "namespace", "", "The namespace of the resources.")
+	o.fs.StringVar(&o.namespace, "n", "", "The namespace of the resources (shorthand).")
+	config.RegisterFlags(o.fs)
+	return o
+}
+
+// parse parses the command line, which may have flags before and after the positional arguments.
+// It returns the exit code when the command must not run.
+func (o *options) parse(args []string, positionalArgs int) ([]string, int, bool) {
+	positional, err := o.parseArgs(args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return nil, 0, false
+	case err != nil:
+		return nil, 2, false
+	case len(positional) != positionalArgs:
+		o.fs.Usage()
+		return nil, 2, false
+	}
+	return positional, 0, true
+}
+
+// parseArgs parses flags and collects the positional arguments between them
+func (o *options) parseArgs(args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := o.fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if o.fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, o.fs.Arg(0))
+		args = o.fs.Args()[1:]
+	}
+}
+
+// client returns a client for the cluster of the kubeconfig
+func (o *options) client() (client.Client, error) {
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+	return c, nil
+}
+
+// currentNamespace returns the namespace flag, or the namespace of the current kubeconfig context
+func (o *options) currentNamespace() (string, error) {
+	if o.namespace != "" {
+		return o.namespace, nil
+	}
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := o.fs.Lookup("kubeconfig"); kubeconfig != nil {
+		rules.ExplicitPath = kubeconfig.Value.String()
+	}
+	namespace, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).Namespace()
+	return namespace, err
+}
+
+// explain implements the explain command
+func explain(args []string) int {
+	o := newOptions("explain")
+	var output string
+	o.fs.StringVar(&output, "output", "", "The output format, empty for a table or json.")
+	o.fs.StringVar(&output, "o", "", "The output format (shorthand).")
+	defaultRouterGatewayNamespace := o.fs.String("default-router-gateway-namespace", "istio-system",
+		"The default namespace where the Router Gateway resources are located.")
+	// The records are listed and claimed as by the manager, so explain takes the same flags
+	var dnsBackendConfig dnsbackend.Config
+	dnsBackendConfig.BindFlags(o.fs)
+	var ownershipConfig ownership.Config
+	ownershipConfig.BindFlags(o.fs)
+	positional, code, ok := o.parse(args, 1)
+	if !ok {
+		return code
+	}
+	if output != "" && output != "json" {
+		fmt.Fprintf(os.Stderr, "unknown output format %q, must be json\n", output)
+		return 2
+	}
+
+	namespace, err := o.currentNamespace()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to determine the namespace: %v\n", err)
+		return 1
+	}
+	c, err := o.client()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	dnsBackend, err := dnsbackend.New(c, dnsBackendConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create DNS backend: %v\n", err)
+		return 1
+	}
+
+	diagnosis, err := (&routingcontroller.RouteDiagnoser{
+		Client:                        c,
+		DefaultRouterGatewayNamespace: *defaultRouterGatewayNamespace,
+		Identity:                      clusteridentity.NewReaderProvider(c),
+		Config:                        dnsconfiguration.NewReaderProvider(c),
+		DNSBackend:                    dnsBackend,
+		Ownership:                     ownershipConfig.Resolver(),
+	}).Diagnose(context.Background(), types.NamespacedName{Name: positional[0], Namespace: namespace})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to diagnose ServiceRoute %s/%s: %v\n", namespace, positional[0], err)
+		return 1
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(diagnosis)
+	} else {
+		err = diagnose.Write(os.Stdout, diagnosis)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// list implements the hosts, gateways and policies commands
+func list(command string, args []string) int {
+	o := newOptions(command)
+	if _, code, ok := o.parse(args, 0); !ok {
+		return code
+	}
+	c, err := o.client()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	table, err := listTable(context.Background(), c, command, o.namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to list %s: %v\n", command, err)
+		return 1
+	}
+	if err := diagnose.WriteTable(os.Stdout, table); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// listTable reads the resources of a list command, from every namespace when namespace is empty
+func listTable(ctx context.Context, c client.Client, command, namespace string) (*diagnose.Table, error) {
+	inNamespace := client.InNamespace(namespace)
+
+	switch command {
+	case "hosts":
+		var serviceRoutes routingv1alpha1.ServiceRouteList
+		if err := c.List(ctx, &serviceRoutes, inNamespace); err != nil {
+			return nil, err
+		}
+		return diagnose.HostsTable(serviceRoutes.Items), nil
+	case "gateways":
+		var gateways routingv1alpha1.GatewayList
+		if err := c.List(ctx, &gateways, inNamespace); err != nil {
+			return nil, err
+		}
+		return diagnose.GatewaysTable(gateways.Items), nil
+	}
+
+	var dnsPolicies routingv1alpha1.DNSPolicyList
+	if err := c.List(ctx, &dnsPolicies, inNamespace); err != nil {
+		return nil, err
+	}
+	// ClusterDNSPolicies apply to every namespace, so they are listed regardless of the namespace
+	var clusterPolicies routingv1alpha1.ClusterDNSPolicyList
+	if err := c.List(ctx, &clusterPolicies); err != nil {
+		return nil, err
+	}
+	return diagnose.PoliciesTable(dnsPolicies.Items, clusterPolicies.Items), nil
+}
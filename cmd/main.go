@@ -17,7 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -26,16 +28,30 @@ import (
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
 	clustercontroller "github.com/AshwinSarimin/service-router-operator/internal/controller/cluster"
 	routingcontroller "github.com/AshwinSarimin/service-router-operator/internal/controller/routing"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsexport"
+	routermetrics "github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+	clusterwebhook "github.com/AshwinSarimin/service-router-operator/internal/webhook/cluster/v1alpha1"
+	routingwebhook "github.com/AshwinSarimin/service-router-operator/internal/webhook/routing/v1alpha1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
@@ -56,13 +72,55 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export-dns" {
+		os.Exit(exportDNS(os.Args[2:]))
+	}
+
 	var metricsAddr string
+	var dnsExportAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var defaultRouterGatewayNamespace string
+	var enableGatewayAPI bool
+	var enableWebhooks bool
+	var webhookCertPath string
+	var webhookPort int
+	var dnsBackendConfig dnsbackend.Config
+	var enableIdentityDiscovery bool
+	var identityDiscovery clusteridentity.DiscoveryConfig
+	var regionCodes string
+	var ownershipConfig ownership.Config
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&dnsExportAddr, "dns-export-bind-address", "0",
+		"The address the DNS record export binds to. Use 127.0.0.1 to only reach it through kubectl port-forward. "+
+			"Set to 0 to disable the export.")
 	flag.StringVar(&defaultRouterGatewayNamespace, "default-router-gateway-namespace", "istio-system", "The default namespace where the Router Gateway resources are located.")
+	flag.BoolVar(&enableGatewayAPI, "enable-gateway-api", false,
+		"Allow Gateways to use the GatewayAPI implementation. Requires the Gateway API CRDs to be installed.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating and defaulting admission webhooks. Requires a serving certificate.")
+	flag.StringVar(&webhookCertPath, "webhook-cert-path", "",
+		"The directory that contains the webhook serving certificate (tls.crt and tls.key).")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server listens on.")
+	dnsBackendConfig.BindFlags(flag.CommandLine)
+	flag.BoolVar(&enableIdentityDiscovery, "identity-discovery", false,
+		"Derive the ClusterIdentity region and cluster from the node topology labels, creating it when missing.")
+	flag.StringVar(&identityDiscovery.Name, "identity-discovery-name", "cluster-identity",
+		"The name of the ClusterIdentity created by identity discovery.")
+	flag.StringVar(&identityDiscovery.RegionLabel, "identity-discovery-region-label", clusteridentity.DefaultRegionLabel,
+		"The node label holding the region.")
+	flag.StringVar(&regionCodes, "identity-discovery-region-codes", "",
+		"Comma separated label=code pairs mapping region label values to region codes, e.g. northeurope=neu,westeurope=weu.")
+	flag.StringVar(&identityDiscovery.ClusterLabel, "identity-discovery-cluster-label", "",
+		"The label holding the cluster identifier. The cluster is not discovered when empty.")
+	flag.StringVar(&identityDiscovery.ClusterLabelSource, "identity-discovery-cluster-label-source", clusteridentity.ClusterLabelSourceNode,
+		"Where the cluster label is read: node or kube-system.")
+	flag.StringVar(&identityDiscovery.Domain, "identity-discovery-domain", "",
+		"The domain of a ClusterIdentity created by identity discovery.")
+	flag.StringVar(&identityDiscovery.EnvironmentLetter, "identity-discovery-environment-letter", "",
+		"The environment letter of a ClusterIdentity created by identity discovery.")
+	ownershipConfig.BindFlags(flag.CommandLine)
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -80,36 +138,96 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "3afd9a04.router.io",
+		// The health gate watches EndpointSlices, cache only what it reads
+		Cache: cache.Options{ByObject: map[client.Object]cache.ByObject{
+			&discoveryv1.EndpointSlice{}: routingcontroller.EndpointSliceCache(),
+		}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
 		// speeds up voluntary leader transitions as the new leader don't have to wait
 		// LeaseDuration time first.
 		// LeaderElectionReleaseOnCancel: true,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertPath,
+		}),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	dnsBackend, err := dnsbackend.New(mgr.GetClient(), dnsBackendConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create DNS backend")
+		os.Exit(1)
+	}
+	setupLog.Info("Publishing DNS records", "backend", dnsBackendConfig.Backend)
+	metrics.Registry.MustRegister(routermetrics.NewStateCollector(mgr.GetCache(), dnsBackend))
+
+	ownershipResolver := ownershipConfig.Resolver()
+	if ownershipConfig.Enabled {
+		setupLog.Info("Checking hostname ownership before publishing in other regions",
+			"nameserver", ownershipConfig.Nameserver)
+	}
+
+	var discovery *clusteridentity.DiscoveryConfig
+	if enableIdentityDiscovery {
+		identityDiscovery.RegionCodes, err = clusteridentity.ParseRegionCodes(regionCodes)
+		if err != nil {
+			setupLog.Error(err, "invalid identity discovery region codes")
+			os.Exit(1)
+		}
+		discovery = &identityDiscovery
+		setupLog.Info("Discovering the cluster identity from node labels", "regionLabel", identityDiscovery.RegionLabel)
+	}
+
+	// Every reconciler reads the cluster identity and DNS configuration through the same
+	// providers, which are backed by the manager's informer cache
+	identityProvider := clusteridentity.NewProvider(mgr.GetCache())
+	configProvider := dnsconfiguration.NewProvider(mgr.GetCache())
+
 	if err = (&clustercontroller.ClusterIdentityReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Discovery: discovery,
+		Config:    configProvider,
+		Recorder:  mgr.GetEventRecorder("clusteridentity-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterIdentity")
 		os.Exit(1)
 	}
 	if err = (&routingcontroller.DNSPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Identity: identityProvider,
+		Config:   configProvider,
+		Recorder: mgr.GetEventRecorder("dnspolicy-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DNSPolicy")
 		os.Exit(1)
 	}
+	if err = (&routingcontroller.ClusterDNSPolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Identity: identityProvider,
+		Config:   configProvider,
+		Recorder: mgr.GetEventRecorder("clusterdnspolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterDNSPolicy")
+		os.Exit(1)
+	}
 	if err = (&routingcontroller.ServiceRouteReconciler{
 		Client:                        mgr.GetClient(),
 		Scheme:                        mgr.GetScheme(),
 		DefaultRouterGatewayNamespace: defaultRouterGatewayNamespace,
+		GatewayAPIEnabled:             enableGatewayAPI,
+		DNSBackend:                    dnsBackend,
+		Identity:                      identityProvider,
+		Config:                        configProvider,
+		Ownership:                     ownershipResolver,
+		Recorder:                      mgr.GetEventRecorder("serviceroute-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ServiceRoute")
 		os.Exit(1)
@@ -118,26 +236,85 @@ func main() {
 		Client:                        mgr.GetClient(),
 		Scheme:                        mgr.GetScheme(),
 		DefaultRouterGatewayNamespace: defaultRouterGatewayNamespace,
+		GatewayAPIEnabled:             enableGatewayAPI,
+		Identity:                      identityProvider,
+		Config:                        configProvider,
+		DNSBackend:                    dnsBackend,
+		Recorder:                      mgr.GetEventRecorder("gateway-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Gateway")
 		os.Exit(1)
 	}
 	if err = (&routingcontroller.IngressDNSReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		DNSBackend: dnsBackend,
+		Identity:   identityProvider,
+		Config:     configProvider,
+		Recorder:   mgr.GetEventRecorder("ingress-dns-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IngressDNS")
 		os.Exit(1)
 	}
 	if err = (&clustercontroller.DNSConfigurationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("dnsconfiguration-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DNSConfiguration")
 		os.Exit(1)
 	}
+
+	// Webhooks share their validation rules with the reconcilers, so invalid objects
+	// are rejected on admission instead of surfacing later as Failed.
+	if enableWebhooks {
+		if err = clusterwebhook.SetupClusterIdentityWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterIdentity")
+			os.Exit(1)
+		}
+		if err = clusterwebhook.SetupDNSConfigurationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DNSConfiguration")
+			os.Exit(1)
+		}
+		if err = routingwebhook.SetupClusterDNSPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterDNSPolicy")
+			os.Exit(1)
+		}
+		if err = routingwebhook.SetupDNSPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DNSPolicy")
+			os.Exit(1)
+		}
+		if err = routingwebhook.SetupGatewayWebhookWithManager(mgr, enableGatewayAPI); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Gateway")
+			os.Exit(1)
+		}
+		if err = routingwebhook.SetupServiceRouteWebhookWithManager(mgr, defaultRouterGatewayNamespace); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ServiceRoute")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	// Serve the complete desired record set on its own listener, never on the unauthenticated metrics endpoint
+	if dnsExportAddr != "0" {
+		if err := mgr.Add(&dnsexport.Server{
+			BindAddress: dnsExportAddr,
+			Handler: &dnsexport.Handler{
+				Source: &routingcontroller.RecordExporter{
+					Client:                        mgr.GetClient(),
+					DefaultRouterGatewayNamespace: defaultRouterGatewayNamespace,
+					Identity:                      identityProvider,
+					Config:                        configProvider,
+					DNSBackend:                    dnsBackend,
+					Ownership:                     ownershipResolver,
+				},
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up DNS record export")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -153,3 +330,60 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// exportDNS implements the export-dns subcommand, which prints every DNS record
+// the operator publishes as a zone file or JSON, computed from the cluster state.
+func exportDNS(args []string) int {
+	fs := flag.NewFlagSet("export-dns", flag.ContinueOnError)
+	format := fs.String("format", dnsexport.FormatZone, "The output format, zone or json.")
+	controller := fs.String("controller", "", "Only export the records of this ExternalDNS controller.")
+	defaultRouterGatewayNamespace := fs.String("default-router-gateway-namespace", "istio-system",
+		"The default namespace where the Router Gateway resources are located.")
+	// The export must read and claim records the way the manager does, so it takes the same flags
+	var dnsBackendConfig dnsbackend.Config
+	dnsBackendConfig.BindFlags(fs)
+	var ownershipConfig ownership.Config
+	ownershipConfig.BindFlags(fs)
+	config.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load kubeconfig: %v\n", err)
+		return 1
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create client: %v\n", err)
+		return 1
+	}
+
+	dnsBackend, err := dnsbackend.New(c, dnsBackendConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create DNS backend: %v\n", err)
+		return 1
+	}
+	export, err := (&routingcontroller.RecordExporter{
+		Client:                        c,
+		DefaultRouterGatewayNamespace: *defaultRouterGatewayNamespace,
+		Identity:                      clusteridentity.NewReaderProvider(c),
+		Config:                        dnsconfiguration.NewReaderProvider(c),
+		DNSBackend:                    dnsBackend,
+		Ownership:                     ownershipConfig.Resolver(),
+	}).Export(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to export DNS records: %v\n", err)
+		return 1
+	}
+	if *controller != "" {
+		export = export.FilterController(*controller)
+	}
+
+	if err := dnsexport.Write(os.Stdout, export, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+const (
+	// DefaultRegionLabel is the well-known node label holding the cloud region
+	DefaultRegionLabel = "topology.kubernetes.io/region"
+
+	// ClusterLabelSourceNode reads the cluster label from the nodes
+	ClusterLabelSourceNode = "node"
+
+	// ClusterLabelSourceKubeSystem reads the cluster label from the kube-system namespace
+	ClusterLabelSourceKubeSystem = "kube-system"
+
+	// DiscoveredAnnotation marks a ClusterIdentity created by discovery.
+	// Discovery keeps the region and cluster of such a ClusterIdentity up to date,
+	// a ClusterIdentity declared by hand is only compared with the discovered values.
+	DiscoveredAnnotation = "cluster.router.io/discovered"
+)
+
+// DiscoveryConfig configures how the cluster identity is discovered from the cluster topology
+type DiscoveryConfig struct {
+	// Name is the name of the ClusterIdentity created by discovery
+	Name string
+
+	// RegionLabel is the node label holding the region, defaults to DefaultRegionLabel
+	RegionLabel string
+
+	// RegionCodes maps region label values to region codes (e.g. northeurope -> neu).
+	// When empty, the label value is used as region as is.
+	RegionCodes map[string]string
+
+	// ClusterLabel is the label holding the cluster identifier, the cluster is not discovered when empty
+	ClusterLabel string
+
+	// ClusterLabelSource is where ClusterLabel is read, node (default) or kube-system
+	ClusterLabelSource string
+
+	// Domain and EnvironmentLetter cannot be discovered, they are used as is
+	// when discovery creates the ClusterIdentity
+	Domain            string
+	EnvironmentLetter string
+}
+
+// Discovered holds the identity values derived from the cluster topology
+type Discovered struct {
+	Region  string
+	Cluster string
+}
+
+// Validate checks the discovery settings
+func (c *DiscoveryConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("identity discovery requires a ClusterIdentity name")
+	}
+	switch c.ClusterLabelSource {
+	case "", ClusterLabelSourceNode, ClusterLabelSourceKubeSystem:
+	default:
+		return fmt.Errorf("invalid cluster label source %q, must be %s or %s",
+			c.ClusterLabelSource, ClusterLabelSourceNode, ClusterLabelSourceKubeSystem)
+	}
+	return nil
+}
+
+// CanCreate returns an error naming the settings missing to create a ClusterIdentity from discovery
+func (c *DiscoveryConfig) CanCreate() error {
+	var missing []string
+	if c.ClusterLabel == "" {
+		missing = append(missing, "cluster label")
+	}
+	if c.Domain == "" {
+		missing = append(missing, "domain")
+	}
+	if c.EnvironmentLetter == "" {
+		missing = append(missing, "environment letter")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("creating a ClusterIdentity from discovery requires a %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ParseRegionCodes parses a comma separated list of label=code pairs,
+// e.g. "northeurope=neu,westeurope=weu"
+func ParseRegionCodes(value string) (map[string]string, error) {
+	codes := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		label, code, found := strings.Cut(pair, "=")
+		label, code = strings.TrimSpace(label), strings.TrimSpace(code)
+		if !found || label == "" || code == "" {
+			return nil, fmt.Errorf("invalid region code mapping %q, must be label=code", pair)
+		}
+		if _, exists := codes[label]; exists {
+			return nil, fmt.Errorf("region %s is mapped more than once", label)
+		}
+		codes[label] = code
+	}
+	return codes, nil
+}
+
+// Discover derives the cluster identity from the node labels and, when configured,
+// the kube-system namespace labels
+func Discover(ctx context.Context, c client.Client, cfg *DiscoveryConfig) (*Discovered, error) {
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	regionLabel := cfg.RegionLabel
+	if regionLabel == "" {
+		regionLabel = DefaultRegionLabel
+	}
+	regionValue, err := nodeLabelValue(nodes.Items, regionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := &Discovered{Region: regionValue}
+	if len(cfg.RegionCodes) > 0 {
+		code, ok := cfg.RegionCodes[regionValue]
+		if !ok {
+			return nil, fmt.Errorf("region %s has no region code mapping", regionValue)
+		}
+		discovered.Region = code
+	}
+
+	if cfg.ClusterLabel == "" {
+		return discovered, nil
+	}
+
+	if cfg.ClusterLabelSource == ClusterLabelSourceKubeSystem {
+		var ns corev1.Namespace
+		if err := c.Get(ctx, client.ObjectKey{Name: "kube-system"}, &ns); err != nil {
+			return nil, fmt.Errorf("failed to get namespace kube-system: %w", err)
+		}
+		cluster, ok := ns.Labels[cfg.ClusterLabel]
+		if !ok || cluster == "" {
+			return nil, fmt.Errorf("namespace kube-system has no label %s", cfg.ClusterLabel)
+		}
+		discovered.Cluster = cluster
+		return discovered, nil
+	}
+
+	discovered.Cluster, err = nodeLabelValue(nodes.Items, cfg.ClusterLabel)
+	if err != nil {
+		return nil, err
+	}
+	return discovered, nil
+}
+
+// nodeLabelValue returns the value of a label all labelled nodes agree on.
+// Nodes without the label are ignored, nodes disagreeing on the value are an error
+// because they leave the identity ambiguous.
+func nodeLabelValue(nodes []corev1.Node, label string) (string, error) {
+	values := make(map[string]bool)
+	for _, node := range nodes {
+		if value := node.Labels[label]; value != "" {
+			values[value] = true
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return "", fmt.Errorf("no node has label %s", label)
+	case 1:
+		for value := range values {
+			return value, nil
+		}
+	}
+
+	distinct := make([]string, 0, len(values))
+	for value := range values {
+		distinct = append(distinct, value)
+	}
+	sort.Strings(distinct)
+	return "", fmt.Errorf("nodes disagree on label %s: %s", label, strings.Join(distinct, ", "))
+}
+
+// Mismatches describes where a ClusterIdentity spec differs from the discovered values.
+// A value that was not discovered is not compared.
+func Mismatches(spec *clusterv1alpha1.ClusterIdentitySpec, discovered *Discovered) []string {
+	var mismatches []string
+	if discovered.Region != "" && spec.Region != discovered.Region {
+		mismatches = append(mismatches, fmt.Sprintf("region is %s but the nodes are in %s", spec.Region, discovered.Region))
+	}
+	if discovered.Cluster != "" && spec.Cluster != discovered.Cluster {
+		mismatches = append(mismatches, fmt.Sprintf("cluster is %s but the cluster label is %s", spec.Cluster, discovered.Cluster))
+	}
+	return mismatches
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+// testSchemes are the API groups of the fake clients of the tests
+var testSchemes = runtime.SchemeBuilder{corev1.AddToScheme, clusterv1alpha1.AddToScheme}
+
+func TestParseRegionCodes(t *testing.T) {
+	codes, err := ParseRegionCodes(" northeurope=neu, westeurope=weu ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != 2 || codes["northeurope"] != "neu" || codes["westeurope"] != "weu" {
+		t.Errorf("unexpected region codes: %v", codes)
+	}
+
+	codes, err = ParseRegionCodes("")
+	if err != nil || len(codes) != 0 {
+		t.Errorf("expected no region codes, got %v, %v", codes, err)
+	}
+
+	for _, value := range []string{"northeurope", "northeurope=", "=neu", "northeurope=neu,northeurope=weu"} {
+		if _, err := ParseRegionCodes(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestDiscoveryConfigValidate(t *testing.T) {
+	cfg := &DiscoveryConfig{Name: "cluster-identity"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg.ClusterLabelSource = "configmap"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown cluster label source")
+	}
+
+	if err := (&DiscoveryConfig{}).Validate(); err == nil {
+		t.Error("expected error for missing name")
+	}
+
+	err := (&DiscoveryConfig{Name: "cluster-identity", Domain: "example.com"}).CanCreate()
+	if err == nil || !strings.Contains(err.Error(), "cluster label, environment letter") {
+		t.Errorf("expected the missing settings to be named, got %v", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	ctx := context.Background()
+	regionCodes := map[string]string{"westeurope": "weu"}
+
+	tests := []struct {
+		name    string
+		cfg     DiscoveryConfig
+		objs    []client.Object
+		want    Discovered
+		wantErr string
+	}{
+		{
+			name: "region from the default label without mapping",
+			objs: []client.Object{
+				newNode("node-1", map[string]string{DefaultRegionLabel: "westeurope"}),
+			},
+			want: Discovered{Region: "westeurope"},
+		},
+		{
+			name: "region mapped to a region code and cluster from the nodes",
+			cfg:  DiscoveryConfig{RegionCodes: regionCodes, ClusterLabel: "example.com/cluster"},
+			objs: []client.Object{
+				newNode("node-1", map[string]string{DefaultRegionLabel: "westeurope", "example.com/cluster": "aks"}),
+				newNode("node-2", map[string]string{DefaultRegionLabel: "westeurope", "example.com/cluster": "aks"}),
+				newNode("virtual-node", nil),
+			},
+			want: Discovered{Region: "weu", Cluster: "aks"},
+		},
+		{
+			name: "cluster from the kube-system namespace",
+			cfg: DiscoveryConfig{
+				RegionLabel:        "example.com/region",
+				ClusterLabel:       "example.com/cluster",
+				ClusterLabelSource: ClusterLabelSourceKubeSystem,
+			},
+			objs: []client.Object{
+				newNode("node-1", map[string]string{"example.com/region": "neu"}),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   "kube-system",
+					Labels: map[string]string{"example.com/cluster": "aks02"},
+				}},
+			},
+			want: Discovered{Region: "neu", Cluster: "aks02"},
+		},
+		{
+			name:    "no labelled node",
+			objs:    []client.Object{newNode("node-1", nil)},
+			wantErr: "no node has label topology.kubernetes.io/region",
+		},
+		{
+			name: "nodes in several regions",
+			objs: []client.Object{
+				newNode("node-1", map[string]string{DefaultRegionLabel: "westeurope"}),
+				newNode("node-2", map[string]string{DefaultRegionLabel: "northeurope"}),
+			},
+			wantErr: "nodes disagree on label topology.kubernetes.io/region: northeurope, westeurope",
+		},
+		{
+			name: "region without mapping",
+			cfg:  DiscoveryConfig{RegionCodes: regionCodes},
+			objs: []client.Object{
+				newNode("node-1", map[string]string{DefaultRegionLabel: "francecentral"}),
+			},
+			wantErr: "region francecentral has no region code mapping",
+		},
+		{
+			name: "kube-system without the cluster label",
+			cfg:  DiscoveryConfig{ClusterLabel: "example.com/cluster", ClusterLabelSource: ClusterLabelSourceKubeSystem},
+			objs: []client.Object{
+				newNode("node-1", map[string]string{DefaultRegionLabel: "westeurope"}),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+			},
+			wantErr: "namespace kube-system has no label example.com/cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovered, err := Discover(ctx, testutil.NewFakeClient(t, testSchemes, tt.objs...), &tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *discovered != tt.want {
+				t.Errorf("got %+v, want %+v", *discovered, tt.want)
+			}
+		})
+	}
+}
+
+func TestMismatches(t *testing.T) {
+	spec := &clusterv1alpha1.ClusterIdentitySpec{Region: "weu", Cluster: "aks"}
+
+	if mismatches := Mismatches(spec, &Discovered{Region: "weu", Cluster: "aks"}); len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %v", mismatches)
+	}
+	if mismatches := Mismatches(spec, &Discovered{Region: "weu"}); len(mismatches) != 0 {
+		t.Errorf("expected an undiscovered cluster to be ignored, got %v", mismatches)
+	}
+
+	mismatches := Mismatches(spec, &Discovered{Region: "neu", Cluster: "aks02"})
+	want := []string{
+		"region is weu but the nodes are in neu",
+		"cluster is aks but the cluster label is aks02",
+	}
+	if strings.Join(mismatches, "|") != strings.Join(want, "|") {
+		t.Errorf("got %v, want %v", mismatches, want)
+	}
+}
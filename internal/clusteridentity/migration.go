@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+// HostnamesChanged reports whether the hostnames rendered from two identities differ.
+// AdoptsRegions and the grace period do not take part in hostnames.
+func HostnamesChanged(a, b *clusterv1alpha1.ClusterIdentitySpec) bool {
+	return a.Region != b.Region ||
+		a.Cluster != b.Cluster ||
+		a.Domain != b.Domain ||
+		a.EnvironmentLetter != b.EnvironmentLetter ||
+		a.SourceHostTemplate != b.SourceHostTemplate ||
+		a.TargetHostTemplate != b.TargetHostTemplate
+}
+
+// MigrationGracePeriod returns how long the hostnames of a previous identity stay published,
+// 0 when hostname migration is disabled
+func MigrationGracePeriod(spec *clusterv1alpha1.ClusterIdentitySpec) time.Duration {
+	if spec.MigrationGracePeriod == nil || spec.MigrationGracePeriod.Duration < 0 {
+		return 0
+	}
+	return spec.MigrationGracePeriod.Duration
+}
+
+// UpdateMigrations starts a hostname migration when the hostname fields of the spec differ from the
+// published identity, ends the migrations that expired or whose identity is current again, and records
+// the spec as the published identity. It returns the time until the next migration ends,
+// 0 when no migration is in progress.
+func UpdateMigrations(cr *clusterv1alpha1.ClusterIdentity, now time.Time) time.Duration {
+	status := &cr.Status
+
+	if published := status.PublishedIdentity; published != nil && HostnamesChanged(published, &cr.Spec) {
+		if gracePeriod := MigrationGracePeriod(&cr.Spec); gracePeriod > 0 {
+			status.Migrations = append(status.Migrations, clusterv1alpha1.ClusterIdentityMigration{
+				Identity:  *published.DeepCopy(),
+				StartedAt: metav1.NewTime(now),
+				ExpiresAt: metav1.NewTime(now.Add(gracePeriod)),
+			})
+		}
+	}
+
+	var next time.Duration
+	migrations := status.Migrations[:0]
+	for _, migration := range status.Migrations {
+		remaining := migration.ExpiresAt.Sub(now)
+		if remaining <= 0 || !HostnamesChanged(&migration.Identity, &cr.Spec) {
+			continue
+		}
+		migrations = append(migrations, migration)
+		if next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+	if len(migrations) == 0 {
+		migrations = nil
+	}
+	status.Migrations = migrations
+	status.PublishedIdentity = cr.Spec.DeepCopy()
+
+	return next
+}
+
+// Previous returns the previous identities whose hostnames are still published at now, oldest first.
+// A spec change the ClusterIdentity controller has not recorded yet is included as well, so the old
+// hostnames are never withdrawn between the change and the start of its migration.
+func Previous(cr *clusterv1alpha1.ClusterIdentity, now time.Time) []*ClusterIdentity {
+	var previous []*ClusterIdentity
+	seen := func(spec *clusterv1alpha1.ClusterIdentitySpec) bool {
+		if !HostnamesChanged(spec, &cr.Spec) {
+			return true
+		}
+		for _, identity := range previous {
+			if !HostnamesChanged((*clusterv1alpha1.ClusterIdentitySpec)(identity), spec) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range cr.Status.Migrations {
+		migration := &cr.Status.Migrations[i]
+		if !migration.ExpiresAt.After(now) || seen(&migration.Identity) {
+			continue
+		}
+		identity := ClusterIdentity(*migration.Identity.DeepCopy())
+		previous = append(previous, &identity)
+	}
+
+	if published := cr.Status.PublishedIdentity; published != nil && MigrationGracePeriod(&cr.Spec) > 0 && !seen(published) {
+		identity := ClusterIdentity(*published.DeepCopy())
+		previous = append(previous, &identity)
+	}
+
+	return previous
+}
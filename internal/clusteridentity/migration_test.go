@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+func migratingIdentity(gracePeriod time.Duration) *clusterv1alpha1.ClusterIdentity {
+	cr := newClusterIdentity("cluster-identity", "neu")
+	cr.Spec.MigrationGracePeriod = &metav1.Duration{Duration: gracePeriod}
+	return cr
+}
+
+func TestHostnamesChanged(t *testing.T) {
+	spec := newClusterIdentity("cluster-identity", "neu").Spec
+
+	other := *spec.DeepCopy()
+	other.AdoptsRegions = []string{"frc"}
+	other.MigrationGracePeriod = &metav1.Duration{Duration: time.Hour}
+	if HostnamesChanged(&spec, &other) {
+		t.Error("expected adopted regions and grace period to leave hostnames unchanged")
+	}
+
+	other.TargetHostTemplate = "{{.Cluster}}.{{.Domain}}"
+	if !HostnamesChanged(&spec, &other) {
+		t.Error("expected a target host template change to change hostnames")
+	}
+}
+
+func TestUpdateMigrations(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cr := migratingIdentity(time.Hour)
+
+	if next := UpdateMigrations(cr, now); next != 0 || len(cr.Status.Migrations) != 0 {
+		t.Fatalf("expected no migration for a new identity, got %v, %v", next, cr.Status.Migrations)
+	}
+	if cr.Status.PublishedIdentity == nil || cr.Status.PublishedIdentity.Region != "neu" {
+		t.Fatalf("expected the spec to be published, got %+v", cr.Status.PublishedIdentity)
+	}
+
+	cr.Spec.Region = "weu"
+	next := UpdateMigrations(cr, now)
+	if next != time.Hour || len(cr.Status.Migrations) != 1 {
+		t.Fatalf("expected a migration ending in an hour, got %v, %v", next, cr.Status.Migrations)
+	}
+	migration := cr.Status.Migrations[0]
+	if migration.Identity.Region != "neu" || !migration.ExpiresAt.Time.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected migration: %+v", migration)
+	}
+
+	// Reconciling again does not start another migration
+	if next := UpdateMigrations(cr, now.Add(10*time.Minute)); next != 50*time.Minute || len(cr.Status.Migrations) != 1 {
+		t.Errorf("expected the migration to continue, got %v, %v", next, cr.Status.Migrations)
+	}
+
+	if next := UpdateMigrations(cr, now.Add(time.Hour)); next != 0 || cr.Status.Migrations != nil {
+		t.Errorf("expected the migration to end, got %v, %v", next, cr.Status.Migrations)
+	}
+}
+
+func TestUpdateMigrationsRevert(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cr := migratingIdentity(time.Hour)
+	UpdateMigrations(cr, now)
+
+	cr.Spec.Region = "weu"
+	UpdateMigrations(cr, now)
+
+	// Changing back ends the migration, its hostnames are current again
+	cr.Spec.Region = "neu"
+	UpdateMigrations(cr, now)
+	if len(cr.Status.Migrations) != 1 || cr.Status.Migrations[0].Identity.Region != "weu" {
+		t.Errorf("expected only the weu identity to migrate, got %+v", cr.Status.Migrations)
+	}
+}
+
+func TestUpdateMigrationsDisabled(t *testing.T) {
+	cr := newClusterIdentity("cluster-identity", "neu")
+	UpdateMigrations(cr, time.Now())
+
+	cr.Spec.Region = "weu"
+	if next := UpdateMigrations(cr, time.Now()); next != 0 || len(cr.Status.Migrations) != 0 {
+		t.Errorf("expected no migration without a grace period, got %v, %v", next, cr.Status.Migrations)
+	}
+	if cr.Status.PublishedIdentity.Region != "weu" {
+		t.Errorf("expected the new identity to be published, got %+v", cr.Status.PublishedIdentity)
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cr := migratingIdentity(time.Hour)
+	UpdateMigrations(cr, now)
+
+	// A spec change is reported before the controller starts its migration
+	cr.Spec.Region = "weu"
+	previous := Previous(cr, now)
+	if len(previous) != 1 || previous[0].Region != "neu" {
+		t.Fatalf("expected the published identity, got %+v", previous)
+	}
+
+	UpdateMigrations(cr, now)
+	previous = Previous(cr, now)
+	if len(previous) != 1 || previous[0].Region != "neu" {
+		t.Fatalf("expected the migrating identity once, got %+v", previous)
+	}
+
+	if previous := Previous(cr, now.Add(time.Hour)); len(previous) != 0 {
+		t.Errorf("expected an expired migration to be ignored, got %+v", previous)
+	}
+
+	cr.Spec.MigrationGracePeriod = nil
+	cr.Spec.Region = "frc"
+	if previous := Previous(cr, now); len(previous) != 1 || previous[0].Region != "neu" {
+		t.Errorf("expected only the recorded migration without a grace period, got %+v", previous)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+// ClusterIdentity holds the cluster's regional identity information.
+// It has the fields of the ClusterIdentity spec, so new spec fields are carried over without copying.
+type ClusterIdentity clusterv1alpha1.ClusterIdentitySpec
+
+// IdentityProvider gives reconcilers and webhooks the identity of the cluster
+type IdentityProvider interface {
+	// Identity returns the identity of the cluster, or nil when no ClusterIdentity exists.
+	// The returned value is a copy the caller may modify.
+	Identity(ctx context.Context) (*ClusterIdentity, error)
+
+	// PreviousIdentities returns the identities whose hostnames are still published during a
+	// hostname migration, oldest first, see Previous. The returned values are copies.
+	PreviousIdentities(ctx context.Context) ([]*ClusterIdentity, error)
+
+	// Watch returns a controller source passing every ClusterIdentity creation,
+	// update and deletion to the handler
+	Watch(h handler.EventHandler) source.Source
+}
+
+// provider reads the ClusterIdentity through a client.Reader
+type provider struct {
+	reader client.Reader
+	cache  cache.Cache
+}
+
+// NewProvider returns an IdentityProvider backed by the informer cache of a manager.
+// Every manager has its own cache, so managers in one process do not share identities.
+func NewProvider(c cache.Cache) IdentityProvider {
+	return &provider{reader: c, cache: c}
+}
+
+// NewReaderProvider returns an IdentityProvider reading the ClusterIdentity with a plain reader,
+// for callers without a manager such as the export-dns command and tests. Its Watch source never fires.
+func NewReaderProvider(reader client.Reader) IdentityProvider {
+	return &provider{reader: reader}
+}
+
+// Identity implements IdentityProvider
+func (p *provider) Identity(ctx context.Context) (*ClusterIdentity, error) {
+	current, err := p.current(ctx)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	identity := ClusterIdentity(*current.Spec.DeepCopy())
+	return &identity, nil
+}
+
+// PreviousIdentities implements IdentityProvider
+func (p *provider) PreviousIdentities(ctx context.Context) ([]*ClusterIdentity, error) {
+	current, err := p.current(ctx)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	return Previous(current, time.Now()), nil
+}
+
+// current returns the ClusterIdentity of the cluster, or nil when none exists.
+// More than one ClusterIdentity is an error, the identity of the cluster is then ambiguous.
+func (p *provider) current(ctx context.Context) (*clusterv1alpha1.ClusterIdentity, error) {
+	var clusterIdentities clusterv1alpha1.ClusterIdentityList
+	if err := p.reader.List(ctx, &clusterIdentities); err != nil {
+		return nil, err
+	}
+
+	var current []clusterv1alpha1.ClusterIdentity
+	for _, item := range clusterIdentities.Items {
+		if item.DeletionTimestamp == nil {
+			current = append(current, item)
+		}
+	}
+
+	switch len(current) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &current[0], nil
+	}
+	return nil, fmt.Errorf("found %d ClusterIdentities, only one is allowed per cluster", len(current))
+}
+
+// Watch implements IdentityProvider
+func (p *provider) Watch(h handler.EventHandler) source.Source {
+	if p.cache == nil {
+		return source.Func(func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			return nil
+		})
+	}
+	return source.Kind[client.Object](p.cache, &clusterv1alpha1.ClusterIdentity{}, h)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteridentity
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+func newClusterIdentity(name, region string) *clusterv1alpha1.ClusterIdentity {
+	return &clusterv1alpha1.ClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clusterv1alpha1.ClusterIdentitySpec{
+			Region:            region,
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+			AdoptsRegions:     []string{"weu"},
+		},
+	}
+}
+
+func TestProviderIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	identity, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes)).Identity(ctx)
+	if err != nil || identity != nil {
+		t.Fatalf("expected no identity without a ClusterIdentity, got %+v, %v", identity, err)
+	}
+
+	p := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, newClusterIdentity("cluster-identity", "neu")))
+	identity, err = p.Identity(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.Region != "neu" || identity.Cluster != "aks" || identity.Domain != "example.com" ||
+		identity.EnvironmentLetter != "d" || len(identity.AdoptsRegions) != 1 {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	// The returned identity is a copy, changing it does not affect the next caller
+	identity.Region = "weu"
+	identity.AdoptsRegions[0] = "frc"
+	identity, err = p.Identity(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.Region != "neu" || identity.AdoptsRegions[0] != "weu" {
+		t.Errorf("expected an unmodified identity, got %+v", identity)
+	}
+}
+
+func TestProviderIdentityAmbiguous(t *testing.T) {
+	objs := []client.Object{newClusterIdentity("first", "neu"), newClusterIdentity("second", "weu")}
+
+	_, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, objs...)).Identity(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "found 2 ClusterIdentities") {
+		t.Errorf("expected an error for two ClusterIdentities, got %v", err)
+	}
+}
+
+func TestProviderPreviousIdentities(t *testing.T) {
+	cr := newClusterIdentity("cluster-identity", "weu")
+	cr.Spec.MigrationGracePeriod = &metav1.Duration{Duration: time.Hour}
+	cr.Status.Migrations = []clusterv1alpha1.ClusterIdentityMigration{{
+		Identity:  newClusterIdentity("cluster-identity", "neu").Spec,
+		StartedAt: metav1.Now(),
+		ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+	}}
+
+	previous, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, cr)).PreviousIdentities(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(previous) != 1 || previous[0].Region != "neu" {
+		t.Errorf("expected the migrating identity, got %+v", previous)
+	}
+
+	previous, err = NewReaderProvider(testutil.NewFakeClient(t, testSchemes)).PreviousIdentities(context.Background())
+	if err != nil || previous != nil {
+		t.Errorf("expected no previous identities without a ClusterIdentity, got %+v, %v", previous, err)
+	}
+}
+
+func TestReaderProviderWatch(t *testing.T) {
+	src := NewReaderProvider(testutil.NewFakeClient(t, testSchemes)).Watch(nil)
+	if err := src.Start(context.Background(), nil); err != nil {
+		t.Errorf("expected the reader provider source to start without error, got %v", err)
+	}
+}
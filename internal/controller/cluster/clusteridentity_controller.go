@@ -20,20 +20,27 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
 	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
 	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
@@ -41,14 +48,24 @@ import (
 type ClusterIdentityReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Discovery enables deriving the cluster identity from the node topology labels, nil disables it
+	Discovery *clusteridentity.DiscoveryConfig
+	// Config provides the DNSConfiguration adopted regions are checked against,
+	// defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// Recorder records the state transitions of the ClusterIdentity as events
+	Recorder events.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 //+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -58,13 +75,15 @@ type ClusterIdentityReconciler struct {
 func (r *ClusterIdentityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Retrieve the cluster identity to determine the current configuration and update the cache.
+	// Retrieve the cluster identity to report whether it is valid.
+	// Other controllers read it through a clusteridentity.IdentityProvider.
 	var clusterIdentity clusterv1alpha1.ClusterIdentity
 	if err := r.Get(ctx, req.NamespacedName, &clusterIdentity); err != nil {
 		if apierrors.IsNotFound(err) {
-			// Clear the cache when the resource is deleted to prevent other controllers from using stale data.
-			clusteridentity.Clear()
-			logger.Info("ClusterIdentity deleted", "name", req.Name, "namespace", req.Namespace, "action", "cleared cache")
+			logger.Info("ClusterIdentity deleted", "name", req.Name, "namespace", req.Namespace)
+			if r.Discovery != nil {
+				return ctrl.Result{}, r.createDiscoveredIdentity(ctx)
+			}
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch ClusterIdentity")
@@ -72,12 +91,22 @@ func (r *ClusterIdentityReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Ensure singleton to maintain a single source of truth for cluster identity.
-	if err := r.validateSingleton(ctx, &clusterIdentity); err != nil {
+	if err := validation.ClusterIdentitySingleton(ctx, r.Client, &clusterIdentity); err != nil {
 		logger.Error(err, "singleton validation failed")
 		return r.updateStatusFailed(ctx, &clusterIdentity, consts.ReasonSingletonViolation, err.Error())
 	}
 
-	if err := r.validateSpec(&clusterIdentity); err != nil {
+	// Compare with the cluster topology before validating, so a discovered identity is corrected first.
+	if r.Discovery != nil {
+		if err := r.reconcileDiscovery(ctx, &clusterIdentity); err != nil {
+			logger.Error(err, "failed to apply discovered identity")
+			return ctrl.Result{}, err
+		}
+	} else {
+		meta.RemoveStatusCondition(&clusterIdentity.Status.Conditions, consts.ConditionTypeIdentityDiscovered)
+	}
+
+	if err := validation.ClusterIdentity(&clusterIdentity); err != nil {
 		logger.Error(err, "spec validation failed")
 		return r.updateStatusFailed(ctx, &clusterIdentity, consts.ReasonInvalidSpec, err.Error())
 	}
@@ -90,18 +119,14 @@ func (r *ClusterIdentityReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		logger.Info("Optional adopted regions validation skipped or failed", "error", err)
 	}
 
-	// Update the in-memory cache so other controllers (e.g. ServiceRoute) can access
-	// the cluster's identity (region, environment, etc.) synchronously without API calls.
-	identity := &clusteridentity.ClusterIdentity{
-		Region:            clusterIdentity.Spec.Region,
-		Cluster:           clusterIdentity.Spec.Cluster,
-		Domain:            clusterIdentity.Spec.Domain,
-		EnvironmentLetter: clusterIdentity.Spec.EnvironmentLetter,
-		AdoptsRegions:     clusterIdentity.Spec.AdoptsRegions,
-	}
-	clusteridentity.Set(identity)
+	// Keep publishing the hostnames of a previous identity until its grace period ends
+	nextMigrationEnd := r.reconcileMigration(ctx, &clusterIdentity)
 
-	return r.updateStatusActive(ctx, &clusterIdentity)
+	result, err := r.updateStatusActive(ctx, &clusterIdentity)
+	if err == nil && result.IsZero() {
+		result.RequeueAfter = nextMigrationEnd
+	}
+	return result, err
 }
 
 // validateAdoptedRegions checks if adopted regions exist in DNSConfiguration
@@ -118,7 +143,7 @@ func (r *ClusterIdentityReconciler) validateAdoptedRegions(ctx context.Context,
 		return nil
 	}
 
-	config, err := dnsconfiguration.Fetch(ctx, r.Client)
+	config, err := r.Config.Config(ctx)
 	if err != nil {
 		// If listing fails (e.g., CRD not monitored or permission issue), return error to be logged but ignored
 		return fmt.Errorf("failed to fetch DNSConfiguration: %w", err)
@@ -161,52 +186,23 @@ func (r *ClusterIdentityReconciler) validateAdoptedRegions(ctx context.Context,
 	return nil
 }
 
-// validateSingleton ensures only one ClusterIdentity resource exists
-func (r *ClusterIdentityReconciler) validateSingleton(ctx context.Context, current *clusterv1alpha1.ClusterIdentity) error {
-	var clusterIdentities clusterv1alpha1.ClusterIdentityList
-	if err := r.List(ctx, &clusterIdentities); err != nil {
-		return fmt.Errorf("failed to list ClusterIdentities: %w", err)
-	}
-
-	if len(clusterIdentities.Items) > 1 {
-		return fmt.Errorf("only one ClusterIdentity resource is allowed per cluster, found %d", len(clusterIdentities.Items))
-	}
-
-	return nil
-}
-
-// validateSpec validates the ClusterIdentity spec fields
-func (r *ClusterIdentityReconciler) validateSpec(cr *clusterv1alpha1.ClusterIdentity) error {
-	if cr.Spec.Region == "" {
-		return fmt.Errorf("region cannot be empty")
-	}
-	if cr.Spec.Cluster == "" {
-		return fmt.Errorf("cluster cannot be empty")
-	}
-	if cr.Spec.Domain == "" {
-		return fmt.Errorf("domain cannot be empty")
-	}
-	if cr.Spec.EnvironmentLetter == "" {
-		return fmt.Errorf("environmentLetter cannot be empty")
-	}
-	return nil
-}
-
 // updateStatusActive updates the ClusterIdentity status to Active
 func (r *ClusterIdentityReconciler) updateStatusActive(ctx context.Context, cr *clusterv1alpha1.ClusterIdentity) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(cr.Status.Conditions)
 	cr.Status.Phase = consts.PhaseActive
 	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionTrue,
 		ObservedGeneration: cr.Generation,
 		Reason:             consts.ReasonReconciliationSucceeded,
-		Message:            "ClusterIdentity is active and cluster identity is cached",
+		Message:            "ClusterIdentity is active",
 	})
 
 	if err := r.Status().Update(ctx, cr); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("ClusterIdentity").Inc()
 			logger.Info("ClusterIdentity status update conflict (Active), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -214,6 +210,8 @@ func (r *ClusterIdentityReconciler) updateStatusActive(ctx context.Context, cr *
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, cr, corev1.EventTypeNormal, previous, cr.Status.Conditions)
+
 	logger.Info("ClusterIdentity reconciled successfully", "phase", cr.Status.Phase)
 	return ctrl.Result{}, nil
 }
@@ -222,6 +220,7 @@ func (r *ClusterIdentityReconciler) updateStatusActive(ctx context.Context, cr *
 func (r *ClusterIdentityReconciler) updateStatusFailed(ctx context.Context, cr *clusterv1alpha1.ClusterIdentity, reason, message string) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(cr.Status.Conditions)
 	cr.Status.Phase = consts.PhaseFailed
 	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
@@ -233,6 +232,7 @@ func (r *ClusterIdentityReconciler) updateStatusFailed(ctx context.Context, cr *
 
 	if err := r.Status().Update(ctx, cr); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("ClusterIdentity").Inc()
 			logger.Info("ClusterIdentity status update conflict (Failed), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -240,6 +240,8 @@ func (r *ClusterIdentityReconciler) updateStatusFailed(ctx context.Context, cr *
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, cr, corev1.EventTypeWarning, previous, cr.Status.Conditions)
+
 	logger.Info("ClusterIdentity marked as Failed", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
@@ -264,11 +266,41 @@ func (r *ClusterIdentityReconciler) mapDNSConfigToClusterIdentities(ctx context.
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterIdentityReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if r.Config == nil {
+		r.Config = dnsconfiguration.NewProvider(mgr.GetCache())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("clusteridentity-controller")
+	}
+
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1alpha1.ClusterIdentity{}).
-		Watches(
-			&clusterv1alpha1.DNSConfiguration{},
-			handler.EnqueueRequestsFromMapFunc(r.mapDNSConfigToClusterIdentities),
-		).
-		Complete(r)
+		WatchesRawSource(r.Config.Watch(handler.EnqueueRequestsFromMapFunc(r.mapDNSConfigToClusterIdentities)))
+
+	if r.Discovery != nil {
+		if err := r.Discovery.Validate(); err != nil {
+			return err
+		}
+
+		// Only label changes matter, nodes update their status far too often to watch everything
+		bldr = bldr.Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.mapTopologyToClusterIdentities),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		)
+		if r.Discovery.ClusterLabelSource == clusteridentity.ClusterLabelSourceKubeSystem {
+			bldr = bldr.Watches(
+				&corev1.Namespace{},
+				handler.EnqueueRequestsFromMapFunc(r.mapTopologyToClusterIdentities),
+				builder.WithPredicates(
+					predicate.LabelChangedPredicate{},
+					predicate.NewPredicateFuncs(func(obj client.Object) bool {
+						return obj.GetName() == "kube-system"
+					}),
+				),
+			)
+		}
+	}
+
+	return bldr.Complete(r)
 }
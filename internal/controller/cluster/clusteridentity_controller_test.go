@@ -22,13 +22,19 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
 )
 
 var _ = Describe("ClusterIdentity Controller", func() {
@@ -39,8 +45,17 @@ var _ = Describe("ClusterIdentity Controller", func() {
 
 	Context("When reconciling a ClusterIdentity", func() {
 		ctx := context.Background()
+		provider := clusteridentity.NewReaderProvider(k8sClient)
 
-		It("Should create ClusterIdentity successfully and update cache", func() {
+		currentIdentity := func() *clusteridentity.ClusterIdentity {
+			identity, err := provider.Identity(ctx)
+			if err != nil {
+				return nil
+			}
+			return identity
+		}
+
+		It("Should create ClusterIdentity successfully and expose it through the provider", func() {
 			clusterIdentity := &clusterv1alpha1.ClusterIdentity{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-cluster-identity",
@@ -60,7 +75,7 @@ var _ = Describe("ClusterIdentity Controller", func() {
 				return err == nil && clusterIdentity.Status.Phase == "Active"
 			}, timeout, interval).Should(BeTrue())
 
-			identity := clusteridentity.Get()
+			identity := currentIdentity()
 			Expect(identity).NotTo(BeNil())
 			Expect(identity.Region).To(Equal("neu"))
 			Expect(identity.Cluster).To(Equal("aks"))
@@ -87,9 +102,7 @@ var _ = Describe("ClusterIdentity Controller", func() {
 				return errors.IsNotFound(err)
 			}, timeout, interval).Should(BeTrue())
 
-			Eventually(func() *clusteridentity.ClusterIdentity {
-				return clusteridentity.Get()
-			}, timeout, interval).Should(BeNil())
+			Eventually(currentIdentity, timeout, interval).Should(BeNil())
 		})
 
 		It("Should reject multiple ClusterIdentity resources", func() {
@@ -183,7 +196,7 @@ var _ = Describe("ClusterIdentity Controller", func() {
 			Expect(k8sClient.Update(ctx, clusterIdentity)).Should(Succeed())
 
 			Eventually(func() string {
-				identity := clusteridentity.Get()
+				identity := currentIdentity()
 				if identity == nil {
 					return ""
 				}
@@ -264,3 +277,184 @@ var _ = Describe("ClusterIdentity Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("ClusterIdentity discovery", func() {
+	const regionLabel = "topology.kubernetes.io/region"
+
+	var (
+		ctx        context.Context
+		reconciler *ClusterIdentityReconciler
+	)
+
+	newReconciler := func(objs ...client.Object) *ClusterIdentityReconciler {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&clusterv1alpha1.ClusterIdentity{}).
+			Build()
+		return &ClusterIdentityReconciler{
+			Client: c,
+			Scheme: scheme.Scheme,
+			Config: dnsconfiguration.NewReaderProvider(c),
+			Discovery: &clusteridentity.DiscoveryConfig{
+				Name:              "cluster-identity",
+				RegionCodes:       map[string]string{"westeurope": "weu", "northeurope": "neu"},
+				ClusterLabel:      "example.com/cluster",
+				Domain:            "example.com",
+				EnvironmentLetter: "d",
+			},
+		}
+	}
+
+	node := func(region, cluster string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{regionLabel: region, "example.com/cluster": cluster},
+		}}
+	}
+
+	reconcileIdentity := func(name string) *clusterv1alpha1.ClusterIdentity {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		Expect(err).NotTo(HaveOccurred())
+		identity := &clusterv1alpha1.ClusterIdentity{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: name}, identity)).To(Succeed())
+		return identity
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("Should create the ClusterIdentity from the node labels when none exists", func() {
+		reconciler = newReconciler(node("westeurope", "aks"))
+
+		identity := reconcileIdentity("cluster-identity")
+		Expect(identity.Annotations).To(HaveKeyWithValue(clusteridentity.DiscoveredAnnotation, "true"))
+		Expect(identity.Spec.Region).To(Equal("weu"))
+		Expect(identity.Spec.Cluster).To(Equal("aks"))
+		Expect(identity.Spec.Domain).To(Equal("example.com"))
+		Expect(identity.Spec.EnvironmentLetter).To(Equal("d"))
+	})
+
+	It("Should patch a discovered ClusterIdentity when the topology changes", func() {
+		reconciler = newReconciler(node("northeurope", "aks02"), &clusterv1alpha1.ClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cluster-identity",
+				Annotations: map[string]string{clusteridentity.DiscoveredAnnotation: "true"},
+			},
+			Spec: clusterv1alpha1.ClusterIdentitySpec{Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d"},
+		})
+
+		identity := reconcileIdentity("cluster-identity")
+		Expect(identity.Spec.Region).To(Equal("neu"))
+		Expect(identity.Spec.Cluster).To(Equal("aks02"))
+		cond := meta.FindStatusCondition(identity.Status.Conditions, "IdentityDiscovered")
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		current, err := clusteridentity.NewReaderProvider(reconciler.Client).Identity(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current.Region).To(Equal("neu"))
+	})
+
+	It("Should report a mismatch without changing a declared ClusterIdentity", func() {
+		reconciler = newReconciler(node("northeurope", "aks"), &clusterv1alpha1.ClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "declared"},
+			Spec:       clusterv1alpha1.ClusterIdentitySpec{Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d"},
+		})
+
+		identity := reconcileIdentity("declared")
+		Expect(identity.Spec.Region).To(Equal("weu"))
+		Expect(identity.Status.Phase).To(Equal("Active"))
+		cond := meta.FindStatusCondition(identity.Status.Conditions, "IdentityDiscovered")
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("DiscoveredIdentityMismatch"))
+		Expect(cond.Message).To(ContainSubstring("region is weu but the nodes are in neu"))
+	})
+
+	It("Should report a discovery failure when the nodes are not labelled", func() {
+		reconciler = newReconciler(&clusterv1alpha1.ClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "declared"},
+			Spec:       clusterv1alpha1.ClusterIdentitySpec{Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d"},
+		})
+
+		identity := reconcileIdentity("declared")
+		cond := meta.FindStatusCondition(identity.Status.Conditions, "IdentityDiscovered")
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("IdentityDiscoveryFailed"))
+	})
+})
+
+var _ = Describe("ClusterIdentity hostname migration", func() {
+	var (
+		ctx        context.Context
+		reconciler *ClusterIdentityReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(&clusterv1alpha1.ClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+				Spec: clusterv1alpha1.ClusterIdentitySpec{
+					Region:               "neu",
+					Cluster:              "aks",
+					Domain:               "example.com",
+					EnvironmentLetter:    "d",
+					MigrationGracePeriod: &metav1.Duration{Duration: time.Hour},
+				},
+			}).
+			WithStatusSubresource(&clusterv1alpha1.ClusterIdentity{}).
+			Build()
+		reconciler = &ClusterIdentityReconciler{
+			Client: c,
+			Scheme: scheme.Scheme,
+			Config: dnsconfiguration.NewReaderProvider(c),
+		}
+	})
+
+	reconcileIdentity := func() (ctrl.Result, *clusterv1alpha1.ClusterIdentity) {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster-identity"}})
+		Expect(err).NotTo(HaveOccurred())
+		identity := &clusterv1alpha1.ClusterIdentity{}
+		Expect(reconciler.Get(ctx, types.NamespacedName{Name: "cluster-identity"}, identity)).To(Succeed())
+		return result, identity
+	}
+
+	It("Should keep publishing the previous identity until the grace period ends", func() {
+		result, identity := reconcileIdentity()
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(identity.Status.PublishedIdentity).NotTo(BeNil())
+		Expect(identity.Status.Migrations).To(BeEmpty())
+		Expect(meta.FindStatusCondition(identity.Status.Conditions, "HostnameMigration")).To(BeNil())
+
+		identity.Spec.Domain = "example.org"
+		Expect(reconciler.Update(ctx, identity)).To(Succeed())
+
+		// The previous identity is available before the migration is recorded
+		previous, err := clusteridentity.NewReaderProvider(reconciler.Client).PreviousIdentities(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(previous).To(HaveLen(1))
+		Expect(previous[0].Domain).To(Equal("example.com"))
+
+		result, identity = reconcileIdentity()
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(identity.Status.PublishedIdentity.Domain).To(Equal("example.org"))
+		Expect(identity.Status.Migrations).To(HaveLen(1))
+		Expect(identity.Status.Migrations[0].Identity.Domain).To(Equal("example.com"))
+		cond := meta.FindStatusCondition(identity.Status.Conditions, "HostnameMigration")
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("MigrationInProgress"))
+
+		// Once the grace period has passed the migration ends
+		identity.Status.Migrations[0].ExpiresAt = metav1.NewTime(time.Now().Add(-time.Second))
+		Expect(reconciler.Status().Update(ctx, identity)).To(Succeed())
+
+		result, identity = reconcileIdentity()
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(identity.Status.Migrations).To(BeEmpty())
+		Expect(meta.FindStatusCondition(identity.Status.Conditions, "HostnameMigration")).To(BeNil())
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// createDiscoveredIdentity creates the ClusterIdentity from the cluster topology when none exists.
+// Discovery problems are logged rather than returned: a node label change triggers a new attempt.
+func (r *ClusterIdentityReconciler) createDiscoveredIdentity(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	var identities clusterv1alpha1.ClusterIdentityList
+	if err := r.List(ctx, &identities); err != nil {
+		return err
+	}
+	if len(identities.Items) > 0 {
+		return nil
+	}
+
+	if err := r.Discovery.CanCreate(); err != nil {
+		logger.Info("No ClusterIdentity exists and discovery cannot create one", "reason", err.Error())
+		return nil
+	}
+
+	discovered, err := clusteridentity.Discover(ctx, r.Client, r.Discovery)
+	if err != nil {
+		logger.Info("Identity discovery failed, no ClusterIdentity created", "error", err.Error())
+		return nil
+	}
+
+	clusterIdentity := &clusterv1alpha1.ClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        r.Discovery.Name,
+			Annotations: map[string]string{clusteridentity.DiscoveredAnnotation: "true"},
+		},
+		Spec: clusterv1alpha1.ClusterIdentitySpec{
+			Region:            discovered.Region,
+			Cluster:           discovered.Cluster,
+			Domain:            r.Discovery.Domain,
+			EnvironmentLetter: r.Discovery.EnvironmentLetter,
+		},
+	}
+	if err := r.Create(ctx, clusterIdentity); err != nil {
+		return err
+	}
+
+	logger.Info("ClusterIdentity created from discovery", "name", clusterIdentity.Name,
+		"region", discovered.Region, "cluster", discovered.Cluster)
+	return nil
+}
+
+// reconcileDiscovery compares the ClusterIdentity with the cluster topology and records the outcome
+// in the IdentityDiscovered condition. A ClusterIdentity created by discovery is patched to follow
+// the topology, a ClusterIdentity declared by hand is left alone and a mismatch is only reported.
+func (r *ClusterIdentityReconciler) reconcileDiscovery(ctx context.Context, cr *clusterv1alpha1.ClusterIdentity) error {
+	logger := log.FromContext(ctx)
+
+	discovered, err := clusteridentity.Discover(ctx, r.Client, r.Discovery)
+	if err != nil {
+		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+			Type:               consts.ConditionTypeIdentityDiscovered,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: cr.Generation,
+			Reason:             consts.ReasonIdentityDiscoveryFailed,
+			Message:            err.Error(),
+		})
+		return nil
+	}
+
+	mismatches := clusteridentity.Mismatches(&cr.Spec, discovered)
+	if len(mismatches) > 0 && cr.Annotations[clusteridentity.DiscoveredAnnotation] == "true" {
+		patch := client.MergeFrom(cr.DeepCopy())
+		if discovered.Region != "" {
+			cr.Spec.Region = discovered.Region
+		}
+		if discovered.Cluster != "" {
+			cr.Spec.Cluster = discovered.Cluster
+		}
+		if err := r.Patch(ctx, cr, patch); err != nil {
+			return err
+		}
+		logger.Info("ClusterIdentity updated from discovery", "changes", strings.Join(mismatches, "; "))
+		recorder.Eventf(r.Recorder, cr, corev1.EventTypeNormal, consts.ReasonIdentityUpdated, "DiscoverIdentity",
+			"Updated from the cluster topology: %s", strings.Join(mismatches, "; "))
+		mismatches = nil
+	}
+
+	if len(mismatches) > 0 {
+		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+			Type:               consts.ConditionTypeIdentityDiscovered,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: cr.Generation,
+			Reason:             consts.ReasonDiscoveredIdentityMismatch,
+			Message:            "Declared identity differs from the cluster topology: " + strings.Join(mismatches, "; "),
+		})
+		return nil
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeIdentityDiscovered,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             consts.ReasonDiscoveredIdentityMatches,
+		Message:            "Declared identity matches the cluster topology",
+	})
+	return nil
+}
+
+// mapTopologyToClusterIdentities triggers reconciliation of the ClusterIdentity when node or
+// kube-system labels change. Without a ClusterIdentity the request names the one discovery creates.
+func (r *ClusterIdentityReconciler) mapTopologyToClusterIdentities(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.mapDNSConfigToClusterIdentities(ctx, obj)
+	if len(requests) == 0 {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: r.Discovery.Name},
+		})
+	}
+	return requests
+}
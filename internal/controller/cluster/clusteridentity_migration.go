@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// reconcileMigration records hostname migrations in the status and reports them in the HostnameMigration
+// condition. The routing controllers publish the hostnames of every migrating identity next to the current
+// ones, see clusteridentity.IdentityProvider. It returns the time until the next migration ends,
+// 0 when no migration is in progress.
+func (r *ClusterIdentityReconciler) reconcileMigration(ctx context.Context, cr *clusterv1alpha1.ClusterIdentity) time.Duration {
+	logger := log.FromContext(ctx)
+
+	before := len(cr.Status.Migrations)
+	nextMigrationEnd := clusteridentity.UpdateMigrations(cr, time.Now())
+	if len(cr.Status.Migrations) != before {
+		logger.Info("Hostname migrations changed", "previous", before, "current", len(cr.Status.Migrations))
+	}
+
+	if len(cr.Status.Migrations) == 0 {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, consts.ConditionTypeHostnameMigration)
+		return 0
+	}
+
+	var lastMigrationEnd time.Time
+	for _, migration := range cr.Status.Migrations {
+		if migration.ExpiresAt.After(lastMigrationEnd) {
+			lastMigrationEnd = migration.ExpiresAt.Time
+		}
+	}
+	identities := "identity"
+	if len(cr.Status.Migrations) > 1 {
+		identities = "identities"
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeHostnameMigration,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             consts.ReasonMigrationInProgress,
+		Message: fmt.Sprintf("Publishing the hostnames of %d previous %s until %s",
+			len(cr.Status.Migrations), identities, lastMigrationEnd.UTC().Format(time.RFC3339)),
+	})
+	return nextMigrationEnd
+}
@@ -20,40 +20,48 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
 // DNSConfigurationReconciler reconciles a DNSConfiguration object
 type DNSConfigurationReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder records the state transitions of the DNSConfiguration as events
+	Recorder events.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations/finalizers,verbs=update
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *DNSConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Retrieve the desired DNS configuration to update the cache.
+	// Retrieve the DNS configuration to report whether it is valid.
+	// Other controllers read it through a dnsconfiguration.ConfigProvider.
 	var dnsConfig clusterv1alpha1.DNSConfiguration
 	if err := r.Get(ctx, req.NamespacedName, &dnsConfig); err != nil {
 		if apierrors.IsNotFound(err) {
-			// Clear the cache when the resource is deleted to prevent other controllers from using stale data.
-			dnsconfiguration.Clear()
-			logger.Info("DNSConfiguration deleted", "name", req.Name, "namespace", req.Namespace, "action", "cleared cache")
+			logger.Info("DNSConfiguration deleted", "name", req.Name, "namespace", req.Namespace)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch DNSConfiguration")
@@ -61,79 +69,59 @@ func (r *DNSConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	// Ensure singleton to maintain a single source of truth for DNS configuration.
-	if err := r.validateSingleton(ctx, &dnsConfig); err != nil {
+	if err := validation.DNSConfigurationSingleton(ctx, r.Client, &dnsConfig); err != nil {
 		logger.Error(err, "singleton validation failed")
-		return r.updateStatusFailed(ctx, &dnsConfig, "SingletonViolation", err.Error())
+		return r.updateStatusFailed(ctx, &dnsConfig, consts.ReasonSingletonViolation, err.Error())
 	}
 
-	if err := r.validateSpec(&dnsConfig); err != nil {
+	if err := validation.DNSConfiguration(&dnsConfig); err != nil {
 		logger.Error(err, "spec validation failed")
-		return r.updateStatusFailed(ctx, &dnsConfig, "InvalidSpec", err.Error())
+		return r.updateStatusFailed(ctx, &dnsConfig, consts.ReasonInvalidSpec, err.Error())
 	}
 
-	// Update the in-memory cache so other controllers (DNSPolicy, ServiceRoute) can access
-	// this configuration synchronously without API calls.
-	config := &dnsconfiguration.DNSConfiguration{
-		ExternalDNSControllers: make([]dnsconfiguration.ExternalDNSController, len(dnsConfig.Spec.ExternalDNSControllers)),
-	}
-
-	for i, c := range dnsConfig.Spec.ExternalDNSControllers {
-		config.ExternalDNSControllers[i] = dnsconfiguration.ExternalDNSController{
-			Name:   c.Name,
-			Region: c.Region,
-		}
-	}
-
-	dnsconfiguration.Set(config)
+	reconcilePreFailover(&dnsConfig)
 
 	// Update status to Ready
 	return r.updateStatusReady(ctx, &dnsConfig)
 }
 
-// validateSingleton ensures only one DNSConfiguration resource exists per cluster
-func (r *DNSConfigurationReconciler) validateSingleton(ctx context.Context, current *clusterv1alpha1.DNSConfiguration) error {
-	var dnsConfigs clusterv1alpha1.DNSConfigurationList
-	if err := r.List(ctx, &dnsConfigs); err != nil {
-		return fmt.Errorf("failed to list DNSConfigurations: %w", err)
+// reconcilePreFailover reports in the PreFailover condition whether record TTLs are lowered
+// ahead of a planned region switch, so the mode is not left enabled unnoticed
+func reconcilePreFailover(cr *clusterv1alpha1.DNSConfiguration) {
+	if cr.Spec.PreFailover == nil {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, consts.ConditionTypePreFailover)
+		return
 	}
 
-	if len(dnsConfigs.Items) > 1 {
-		return fmt.Errorf("only one DNSConfiguration resource is allowed per cluster, found %d", len(dnsConfigs.Items))
-	}
-
-	return nil
-}
-
-// validateSpec validates the DNSConfiguration spec fields
-func (r *DNSConfigurationReconciler) validateSpec(cr *clusterv1alpha1.DNSConfiguration) error {
-	if len(cr.Spec.ExternalDNSControllers) == 0 {
-		return fmt.Errorf("externalDNSControllers cannot be empty")
-	}
-	for i, c := range cr.Spec.ExternalDNSControllers {
-		if c.Name == "" {
-			return fmt.Errorf("externalDNSControllers[%d].name cannot be empty", i)
-		}
-		if c.Region == "" {
-			return fmt.Errorf("externalDNSControllers[%d].region cannot be empty", i)
-		}
+	ttl := cr.Spec.PreFailover.TTL
+	if ttl <= 0 {
+		ttl = dnsconfiguration.DefaultPreFailoverTTL
 	}
-	return nil
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypePreFailover,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             consts.ReasonPreFailoverEnabled,
+		Message:            fmt.Sprintf("Record TTLs are capped at %d seconds", ttl),
+	})
 }
 
 // updateStatusReady updates the DNSConfiguration status to Ready
 func (r *DNSConfigurationReconciler) updateStatusReady(ctx context.Context, cr *clusterv1alpha1.DNSConfiguration) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(cr.Status.Conditions)
 	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
-		Type:               "Ready",
+		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionTrue,
 		ObservedGeneration: cr.Generation,
-		Reason:             "ReconciliationSucceeded",
-		Message:            "DNSConfiguration is valid and cached",
+		Reason:             consts.ReasonReconciliationSucceeded,
+		Message:            "DNSConfiguration is valid",
 	})
 
 	if err := r.Status().Update(ctx, cr); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("DNSConfiguration").Inc()
 			logger.Info("DNSConfiguration status update conflict (Ready), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -141,6 +129,8 @@ func (r *DNSConfigurationReconciler) updateStatusReady(ctx context.Context, cr *
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, cr, corev1.EventTypeNormal, previous, cr.Status.Conditions)
+
 	logger.Info("DNSConfiguration reconciled successfully")
 	return ctrl.Result{}, nil
 }
@@ -149,8 +139,9 @@ func (r *DNSConfigurationReconciler) updateStatusReady(ctx context.Context, cr *
 func (r *DNSConfigurationReconciler) updateStatusFailed(ctx context.Context, cr *clusterv1alpha1.DNSConfiguration, reason, message string) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(cr.Status.Conditions)
 	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
-		Type:               "Ready",
+		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionFalse,
 		ObservedGeneration: cr.Generation,
 		Reason:             reason,
@@ -159,6 +150,7 @@ func (r *DNSConfigurationReconciler) updateStatusFailed(ctx context.Context, cr
 
 	if err := r.Status().Update(ctx, cr); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("DNSConfiguration").Inc()
 			logger.Info("DNSConfiguration status update conflict (Failed), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -166,12 +158,18 @@ func (r *DNSConfigurationReconciler) updateStatusFailed(ctx context.Context, cr
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, cr, corev1.EventTypeWarning, previous, cr.Status.Conditions)
+
 	logger.Info("DNSConfiguration marked as Failed", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DNSConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("dnsconfiguration-controller")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1alpha1.DNSConfiguration{}).
 		Complete(r)
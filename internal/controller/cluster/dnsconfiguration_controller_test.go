@@ -22,6 +22,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -37,19 +38,24 @@ var _ = Describe("DNSConfiguration Controller", func() {
 	)
 
 	Context("When reconciling a DNSConfiguration", func() {
-		BeforeEach(func() {
-			dnsconfiguration.Clear()
-		})
+		provider := dnsconfiguration.NewReaderProvider(k8sClient)
+
+		currentConfig := func() *dnsconfiguration.DNSConfiguration {
+			config, err := provider.Config(context.Background())
+			if err != nil {
+				return nil
+			}
+			return config
+		}
 
 		AfterEach(func() {
 			dnsConfig := &clusterv1alpha1.DNSConfiguration{}
 			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: DNSConfigName}, dnsConfig); err == nil {
 				Expect(k8sClient.Delete(context.Background(), dnsConfig)).To(Succeed())
 			}
-			dnsconfiguration.Clear()
 		})
 
-		It("Should update the cache and status when valid", func() {
+		It("Should expose the configuration and update the status when valid", func() {
 			ctx := context.Background()
 
 			dnsConfig := &clusterv1alpha1.DNSConfiguration{
@@ -68,15 +74,12 @@ var _ = Describe("DNSConfiguration Controller", func() {
 
 			Expect(k8sClient.Create(ctx, dnsConfig)).To(Succeed())
 
-			// Verify Cache
-			Eventually(func() *dnsconfiguration.DNSConfiguration {
-				return dnsconfiguration.Get()
-			}, timeout, interval).ShouldNot(BeNil())
+			Eventually(currentConfig, timeout, interval).ShouldNot(BeNil())
 
-			cached := dnsconfiguration.Get()
-			Expect(len(cached.ExternalDNSControllers)).To(Equal(1))
-			Expect(cached.ExternalDNSControllers[0].Name).To(Equal("external-dns-weu"))
-			Expect(cached.ExternalDNSControllers[0].Region).To(Equal("weu"))
+			config := currentConfig()
+			Expect(len(config.ExternalDNSControllers)).To(Equal(1))
+			Expect(config.ExternalDNSControllers[0].Name).To(Equal("external-dns-weu"))
+			Expect(config.ExternalDNSControllers[0].Region).To(Equal("weu"))
 
 			Eventually(func() string {
 				var current clusterv1alpha1.DNSConfiguration
@@ -90,7 +93,39 @@ var _ = Describe("DNSConfiguration Controller", func() {
 			}, timeout, interval).Should(Equal("ReconciliationSucceeded"))
 		})
 
-		It("Should clear cache when deleted", func() {
+		It("Should report the pre-failover mode in its status", func() {
+			ctx := context.Background()
+
+			dnsConfig := &clusterv1alpha1.DNSConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: DNSConfigName,
+				},
+				Spec: clusterv1alpha1.DNSConfigurationSpec{
+					ExternalDNSControllers: []clusterv1alpha1.ExternalDNSController{
+						{Name: "external-dns-weu", Region: "weu"},
+					},
+					PreFailover: &clusterv1alpha1.PreFailover{TTL: 20},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, dnsConfig)).To(Succeed())
+			Eventually(currentConfig, timeout, interval).ShouldNot(BeNil())
+			Expect(currentConfig().PreFailoverTTL).To(Equal(int64(20)))
+
+			Eventually(func() *metav1.Condition {
+				var current clusterv1alpha1.DNSConfiguration
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: DNSConfigName}, &current); err != nil {
+					return nil
+				}
+				return meta.FindStatusCondition(current.Status.Conditions, "PreFailover")
+			}, timeout, interval).Should(And(
+				Not(BeNil()),
+				HaveField("Reason", "PreFailoverEnabled"),
+				HaveField("Message", "Record TTLs are capped at 20 seconds"),
+			))
+		})
+
+		It("Should no longer expose the configuration when deleted", func() {
 			ctx := context.Background()
 
 			dnsConfig := &clusterv1alpha1.DNSConfiguration{
@@ -105,15 +140,11 @@ var _ = Describe("DNSConfiguration Controller", func() {
 			}
 
 			Expect(k8sClient.Create(ctx, dnsConfig)).To(Succeed())
-			Eventually(func() *dnsconfiguration.DNSConfiguration {
-				return dnsconfiguration.Get()
-			}, timeout, interval).ShouldNot(BeNil())
+			Eventually(currentConfig, timeout, interval).ShouldNot(BeNil())
 
 			Expect(k8sClient.Delete(ctx, dnsConfig)).To(Succeed())
 
-			Eventually(func() *dnsconfiguration.DNSConfiguration {
-				return dnsconfiguration.Get()
-			}, timeout, interval).Should(BeNil())
+			Eventually(currentConfig, timeout, interval).Should(BeNil())
 		})
 	})
 })
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// ClusterDNSPolicyReconciler reconciles a ClusterDNSPolicy object.
+// The policy is evaluated with the same rules as a DNSPolicy, only its status is written
+// to the ClusterDNSPolicy.
+type ClusterDNSPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// PeerHealth checks the peer regions of Failover mode policies, defaults to HTTP and Lease probes
+	PeerHealth PeerHealthChecker
+	// Identity provides the cluster identity, defaults to the manager's informer cache
+	Identity clusteridentity.IdentityProvider
+	// Config provides the DNS configuration, defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// Recorder records activation changes and failover switches of the policy as events
+	Recorder events.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=routing.router.io,resources=clusterdnspolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=routing.router.io,resources=clusterdnspolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=routing.router.io,resources=clusterdnspolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile evaluates the ClusterDNSPolicy and records the active controllers in its status
+func (r *ClusterDNSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var clusterPolicy routingv1alpha1.ClusterDNSPolicy
+	if err := r.Get(ctx, req.NamespacedName, &clusterPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("ClusterDNSPolicy deleted", "name", req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch ClusterDNSPolicy")
+		return ctrl.Result{}, err
+	}
+
+	policies := &DNSPolicyReconciler{
+		Client:     r.Client,
+		Scheme:     r.Scheme,
+		PeerHealth: r.PeerHealth,
+		Identity:   r.Identity,
+		Config:     r.Config,
+		Recorder:   r.Recorder,
+		statusWriter: func(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) error {
+			clusterPolicy.Status = dnsPolicy.Status
+			return r.Status().Update(ctx, &clusterPolicy)
+		},
+		eventTarget: &clusterPolicy,
+	}
+	dnsPolicy := dnsPolicyView(&clusterPolicy)
+
+	if err := validation.ClusterDNSPolicy(&clusterPolicy); err != nil {
+		logger.Error(err, "validation failed")
+		return policies.updateStatusFailed(ctx, dnsPolicy, consts.ReasonValidationFailed, err.Error())
+	}
+
+	return policies.reconcilePolicy(ctx, dnsPolicy)
+}
+
+// dnsPolicyView returns a DNSPolicy carrying the name, spec and status of a ClusterDNSPolicy.
+// The view has no namespace, which is how a ClusterDNSPolicy is told apart downstream.
+func dnsPolicyView(clusterPolicy *routingv1alpha1.ClusterDNSPolicy) *routingv1alpha1.DNSPolicy {
+	return &routingv1alpha1.DNSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       clusterPolicy.Name,
+			Generation: clusterPolicy.Generation,
+		},
+		Spec:   *clusterPolicy.Spec.DNSPolicySpec.DeepCopy(),
+		Status: *clusterPolicy.Status.DeepCopy(),
+	}
+}
+
+// selectClusterDNSPolicy returns the ClusterDNSPolicy that applies to a namespace, or nil.
+//
+// A policy applies when its namespaceSelector matches the namespace labels; a policy
+// without a selector applies to every namespace. When several policies apply, the one
+// with the highest priority wins, and equal priorities are decided by name.
+// Policies with an invalid selector never apply.
+func selectClusterDNSPolicy(
+	clusterPolicies []routingv1alpha1.ClusterDNSPolicy,
+	namespaceLabels map[string]string,
+) *routingv1alpha1.ClusterDNSPolicy {
+	var selected *routingv1alpha1.ClusterDNSPolicy
+	for i := range clusterPolicies {
+		policy := &clusterPolicies[i]
+		if policy.DeletionTimestamp != nil {
+			continue
+		}
+
+		if policy.Spec.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+			if err != nil || !selector.Matches(labels.Set(namespaceLabels)) {
+				continue
+			}
+		}
+
+		if selected == nil ||
+			policy.Spec.Priority > selected.Spec.Priority ||
+			(policy.Spec.Priority == selected.Spec.Priority && policy.Name < selected.Name) {
+			selected = policy
+		}
+	}
+
+	return selected
+}
+
+// getClusterDNSPolicyForNamespace returns the view of the ClusterDNSPolicy that applies to a namespace, or nil
+func getClusterDNSPolicyForNamespace(ctx context.Context, c client.Client, namespace string) (*routingv1alpha1.DNSPolicy, error) {
+	var clusterPolicies routingv1alpha1.ClusterDNSPolicyList
+	if err := c.List(ctx, &clusterPolicies); err != nil {
+		return nil, err
+	}
+	if len(clusterPolicies.Items) == 0 {
+		return nil, nil
+	}
+
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	clusterPolicy := selectClusterDNSPolicy(clusterPolicies.Items, ns.Labels)
+	if clusterPolicy == nil {
+		return nil, nil
+	}
+	return dnsPolicyView(clusterPolicy), nil
+}
+
+// dnsPolicyReference identifies the DNSPolicy, or the ClusterDNSPolicy behind a policy view
+func dnsPolicyReference(dnsPolicy *routingv1alpha1.DNSPolicy) *routingv1alpha1.ServiceRouteDNSPolicyReference {
+	if dnsPolicy.Namespace == "" {
+		return &routingv1alpha1.ServiceRouteDNSPolicyReference{Kind: "ClusterDNSPolicy", Name: dnsPolicy.Name}
+	}
+	return &routingv1alpha1.ServiceRouteDNSPolicyReference{
+		Kind:      "DNSPolicy",
+		Name:      dnsPolicy.Name,
+		Namespace: dnsPolicy.Namespace,
+	}
+}
+
+// mapGlobalConfigToClusterDNSPolicies returns all ClusterDNSPolicies for reconciliation
+func (r *ClusterDNSPolicyReconciler) mapGlobalConfigToClusterDNSPolicies(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var clusterPolicies routingv1alpha1.ClusterDNSPolicyList
+	if err := r.List(ctx, &clusterPolicies); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, policy := range clusterPolicies.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: policy.Name},
+		})
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterDNSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.PeerHealth == nil {
+		r.PeerHealth = newProbePeerHealthChecker(mgr.GetClient())
+	}
+	if r.Identity == nil {
+		r.Identity = clusteridentity.NewProvider(mgr.GetCache())
+	}
+	if r.Config == nil {
+		r.Config = dnsconfiguration.NewProvider(mgr.GetCache())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("clusterdnspolicy-controller")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates, such as the peer health counters, must not trigger a reconcile
+		For(&routingv1alpha1.ClusterDNSPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&routingv1alpha1.ServiceRoute{},
+			handler.EnqueueRequestsFromMapFunc(mapServiceRouteToClusterDNSPolicy),
+			builder.WithPredicates(takeoverConflictChanged),
+		).
+		WatchesRawSource(r.Identity.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToClusterDNSPolicies))).
+		WatchesRawSource(r.Config.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToClusterDNSPolicies))).
+		Complete(r)
+}
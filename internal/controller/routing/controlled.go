@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// notControlledError reports an existing object with the name of a generated object
+// that is not controlled by the resource it would be generated for. The operator never
+// patches or deletes such an object, it is reported in the status instead.
+type notControlledError struct {
+	kind string
+	key  client.ObjectKey
+}
+
+func (e *notControlledError) Error() string {
+	return fmt.Sprintf("%s %s already exists and is not managed by the operator", e.kind, e.key)
+}
+
+// isNotControlled reports whether err is a notControlledError
+func isNotControlled(err error) bool {
+	var notControlled *notControlledError
+	return errors.As(err, &notControlled)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+)
+
+var _ = Describe("Generated objects not controlled by the operator", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(istioclientv1beta1.AddToScheme(testScheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(testScheme).
+			WithStatusSubresource(&routingv1alpha1.ServiceRoute{}).Build()
+	})
+
+	It("should not take over a VirtualService the ServiceRoute does not control", func() {
+		serviceRoute := &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				GatewayName: "default-gateway",
+				Environment: "dev",
+				Application: "shop",
+				Backend:     &routingv1alpha1.ServiceRouteBackend{ServiceName: "api", Port: 8080},
+			},
+		}
+		Expect(c.Create(ctx, serviceRoute)).To(Succeed())
+		handMade := &istioclientv1beta1.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       networkingv1beta1.VirtualService{Hosts: []string{"hand-made.example.com"}},
+		}
+		Expect(c.Create(ctx, handMade)).To(Succeed())
+
+		reconciler := &ServiceRouteReconciler{Client: c}
+		gateway := &routingv1alpha1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"}}
+		identity := &clusteridentity.ClusterIdentity{Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d"}
+
+		result, err := reconciler.reconcileBackendRoute(ctx, serviceRoute, gateway, identity, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+
+		var existing istioclientv1beta1.VirtualService
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(handMade), &existing)).To(Succeed())
+		Expect(existing.Spec.Hosts).To(Equal([]string{"hand-made.example.com"}))
+		Expect(existing.OwnerReferences).To(BeEmpty())
+
+		condition := meta.FindStatusCondition(serviceRoute.Status.Conditions, "Ready")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("NotControlled"))
+		Expect(condition.Message).To(Equal("VirtualService default/api already exists and is not managed by the operator"))
+		Expect(serviceRoute.Status.VirtualService).To(BeEmpty())
+	})
+
+	It("should neither delete nor patch an Istio Gateway the Gateway does not control", func() {
+		gateway := &routingv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system", UID: "gateway-uid"},
+			Spec:       routingv1alpha1.GatewaySpec{Controller: "aks-istio-ingressgateway-internal"},
+		}
+		handMade := &istioclientv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"},
+			Spec:       networkingv1beta1.Gateway{Selector: map[string]string{"istio": "hand-made"}},
+		}
+		Expect(c.Create(ctx, handMade)).To(Succeed())
+		reconciler := &GatewayReconciler{Client: c}
+
+		Expect(reconciler.deleteIstioGateway(ctx, gateway)).To(Succeed())
+		desired, err := reconciler.generateIstioGateway(gateway, []string{"api.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		err = reconciler.reconcileIstioGateway(ctx, gateway, desired)
+		Expect(isNotControlled(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("Istio Gateway istio-system/default-gateway already exists and is not managed by the operator"))
+
+		var existing istioclientv1beta1.Gateway
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(handMade), &existing)).To(Succeed())
+		Expect(existing.Spec.Selector).To(Equal(map[string]string{"istio": "hand-made"}))
+	})
+
+	It("should not patch a Gateway API object the owner does not control", func() {
+		gateway := &routingv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system", UID: "gateway-uid"},
+		}
+		handMade := newGatewayAPIObject(gatewayAPIGatewayGVK)
+		handMade.SetName("default-gateway")
+		handMade.SetNamespace("istio-system")
+		handMade.Object["spec"] = map[string]interface{}{"gatewayClassName": "hand-made"}
+		Expect(c.Create(ctx, handMade)).To(Succeed())
+
+		desired, err := generateGatewayAPIGateway(gateway, []string{"api.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		err = reconcileUnstructured(ctx, c, gateway, desired)
+		Expect(isNotControlled(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("Gateway istio-system/default-gateway already exists and is not managed by the operator"))
+
+		existing := newGatewayAPIObject(gatewayAPIGatewayGVK)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(handMade), existing)).To(Succeed())
+		Expect(existing.Object["spec"]).To(Equal(map[string]interface{}{"gatewayClassName": "hand-made"}))
+
+		Expect(deleteUnstructured(ctx, c, gatewayAPIGatewayGVK, client.ObjectKeyFromObject(handMade), gateway)).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(handMade), existing))).To(BeFalse())
+	})
+
+	It("should pass Gateway API Gateway updates that change the published address", func() {
+		withAddress := func(address string) *unstructured.Unstructured {
+			obj := newGatewayAPIObject(gatewayAPIGatewayGVK)
+			obj.Object["status"] = map[string]interface{}{
+				"addresses": []interface{}{map[string]interface{}{"type": "IPAddress", "value": address}},
+			}
+			return obj
+		}
+
+		Expect(gatewayAPIAddressChanged.Update(event.UpdateEvent{
+			ObjectOld: withAddress(""), ObjectNew: withAddress("10.0.0.1"),
+		})).To(BeTrue())
+		Expect(gatewayAPIAddressChanged.Update(event.UpdateEvent{
+			ObjectOld: withAddress("10.0.0.1"), ObjectNew: withAddress("10.0.0.1"),
+		})).To(BeFalse())
+	})
+})
@@ -0,0 +1,342 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/diagnose"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// RouteDiagnoser explains why a ServiceRoute is or is not serving. It reads the resources the
+// ServiceRoute reconciler depends on in the same order and with the same rules, and reports
+// every hop with the condition reason the reconcilers use when that hop blocks the route.
+type RouteDiagnoser struct {
+	client.Client
+	DefaultRouterGatewayNamespace string
+	// Identity and Config provide the cluster identity and DNS configuration, as for the reconcilers
+	Identity clusteridentity.IdentityProvider
+	Config   dnsconfiguration.ConfigProvider
+	// DNSBackend lists the published records, defaults to ExternalDNS DNSEndpoints.
+	// It must be the backend of the manager, or the published records are not found.
+	DNSBackend dnsbackend.DNSBackend
+	// Ownership looks up the claims of other clusters, nil skips the check as the manager does
+	// without --ownership-check
+	Ownership ownership.Resolver
+}
+
+// dnsBackend returns the configured DNS backend, ExternalDNS by default
+func (d *RouteDiagnoser) dnsBackend() dnsbackend.DNSBackend {
+	if d.DNSBackend == nil {
+		return dnsbackend.NewExternalDNS(d.Client)
+	}
+	return d.DNSBackend
+}
+
+// Diagnose walks the dependency chain of a ServiceRoute:
+// DNSPolicy, its active flag, DNSConfiguration, Gateway, ClusterIdentity, the records to publish,
+// DNSEndpoints and the LoadBalancer Service of the Gateway.
+func (d *RouteDiagnoser) Diagnose(ctx context.Context, key types.NamespacedName) (*diagnose.Diagnosis, error) {
+	var serviceRoute routingv1alpha1.ServiceRoute
+	if err := d.Get(ctx, key, &serviceRoute); err != nil {
+		return nil, err
+	}
+
+	diagnosis := &diagnose.Diagnosis{ServiceRoute: key.String(), Phase: serviceRoute.Status.Phase}
+	resource := "ServiceRoute " + key.String()
+	if err := validation.ServiceRoute(&serviceRoute); err != nil {
+		diagnosis.AddBlocked("ServiceRoute", resource, consts.ReasonValidationFailed, err.Error())
+	} else {
+		diagnosis.AddOK("ServiceRoute", resource, "Specification is valid")
+	}
+
+	if err := d.diagnoseDNSPolicy(ctx, diagnosis, &serviceRoute); err != nil {
+		return nil, err
+	}
+	d.diagnoseDNSConfiguration(ctx, diagnosis)
+	gateway, err := d.diagnoseGateway(ctx, diagnosis, &serviceRoute)
+	if err != nil {
+		return nil, err
+	}
+	d.diagnoseClusterIdentity(ctx, diagnosis)
+	if err := d.diagnoseRecords(ctx, diagnosis, &serviceRoute); err != nil {
+		return nil, err
+	}
+	if err := d.diagnoseDNSEndpoints(ctx, diagnosis, &serviceRoute); err != nil {
+		return nil, err
+	}
+	if err := d.diagnoseLoadBalancer(ctx, diagnosis, gateway); err != nil {
+		return nil, err
+	}
+
+	return diagnosis, nil
+}
+
+// diagnoseDNSPolicy reports the DNSPolicy that applies to the ServiceRoute and whether it is active
+func (d *RouteDiagnoser) diagnoseDNSPolicy(
+	ctx context.Context,
+	diagnosis *diagnose.Diagnosis,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	r := &ServiceRouteReconciler{Client: d.Client}
+	dnsPolicy, err := r.getDNSPolicyForServiceRoute(ctx, serviceRoute)
+	if err != nil {
+		return err
+	}
+	if dnsPolicy == nil {
+		diagnosis.AddBlocked("DNSPolicy", "", consts.ReasonDNSPolicyNotFound, fmt.Sprintf(
+			"No DNSPolicy in namespace %s applies to the ServiceRoute and no ClusterDNSPolicy selects the namespace",
+			serviceRoute.Namespace))
+		diagnosis.AddWaiting("Active", "", "Requires a DNSPolicy")
+		return nil
+	}
+
+	reference := dnsPolicyReference(dnsPolicy)
+	resource := reference.Kind + " " + reference.Name
+	if reference.Namespace != "" {
+		resource = reference.Kind + " " + reference.Namespace + "/" + reference.Name
+	}
+	diagnosis.AddOK("DNSPolicy", resource, "Mode "+dnsPolicy.Spec.Mode)
+
+	if !dnsPolicy.Status.Active {
+		message := "DNSPolicy is not active for this cluster"
+		if condition := meta.FindStatusCondition(dnsPolicy.Status.Conditions, consts.ConditionTypeReady); condition != nil {
+			message = fmt.Sprintf("%s: %s: %s", message, condition.Reason, condition.Message)
+		}
+		diagnosis.AddBlocked("Active", resource, consts.ReasonDNSPolicyInactive, message)
+		return nil
+	}
+	diagnosis.AddOK("Active", resource, "Publishing to "+controllerList(dnsPolicy.Status.ActiveControllers))
+	return nil
+}
+
+// diagnoseDNSConfiguration reports the DNSConfiguration mapping controller names to regions
+func (d *RouteDiagnoser) diagnoseDNSConfiguration(ctx context.Context, diagnosis *diagnose.Diagnosis) {
+	dnsConfig, err := d.Config.Config(ctx)
+	if err != nil {
+		diagnosis.AddBlocked("DNSConfiguration", "", consts.ReasonDNSConfigurationNotAvailable, err.Error())
+		return
+	}
+	if dnsConfig == nil {
+		diagnosis.AddBlocked("DNSConfiguration", "", consts.ReasonDNSConfigurationNotAvailable,
+			"No DNSConfiguration exists in the cluster")
+		return
+	}
+	diagnosis.AddOK("DNSConfiguration", "", fmt.Sprintf("%d ExternalDNS controllers", len(dnsConfig.ExternalDNSControllers)))
+}
+
+// diagnoseGateway reports the Gateway referenced by the ServiceRoute and returns it, nil when not found
+func (d *RouteDiagnoser) diagnoseGateway(
+	ctx context.Context,
+	diagnosis *diagnose.Diagnosis,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) (*routingv1alpha1.Gateway, error) {
+	gatewayNamespace := serviceRoute.Spec.GatewayNamespace
+	if gatewayNamespace == "" {
+		gatewayNamespace = d.DefaultRouterGatewayNamespace
+	}
+	resource := "Gateway " + gatewayNamespace + "/" + serviceRoute.Spec.GatewayName
+
+	var gateway routingv1alpha1.Gateway
+	if err := d.Get(ctx, client.ObjectKey{Name: serviceRoute.Spec.GatewayName, Namespace: gatewayNamespace}, &gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			diagnosis.AddBlocked("Gateway", resource, consts.ReasonGatewayNotFound,
+				fmt.Sprintf("Gateway %s not found in namespace %s", serviceRoute.Spec.GatewayName, gatewayNamespace))
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	condition := meta.FindStatusCondition(gateway.Status.Conditions, consts.ConditionTypeReady)
+	if gateway.Status.Phase == consts.PhaseFailed && condition != nil {
+		diagnosis.AddBlocked("Gateway", resource, condition.Reason, condition.Message)
+		return &gateway, nil
+	}
+
+	implementation := gateway.Spec.Implementation
+	if implementation == "" {
+		implementation = consts.ImplementationIstio
+	}
+	diagnosis.AddOK("Gateway", resource, fmt.Sprintf("%s gateway of controller %s, target postfix %s",
+		implementation, gateway.Spec.Controller, gateway.Spec.TargetPostfix))
+	return &gateway, nil
+}
+
+// diagnoseClusterIdentity reports the ClusterIdentity the hostnames are generated from
+func (d *RouteDiagnoser) diagnoseClusterIdentity(ctx context.Context, diagnosis *diagnose.Diagnosis) {
+	clusterIdentity, err := d.Identity.Identity(ctx)
+	if err != nil {
+		diagnosis.AddBlocked("ClusterIdentity", "", consts.ReasonClusterIdentityNotAvailable, err.Error())
+		return
+	}
+	if clusterIdentity == nil {
+		diagnosis.AddBlocked("ClusterIdentity", "", consts.ReasonClusterIdentityNotAvailable,
+			"No ClusterIdentity exists in the cluster")
+		return
+	}
+	diagnosis.AddOK("ClusterIdentity", "", fmt.Sprintf("Region %s, cluster %s, domain %s",
+		clusterIdentity.Region, clusterIdentity.Cluster, clusterIdentity.Domain))
+}
+
+// diagnoseRecords reports the records the ServiceRoute publishes, computed as by the reconciler.
+// It explains the steps without a hop of their own: hostnames, hostname conflicts, backend health
+// and the claims of other clusters.
+func (d *RouteDiagnoser) diagnoseRecords(
+	ctx context.Context,
+	diagnosis *diagnose.Diagnosis,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	if blocking := diagnosis.Blocking(); blocking != nil {
+		diagnosis.AddWaiting("Records", "", "Blocked at "+blocking.Name)
+		return nil
+	}
+
+	r := &ServiceRouteReconciler{
+		Client:                        d.Client,
+		DefaultRouterGatewayNamespace: d.DefaultRouterGatewayNamespace,
+		DNSBackend:                    d.dnsBackend(),
+		Identity:                      d.Identity,
+		Config:                        d.Config,
+		Ownership:                     d.Ownership,
+	}
+	desired, err := r.desiredRecordSets(ctx, serviceRoute)
+	if err != nil {
+		return err
+	}
+	if desired.blocked != nil {
+		diagnosis.AddBlocked("Records", "", desired.blocked.reason, desired.blocked.message)
+		return nil
+	}
+
+	claims := desired.claims
+	if len(claims.recordSets) == 0 && len(claims.conflicts) > 0 {
+		diagnosis.AddBlocked("Records", "", consts.ReasonClaimedByOtherCluster, takeoverConflictMessage(claims.conflicts))
+		return nil
+	}
+	message := fmt.Sprintf("%d record sets to publish", len(claims.recordSets))
+	if d.Ownership == nil {
+		message += ", claims of other clusters not checked"
+	}
+	diagnosis.AddOK("Records", "", message)
+	return nil
+}
+
+// diagnoseDNSEndpoints reports the DNSEndpoints published for the ServiceRoute.
+// Without any, the ServiceRoute's own Ready condition explains why nothing is published.
+func (d *RouteDiagnoser) diagnoseDNSEndpoints(
+	ctx context.Context,
+	diagnosis *diagnose.Diagnosis,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	backend := d.dnsBackend()
+	published, err := backend.List(ctx, serviceRoute.Namespace, serviceRouteRecordLabels(
+		types.NamespacedName{Name: serviceRoute.Name, Namespace: serviceRoute.Namespace}))
+	if err != nil {
+		return err
+	}
+
+	if len(published) == 0 {
+		if blocking := diagnosis.Blocking(); blocking != nil {
+			diagnosis.AddWaiting("DNSEndpoints", "", "None published, blocked at "+blocking.Name)
+			return nil
+		}
+		reason, message := consts.ReasonDNSNotReady, "No DNSEndpoints are published for the ServiceRoute"
+		if ready := meta.FindStatusCondition(serviceRoute.Status.Conditions, consts.ConditionTypeReady); ready != nil &&
+			ready.Status != metav1.ConditionTrue {
+			reason, message = ready.Reason, ready.Message
+		}
+		diagnosis.AddBlocked("DNSEndpoints", "", reason, message)
+		return nil
+	}
+
+	names := make([]string, 0, len(published))
+	for _, set := range published {
+		names = append(names, set.Name)
+	}
+	kind := "RecordSet "
+	if _, ok := backend.(*dnsbackend.ExternalDNS); ok {
+		kind = "DNSEndpoint "
+	}
+	resource := kind + strings.Join(names, ", ")
+
+	dnsReady := meta.FindStatusCondition(serviceRoute.Status.Conditions, consts.ConditionTypeDNSReady)
+	if dnsReady != nil && dnsReady.Status != metav1.ConditionTrue {
+		diagnosis.AddBlocked("DNSEndpoints", resource, dnsReady.Reason, dnsReady.Message)
+		return nil
+	}
+	diagnosis.AddOK("DNSEndpoints", resource, fmt.Sprintf("%d published", len(published)))
+	return nil
+}
+
+// diagnoseLoadBalancer reports the address the target host of the Gateway resolves to:
+// the LoadBalancer Service of the Istio controller, or the address of the Gateway API Gateway
+func (d *RouteDiagnoser) diagnoseLoadBalancer(
+	ctx context.Context,
+	diagnosis *diagnose.Diagnosis,
+	gateway *routingv1alpha1.Gateway,
+) error {
+	if gateway == nil {
+		diagnosis.AddWaiting("LoadBalancer", "", "Requires the Gateway")
+		return nil
+	}
+
+	if usesGatewayAPI(gateway) {
+		resource := "Gateway API Gateway " + gateway.Namespace + "/" + gateway.Name
+		if gateway.Status.LoadBalancerIP == "" {
+			diagnosis.AddBlocked("LoadBalancer", resource, consts.ReasonLoadBalancerIPPending,
+				"Gateway API Gateway address pending")
+			return nil
+		}
+		diagnosis.AddOK("LoadBalancer", resource, "Address "+gateway.Status.LoadBalancerIP)
+		return nil
+	}
+
+	r := &GatewayReconciler{Client: d.Client}
+	svc, err := r.getLoadBalancerService(ctx, gateway.Spec.Controller)
+	if err != nil {
+		return err
+	}
+	if svc == nil {
+		diagnosis.AddBlocked("LoadBalancer", "", consts.ReasonLoadBalancerIPPending,
+			fmt.Sprintf("No LoadBalancer Service labeled istio=%s", gateway.Spec.Controller))
+		return nil
+	}
+
+	resource := "Service " + svc.Namespace + "/" + svc.Name
+	ip := loadBalancerIP(svc)
+	if ip == "" {
+		diagnosis.AddBlocked("LoadBalancer", resource, consts.ReasonLoadBalancerIPPending, "LoadBalancer IP pending")
+		return nil
+	}
+	diagnosis.AddOK("LoadBalancer", resource, "IP "+ip)
+	return nil
+}
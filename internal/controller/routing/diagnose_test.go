@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/diagnose"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+)
+
+var _ = Describe("RouteDiagnoser", func() {
+	var (
+		ctx          context.Context
+		c            client.Client
+		diagnoser    *RouteDiagnoser
+		serviceRoute *routingv1alpha1.ServiceRoute
+		dnsPolicy    *routingv1alpha1.DNSPolicy
+	)
+
+	key := types.NamespacedName{Name: "api", Namespace: "default"}
+
+	// hops returns the name, status and reason of every hop
+	hops := func(diagnosis *diagnose.Diagnosis) []string {
+		var hops []string
+		for _, hop := range diagnosis.Hops {
+			hops = append(hops, hop.Name+" "+hop.Status+" "+hop.Reason)
+		}
+		return hops
+	}
+
+	// serving creates the resources of a ServiceRoute that would be serving
+	serving := func() {
+		dnsPolicy.Status = routingv1alpha1.DNSPolicyStatus{
+			Phase:             "Active",
+			Active:            true,
+			ActiveControllers: []string{"external-dns-weu"},
+		}
+		Expect(c.Status().Update(ctx, dnsPolicy)).To(Succeed())
+		Expect(c.Create(ctx, &clusterv1alpha1.DNSConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns-config"},
+			Spec: clusterv1alpha1.DNSConfigurationSpec{
+				ExternalDNSControllers: []clusterv1alpha1.ExternalDNSController{{Name: "external-dns-weu", Region: "weu"}},
+			},
+		})).To(Succeed())
+		Expect(c.Create(ctx, &clusterv1alpha1.ClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+			Spec: clusterv1alpha1.ClusterIdentitySpec{
+				Region:            "weu",
+				Cluster:           "aks",
+				Domain:            "example.com",
+				EnvironmentLetter: "d",
+			},
+		})).To(Succeed())
+		Expect(c.Create(ctx, &routingv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"},
+			Spec: routingv1alpha1.GatewaySpec{
+				Controller:     "aks-istio-ingressgateway-internal",
+				CredentialName: "cert",
+				TargetPostfix:  "internal",
+			},
+		})).To(Succeed())
+		Expect(c.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aks-istio-ingressgateway-internal",
+				Namespace: "aks-istio-ingress",
+				Labels:    map[string]string{"istio": "aks-istio-ingressgateway-internal"},
+			},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+			}},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(clusterv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(testScheme).
+			WithStatusSubresource(&routingv1alpha1.ServiceRoute{}, &routingv1alpha1.DNSPolicy{}).Build()
+
+		diagnoser = &RouteDiagnoser{
+			Client:                        c,
+			DefaultRouterGatewayNamespace: "istio-system",
+			Identity:                      clusteridentity.NewReaderProvider(c),
+			Config:                        dnsconfiguration.NewReaderProvider(c),
+		}
+
+		serviceRoute = &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				GatewayName: "default-gateway",
+				Environment: "dev",
+				Application: "shop",
+			},
+		}
+		Expect(c.Create(ctx, serviceRoute)).To(Succeed())
+		dnsPolicy = &routingv1alpha1.DNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: key.Namespace},
+			Spec:       routingv1alpha1.DNSPolicySpec{Mode: "RegionBound", SourceRegion: "neu"},
+		}
+		Expect(c.Create(ctx, dnsPolicy)).To(Succeed())
+	})
+
+	It("should report the first blocking hop and wait on the hops depending on it", func() {
+		dnsPolicy.Status = routingv1alpha1.DNSPolicyStatus{
+			Phase: "Inactive",
+			Conditions: []metav1.Condition{{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "PolicyInactive",
+				Message: "sourceRegion neu does not match the cluster region weu",
+			}},
+		}
+		Expect(c.Status().Update(ctx, dnsPolicy)).To(Succeed())
+
+		diagnosis, err := diagnoser.Diagnose(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hops(diagnosis)).To(Equal([]string{
+			"ServiceRoute OK ",
+			"DNSPolicy OK ",
+			"Active Blocked DNSPolicyInactive",
+			"DNSConfiguration Blocked DNSConfigurationNotAvailable",
+			"Gateway Blocked GatewayNotFound",
+			"ClusterIdentity Blocked ClusterIdentityNotAvailable",
+			"Records Waiting ",
+			"DNSEndpoints Waiting ",
+			"LoadBalancer Waiting ",
+		}))
+		Expect(diagnosis.Blocking().Message).To(Equal("DNSPolicy is not active for this cluster: " +
+			"PolicyInactive: sourceRegion neu does not match the cluster region weu"))
+		Expect(diagnosis.Hops[1].Resource).To(Equal("DNSPolicy default/policy"))
+	})
+
+	It("should explain missing DNSEndpoints with the Ready condition of the ServiceRoute", func() {
+		serving()
+		serviceRoute.Status.Conditions = []metav1.Condition{{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "HostnameConflict",
+			Message: "Hostname api-ns-d-dev-shop.example.com is already owned by ServiceRoute default/other",
+		}}
+		Expect(c.Status().Update(ctx, serviceRoute)).To(Succeed())
+
+		diagnosis, err := diagnoser.Diagnose(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosis.Blocking()).To(Equal(&diagnose.Hop{
+			Name:    "DNSEndpoints",
+			Status:  diagnose.StatusBlocked,
+			Reason:  "HostnameConflict",
+			Message: "Hostname api-ns-d-dev-shop.example.com is already owned by ServiceRoute default/other",
+		}))
+		Expect(diagnosis.Hops[len(diagnosis.Hops)-1]).To(Equal(diagnose.Hop{
+			Name:     "LoadBalancer",
+			Resource: "Service aks-istio-ingress/aks-istio-ingressgateway-internal",
+			Status:   diagnose.StatusOK,
+			Message:  "IP 10.0.0.1",
+		}))
+	})
+
+	It("should report every hop OK for a serving ServiceRoute", func() {
+		serving()
+		reconciler := &ServiceRouteReconciler{Client: c, DNSBackend: dnsbackend.NewExternalDNS(c)}
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, []*dnsbackend.RecordSet{{
+			Name:      "api-external-dns-weu",
+			Namespace: key.Namespace,
+			Labels:    serviceRouteRecordLabels(key),
+			Records: []dnsbackend.Record{
+				{DNSName: "api-ns-d-dev-shop.example.com", RecordType: "CNAME", Targets: []string{"aks-weu-internal.example.com"}},
+			},
+		}})).To(Succeed())
+
+		diagnosis, err := diagnoser.Diagnose(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosis.Blocking()).To(BeNil())
+		Expect(hops(diagnosis)).To(HaveLen(9))
+		Expect(diagnosis.Hops[6]).To(Equal(diagnose.Hop{
+			Name:    "Records",
+			Status:  diagnose.StatusOK,
+			Message: "1 record sets to publish, claims of other clusters not checked",
+		}))
+		Expect(diagnosis.Hops[7]).To(Equal(diagnose.Hop{
+			Name:     "DNSEndpoints",
+			Resource: "DNSEndpoint api-external-dns-weu",
+			Status:   diagnose.StatusOK,
+			Message:  "1 published",
+		}))
+	})
+
+	It("should list the records with the configured DNS backend and check claims", func() {
+		serving()
+		diagnoser.DNSBackend = &staticBackend{sets: []*dnsbackend.RecordSet{{Name: "api-rfc2136", Namespace: key.Namespace}}}
+		diagnoser.Ownership = fakeOwnershipResolver{}
+
+		diagnosis, err := diagnoser.Diagnose(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diagnosis.Blocking()).To(BeNil())
+		Expect(diagnosis.Hops[6].Message).To(Equal("1 record sets to publish"))
+		Expect(diagnosis.Hops[7]).To(Equal(diagnose.Hop{
+			Name:     "DNSEndpoints",
+			Resource: "RecordSet api-rfc2136",
+			Status:   diagnose.StatusOK,
+			Message:  "1 published",
+		}))
+	})
+})
+
+// staticBackend lists a fixed set of published record sets
+type staticBackend struct {
+	dnsbackend.DNSBackend
+	sets []*dnsbackend.RecordSet
+}
+
+func (b *staticBackend) List(context.Context, string, map[string]string) ([]*dnsbackend.RecordSet, error) {
+	return b.sets, nil
+}
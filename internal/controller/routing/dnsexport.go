@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsexport"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+)
+
+// RecordExporter computes every DNS record the operator publishes from the current cluster state.
+// It walks the ServiceRoutes, Gateways, DNSPolicy status and LoadBalancer Services with the same
+// rules as the ServiceRoute and IngressDNS reconcilers, so the export shows what DNS should contain
+// independently of what the DNS backend has published so far.
+type RecordExporter struct {
+	client.Client
+	DefaultRouterGatewayNamespace string
+	// Identity and Config provide the cluster identity and DNS configuration the records are computed for
+	Identity clusteridentity.IdentityProvider
+	Config   dnsconfiguration.ConfigProvider
+	// DNSBackend holds the published records the ownership generations are read from,
+	// defaults to ExternalDNS DNSEndpoints
+	DNSBackend dnsbackend.DNSBackend
+	// Ownership looks up the claims of other clusters as the ServiceRoute reconciler does,
+	// nil exports every record set without checking for conflicting claims
+	Ownership ownership.Resolver
+}
+
+var _ dnsexport.Source = &RecordExporter{}
+
+// Export implements dnsexport.Source
+func (e *RecordExporter) Export(ctx context.Context) (*dnsexport.Export, error) {
+	export := &dnsexport.Export{}
+
+	clusterIdentity, err := e.Identity.Identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if clusterIdentity == nil {
+		export.AddSkipped("ClusterIdentity", "ClusterIdentity is not configured, no records are published")
+		return export, nil
+	}
+	previousIdentities, err := e.Identity.PreviousIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsConfig, err := e.Config.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dnsConfig == nil {
+		export.AddSkipped("DNSConfiguration", "DNSConfiguration is not configured, no records are published")
+		return export, nil
+	}
+
+	if err := e.exportServiceRoutes(ctx, export); err != nil {
+		return nil, err
+	}
+	if err := e.exportGateways(ctx, export, clusterIdentity, previousIdentities, dnsConfig); err != nil {
+		return nil, err
+	}
+
+	export.Sort()
+	return export, nil
+}
+
+// exportServiceRoutes adds the CNAME records of every ServiceRoute that would be published
+func (e *RecordExporter) exportServiceRoutes(ctx context.Context, export *dnsexport.Export) error {
+	dnsBackend := e.DNSBackend
+	if dnsBackend == nil {
+		dnsBackend = dnsbackend.NewExternalDNS(e.Client)
+	}
+	r := &ServiceRouteReconciler{
+		Client:                        e.Client,
+		DefaultRouterGatewayNamespace: e.DefaultRouterGatewayNamespace,
+		DNSBackend:                    dnsBackend,
+		Identity:                      e.Identity,
+		Config:                        e.Config,
+		Ownership:                     e.Ownership,
+	}
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := e.List(ctx, &serviceRoutes); err != nil {
+		return err
+	}
+
+	for i := range serviceRoutes.Items {
+		serviceRoute := &serviceRoutes.Items[i]
+		if serviceRoute.DeletionTimestamp != nil {
+			continue
+		}
+		source := fmt.Sprintf("ServiceRoute %s/%s", serviceRoute.Namespace, serviceRoute.Name)
+
+		desired, err := r.desiredRecordSets(ctx, serviceRoute)
+		if err != nil {
+			return err
+		}
+		if desired.blocked != nil {
+			export.AddSkipped(source, desired.blocked.message)
+			continue
+		}
+		if len(desired.claims.conflicts) > 0 {
+			export.AddSkipped(source, takeoverConflictMessage(desired.claims.conflicts))
+		}
+		for _, set := range desired.claims.recordSets {
+			export.AddRecordSet(source, set)
+		}
+	}
+
+	return nil
+}
+
+// exportGateways adds the A records of the gateway target hosts that would be published
+func (e *RecordExporter) exportGateways(
+	ctx context.Context,
+	export *dnsexport.Export,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) error {
+	r := &IngressDNSReconciler{Client: e.Client, Identity: e.Identity, Config: e.Config}
+
+	var gateways routingv1alpha1.GatewayList
+	if err := e.List(ctx, &gateways); err != nil {
+		return err
+	}
+
+	activeConfigs, gatewayAPIGateways := partitionGateways(gateways.Items)
+
+	// Iterate in a stable order so skipped entries do not move between exports
+	configs := make([]gatewayControllerConfig, 0, len(activeConfigs))
+	for config := range activeConfigs {
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		if configs[i].controller != configs[j].controller {
+			return configs[i].controller < configs[j].controller
+		}
+		return configs[i].targetPostfix < configs[j].targetPostfix
+	})
+
+	for _, config := range configs {
+		source := fmt.Sprintf("Gateway controller %s (%s)", config.controller, config.targetPostfix)
+
+		svc, err := r.getLoadBalancerService(ctx, config.controller)
+		if err != nil {
+			return err
+		}
+		if svc == nil {
+			export.AddSkipped(source, "LoadBalancer Service not found")
+			continue
+		}
+		ip := loadBalancerIP(svc)
+		if ip == "" {
+			export.AddSkipped(source, fmt.Sprintf("Service %s/%s has no LoadBalancer IP", svc.Namespace, svc.Name))
+			continue
+		}
+
+		recordSets, err := gatewayServiceRecordSets(config.controller, config.targetPostfix, svc, ip, clusterIdentity, previousIdentities, dnsConfig)
+		if err != nil {
+			export.AddSkipped(source, err.Error())
+			continue
+		}
+		for _, set := range recordSets {
+			export.AddRecordSet(source, set)
+		}
+	}
+
+	for i := range gatewayAPIGateways {
+		gateway := &gatewayAPIGateways[i]
+		source := fmt.Sprintf("Gateway %s/%s", gateway.Namespace, gateway.Name)
+
+		if gateway.Status.LoadBalancerIP == "" {
+			export.AddSkipped(source, "Gateway has no address")
+			continue
+		}
+
+		recordSets, err := gatewayAPIRecordSets(gateway, clusterIdentity, previousIdentities, dnsConfig)
+		if err != nil {
+			export.AddSkipped(source, err.Error())
+			continue
+		}
+		for _, set := range recordSets {
+			export.AddRecordSet(source, set)
+		}
+	}
+
+	return nil
+}
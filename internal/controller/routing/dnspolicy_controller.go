@@ -19,22 +19,31 @@ package routing
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
 	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
 	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
@@ -42,6 +51,21 @@ import (
 type DNSPolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// PeerHealth checks the peer regions of Failover mode policies, defaults to HTTP and Lease probes
+	PeerHealth PeerHealthChecker
+	// Identity provides the cluster identity, defaults to the manager's informer cache
+	Identity clusteridentity.IdentityProvider
+	// Config provides the DNS configuration, defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// Recorder records activation changes and failover switches of the policy as events
+	Recorder events.EventRecorder
+
+	// statusWriter persists the status of the evaluated policy, defaults to updating the DNSPolicy.
+	// The ClusterDNSPolicy reconciler sets it to write the status back to the ClusterDNSPolicy.
+	statusWriter func(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) error
+	// eventTarget is the object events are recorded on, defaults to the evaluated DNSPolicy.
+	// The ClusterDNSPolicy reconciler sets it to the ClusterDNSPolicy.
+	eventTarget runtime.Object
 }
 
 //+kubebuilder:rbac:groups=routing.router.io,resources=dnspolicies,verbs=get;list;watch;create;update;patch;delete
@@ -49,6 +73,9 @@ type DNSPolicyReconciler struct {
 //+kubebuilder:rbac:groups=routing.router.io,resources=dnspolicies/finalizers,verbs=update
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -69,67 +96,88 @@ func (r *DNSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	return r.reconcilePolicy(ctx, &dnsPolicy)
+}
+
+// reconcilePolicy evaluates a policy against the cluster identity and DNS configuration,
+// and records the outcome in its status.
+func (r *DNSPolicyReconciler) reconcilePolicy(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
 	// ClusterIdentity is required to determine the current region and validate if the policy is active.
 	// Cache-first with CRD fallback.
-	clusterIdentity, err := clusteridentity.Fetch(ctx, r.Client)
+	clusterIdentity, err := r.Identity.Identity(ctx)
 	if err != nil {
 		logger.Error(err, "failed to get ClusterIdentity")
-		return r.updateStatusPending(ctx, &dnsPolicy, consts.ReasonClusterIdentityNotAvailable,
+		return r.updateStatusPending(ctx, dnsPolicy, consts.ReasonClusterIdentityNotAvailable,
 			"Waiting for ClusterIdentity to be configured")
 	}
 	if clusterIdentity == nil {
 		logger.Info("ClusterIdentity not available, requeueing")
-		return r.updateStatusPending(ctx, &dnsPolicy, consts.ReasonClusterIdentityNotAvailable,
+		return r.updateStatusPending(ctx, dnsPolicy, consts.ReasonClusterIdentityNotAvailable,
 			"Waiting for ClusterIdentity to be configured")
 	}
 
 	// DNSConfiguration provides the list of available ExternalDNS controllers and their regions.
 	// Cache-first with CRD fallback.
-	dnsConfig, err := dnsconfiguration.Fetch(ctx, r.Client)
+	dnsConfig, err := r.Config.Config(ctx)
 	if err != nil {
 		logger.Error(err, "failed to get DNSConfiguration")
-		return r.updateStatusPending(ctx, &dnsPolicy, consts.ReasonDNSConfigurationNotAvailable,
+		return r.updateStatusPending(ctx, dnsPolicy, consts.ReasonDNSConfigurationNotAvailable,
 			"Waiting for DNSConfiguration to be configured")
 	}
 	if dnsConfig == nil {
 		logger.Info("DNSConfiguration not available, requeueing")
-		return r.updateStatusPending(ctx, &dnsPolicy, consts.ReasonDNSConfigurationNotAvailable,
+		return r.updateStatusPending(ctx, dnsPolicy, consts.ReasonDNSConfigurationNotAvailable,
 			"Waiting for DNSConfiguration to be configured")
 	}
 
 	// Validate the policy spec against the available configuration to prevent invalid states.
-	if err := r.validateDNSPolicy(&dnsPolicy, dnsConfig); err != nil {
+	if err := validation.DNSPolicy(dnsPolicy, dnsConfig); err != nil {
 		logger.Error(err, "validation failed")
-		return r.updateStatusFailed(ctx, &dnsPolicy, consts.ReasonValidationFailed, err.Error())
+		return r.updateStatusFailed(ctx, dnsPolicy, consts.ReasonValidationFailed, err.Error())
+	}
+
+	// Several DNSPolicies in a namespace must not compete for the same ServiceRoutes.
+	// ClusterDNSPolicies have no namespace and select namespaces instead of routes.
+	if dnsPolicy.Namespace != "" {
+		if err := r.setRouteSelectorConflictCondition(ctx, dnsPolicy); err != nil {
+			logger.Error(err, "failed to check for routeSelector conflicts")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// ServiceRoutes withholding hostnames claimed by another cluster are reported on their policy.
+	if err := r.setTakeoverConflictCondition(ctx, dnsPolicy); err != nil {
+		logger.Error(err, "failed to check for takeover conflicts")
+		return ctrl.Result{}, err
 	}
 
 	// Determine if this policy applies to the current cluster based on region and cluster name constraints.
-	policyActive, inactiveReason := r.isPolicyActive(&dnsPolicy, clusterIdentity)
+	policyActive, inactiveReason := r.isPolicyActive(dnsPolicy, clusterIdentity)
 	if !policyActive {
 		logger.Info("DNSPolicy is not active for this cluster", "name", dnsPolicy.Name, "namespace", dnsPolicy.Namespace, "reason", inactiveReason)
-		return r.updateStatusInactive(ctx, &dnsPolicy, inactiveReason)
+		return r.updateStatusInactive(ctx, dnsPolicy, inactiveReason)
 	}
 
-	// Calculate which ExternalDNS controllers should process this policy based on the mode (Active/RegionBound).
-	activeControllers := r.determineActiveControllers(&dnsPolicy, clusterIdentity, dnsConfig)
-
-	// Synchronize the status with the determined active controllers to reflect the current state.
-	return r.updateStatusActive(ctx, &dnsPolicy, activeControllers)
-}
-
-// validateDNSPolicy validates the DNSPolicy specification
-func (r *DNSPolicyReconciler) validateDNSPolicy(dnsPolicy *routingv1alpha1.DNSPolicy, dnsConfig *dnsconfiguration.DNSConfiguration) error {
-	// Validate mode
-	if dnsPolicy.Spec.Mode != "Active" && dnsPolicy.Spec.Mode != "RegionBound" {
-		return fmt.Errorf("invalid mode: %s, must be Active or RegionBound", dnsPolicy.Spec.Mode)
+	// In Failover mode the health of the peer regions decides which of them this cluster takes over.
+	var nextProbe time.Duration
+	if dnsPolicy.Spec.Mode == "Failover" {
+		nextProbe = r.probePeers(ctx, dnsPolicy)
+	} else {
+		dnsPolicy.Status.Peers = nil
 	}
 
-	// Validate controllers are defined in DNSConfiguration
-	if len(dnsConfig.ExternalDNSControllers) == 0 {
-		return fmt.Errorf("at least one ExternalDNS controller must be defined in DNSConfiguration")
-	}
+	// Calculate which ExternalDNS controllers should process this policy based on the mode (Active/RegionBound/Failover).
+	activeControllers := r.determineActiveControllers(dnsPolicy, clusterIdentity, dnsConfig)
 
-	return nil
+	// Synchronize the status with the determined active controllers to reflect the current state.
+	result, err := r.updateStatusActive(ctx, dnsPolicy, activeControllers)
+	if err == nil && !result.Requeue && dnsPolicy.Spec.Mode == "Failover" {
+		// Peer health is polled, there is no event when a peer region fails
+		result.RequeueAfter = nextProbe
+	}
+	return result, err
 }
 
 // isPolicyActive checks if the DNSPolicy should be active based on cluster identity.
@@ -163,15 +211,75 @@ func (r *DNSPolicyReconciler) isPolicyActive(
 //   - Enable one cluster to manage DNS records across multiple regions
 //   - Selects ALL controllers (if policy is active for this cluster)
 //   - Traffic: DNS records in ALL regions point to THIS cluster's gateway
+//
+// Failover Mode:
+//   - Behaves like Active mode while the peer regions are healthy
+//   - Adds the controllers of every peer region taken over after failing its health signal
+//   - Traffic: clients of a failed peer region are sent to THIS cluster's gateway
+//
+// The controllers computed from the mode are then narrowed by RegionSelector, or replaced by Controllers.
 func (r *DNSPolicyReconciler) determineActiveControllers(
 	dnsPolicy *routingv1alpha1.DNSPolicy,
 	clusterIdentity *clusteridentity.ClusterIdentity,
 	dnsConfig *dnsconfiguration.DNSConfiguration,
 ) []string {
-	if dnsPolicy.Spec.Mode == "Active" {
-		return r.determineActiveControllersForActiveMode(clusterIdentity, dnsConfig)
+	var activeControllers []string
+	switch dnsPolicy.Spec.Mode {
+	case "Active":
+		activeControllers = r.determineActiveControllersForActiveMode(clusterIdentity, dnsConfig)
+	case "Failover":
+		activeControllers = r.determineActiveControllersForFailoverMode(dnsPolicy, clusterIdentity, dnsConfig)
+	default:
+		activeControllers = r.determineActiveControllersForRegionBoundMode(dnsConfig)
 	}
-	return r.determineActiveControllersForRegionBoundMode(dnsConfig)
+	return r.applyControllerSelection(dnsPolicy, dnsConfig, activeControllers)
+}
+
+// applyControllerSelection applies the explicit controller selection of the policy to the computed controllers.
+//
+// Controllers replaces the computed controllers, RegionSelector keeps only the computed controllers
+// in the selected regions. Without either, the computed controllers are returned unchanged.
+func (r *DNSPolicyReconciler) applyControllerSelection(
+	dnsPolicy *routingv1alpha1.DNSPolicy,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+	activeControllers []string,
+) []string {
+	if len(dnsPolicy.Spec.Controllers) > 0 {
+		explicit := make(map[string]bool, len(dnsPolicy.Spec.Controllers))
+		for _, name := range dnsPolicy.Spec.Controllers {
+			explicit[name] = true
+		}
+
+		// Keep the DNSConfiguration order, like the mode computed controllers
+		var selected []string
+		for _, controller := range dnsConfig.ExternalDNSControllers {
+			if explicit[controller.Name] {
+				selected = append(selected, controller.Name)
+			}
+		}
+		return selected
+	}
+
+	if len(dnsPolicy.Spec.RegionSelector) > 0 {
+		regions := make(map[string]bool, len(dnsPolicy.Spec.RegionSelector))
+		for _, region := range dnsPolicy.Spec.RegionSelector {
+			regions[region] = true
+		}
+		controllerRegions := make(map[string]string, len(dnsConfig.ExternalDNSControllers))
+		for _, controller := range dnsConfig.ExternalDNSControllers {
+			controllerRegions[controller.Name] = controller.Region
+		}
+
+		var selected []string
+		for _, name := range activeControllers {
+			if regions[controllerRegions[name]] {
+				selected = append(selected, name)
+			}
+		}
+		return selected
+	}
+
+	return activeControllers
 }
 
 // determineActiveControllersForActiveMode selects controllers matching the cluster's region.
@@ -213,6 +321,31 @@ func (r *DNSPolicyReconciler) determineActiveControllersForActiveMode(
 	return activeControllers
 }
 
+// determineActiveControllersForFailoverMode selects the Active mode controllers
+// and the controllers of the peer regions currently taken over.
+func (r *DNSPolicyReconciler) determineActiveControllersForFailoverMode(
+	dnsPolicy *routingv1alpha1.DNSPolicy,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) []string {
+	activeControllers := r.determineActiveControllersForActiveMode(clusterIdentity, dnsConfig)
+
+	selected := make(map[string]bool, len(activeControllers))
+	for _, name := range activeControllers {
+		selected[name] = true
+	}
+
+	takenOver := takenOverRegions(dnsPolicy)
+	for _, controller := range dnsConfig.ExternalDNSControllers {
+		if takenOver[controller.Region] && !selected[controller.Name] {
+			activeControllers = append(activeControllers, controller.Name)
+			selected[controller.Name] = true
+		}
+	}
+
+	return activeControllers
+}
+
 // determineActiveControllersForRegionBoundMode selects all controllers.
 //
 // In RegionBound mode, a cluster provisions DNS records for ALL defined regions,
@@ -262,6 +395,8 @@ func (r *DNSPolicyReconciler) updateStatusActive(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	wasActive := dnsPolicy.Status.Active && meta.IsStatusConditionTrue(dnsPolicy.Status.Conditions, consts.ConditionTypeReady)
+	previousControllers := dnsPolicy.Status.ActiveControllers
 	dnsPolicy.Status.Phase = consts.PhaseActive
 	dnsPolicy.Status.Active = true
 	dnsPolicy.Status.ActiveControllers = activeControllers
@@ -273,8 +408,9 @@ func (r *DNSPolicyReconciler) updateStatusActive(
 		Message:            "DNSPolicy is active",
 	})
 
-	if err := r.Status().Update(ctx, dnsPolicy); err != nil {
+	if err := r.updateStatus(ctx, dnsPolicy); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues(dnsPolicyReference(dnsPolicy).Kind).Inc()
 			logger.Info("DNSPolicy status update conflict (Active), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -282,6 +418,15 @@ func (r *DNSPolicyReconciler) updateStatusActive(
 		return ctrl.Result{}, err
 	}
 
+	switch {
+	case !wasActive:
+		recorder.Eventf(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeNormal, consts.ReasonPolicyActivated,
+			recorder.ActionReconcile, "Policy is active for this cluster, publishing to %s", controllerList(activeControllers))
+	case !slices.Equal(previousControllers, activeControllers):
+		recorder.Eventf(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeNormal, consts.ReasonActiveControllersChanged,
+			recorder.ActionReconcile, "Publishing to %s", controllerList(activeControllers))
+	}
+
 	logger.Info("DNSPolicy reconciled successfully", "activeControllers", len(activeControllers))
 	return ctrl.Result{}, nil
 }
@@ -294,8 +439,10 @@ func (r *DNSPolicyReconciler) updateStatusInactive(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(dnsPolicy.Status.Conditions)
 	dnsPolicy.Status.Active = false
 	dnsPolicy.Status.ActiveControllers = []string{}
+	dnsPolicy.Status.Peers = nil
 	meta.SetStatusCondition(&dnsPolicy.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionFalse,
@@ -304,8 +451,9 @@ func (r *DNSPolicyReconciler) updateStatusInactive(
 		Message:            fmt.Sprintf("Policy not active for this cluster: %s", reason),
 	})
 
-	if err := r.Status().Update(ctx, dnsPolicy); err != nil {
+	if err := r.updateStatus(ctx, dnsPolicy); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues(dnsPolicyReference(dnsPolicy).Kind).Inc()
 			logger.Info("DNSPolicy status update conflict (Inactive), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -313,6 +461,8 @@ func (r *DNSPolicyReconciler) updateStatusInactive(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeNormal, previous, dnsPolicy.Status.Conditions)
+
 	logger.Info("DNSPolicy is inactive", "reason", reason)
 	return ctrl.Result{}, nil
 }
@@ -325,6 +475,7 @@ func (r *DNSPolicyReconciler) updateStatusPending(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(dnsPolicy.Status.Conditions)
 	meta.SetStatusCondition(&dnsPolicy.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionFalse,
@@ -333,8 +484,9 @@ func (r *DNSPolicyReconciler) updateStatusPending(
 		Message:            message,
 	})
 
-	if err := r.Status().Update(ctx, dnsPolicy); err != nil {
+	if err := r.updateStatus(ctx, dnsPolicy); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues(dnsPolicyReference(dnsPolicy).Kind).Inc()
 			logger.Info("DNSPolicy status update conflict (Pending), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -342,6 +494,8 @@ func (r *DNSPolicyReconciler) updateStatusPending(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeNormal, previous, dnsPolicy.Status.Conditions)
+
 	logger.Info("DNSPolicy marked as Pending", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
@@ -354,6 +508,7 @@ func (r *DNSPolicyReconciler) updateStatusFailed(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(dnsPolicy.Status.Conditions)
 	meta.SetStatusCondition(&dnsPolicy.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
 		Status:             metav1.ConditionFalse,
@@ -362,8 +517,9 @@ func (r *DNSPolicyReconciler) updateStatusFailed(
 		Message:            message,
 	})
 
-	if err := r.Status().Update(ctx, dnsPolicy); err != nil {
+	if err := r.updateStatus(ctx, dnsPolicy); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues(dnsPolicyReference(dnsPolicy).Kind).Inc()
 			logger.Info("DNSPolicy status update conflict (Failed), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -371,21 +527,69 @@ func (r *DNSPolicyReconciler) updateStatusFailed(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeWarning, previous, dnsPolicy.Status.Conditions)
+
 	logger.Info("DNSPolicy marked as Failed", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
 
+// updateStatus persists the status of the evaluated policy
+func (r *DNSPolicyReconciler) updateStatus(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) error {
+	if r.statusWriter != nil {
+		return r.statusWriter(ctx, dnsPolicy)
+	}
+	return r.Status().Update(ctx, dnsPolicy)
+}
+
+// eventObject returns the object the events of an evaluated policy are recorded on
+func (r *DNSPolicyReconciler) eventObject(dnsPolicy *routingv1alpha1.DNSPolicy) runtime.Object {
+	if r.eventTarget != nil {
+		return r.eventTarget
+	}
+	return dnsPolicy
+}
+
+// controllerList describes the active controllers of a policy in an event
+func controllerList(controllers []string) string {
+	if len(controllers) == 0 {
+		return "no ExternalDNS controllers"
+	}
+	return strings.Join(controllers, ", ")
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DNSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.PeerHealth == nil {
+		r.PeerHealth = newProbePeerHealthChecker(mgr.GetClient())
+	}
+	if r.Identity == nil {
+		r.Identity = clusteridentity.NewProvider(mgr.GetCache())
+	}
+	if r.Config == nil {
+		r.Config = dnsconfiguration.NewProvider(mgr.GetCache())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("dnspolicy-controller")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&routingv1alpha1.DNSPolicy{}).
+		// Status updates, such as the peer health counters, must not trigger a reconcile
+		For(&routingv1alpha1.DNSPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// A routeSelector conflict is reported on both policies, so a spec change
+		// or deletion re-evaluates the other policies in the namespace
 		Watches(
-			&clusterv1alpha1.ClusterIdentity{},
-			handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToDNSPolicies),
+			&routingv1alpha1.DNSPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.mapToNamespaceDNSPolicies),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
+		// ServiceRoutes report routeSelector matches through their labels and takeover conflicts
+		// through their status
 		Watches(
-			&clusterv1alpha1.DNSConfiguration{},
-			handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToDNSPolicies),
+			&routingv1alpha1.ServiceRoute{},
+			handler.EnqueueRequestsFromMapFunc(r.mapToNamespaceDNSPolicies),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, takeoverConflictChanged)),
 		).
+		WatchesRawSource(r.Identity.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToDNSPolicies))).
+		WatchesRawSource(r.Config.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToDNSPolicies))).
 		Complete(r)
 }
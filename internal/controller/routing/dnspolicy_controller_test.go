@@ -18,14 +18,22 @@ package routing
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
@@ -509,5 +517,448 @@ var _ = Describe("DNSPolicy Controller", func() {
 
 			Expect(k8sClient.Delete(ctx, dnsPolicy)).Should(Succeed())
 		})
+
+		It("should narrow the active controllers to the region selector", func() {
+			dnsPolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dnspolicy-region-selector",
+					Namespace: "default",
+				},
+				Spec: routingv1alpha1.DNSPolicySpec{
+					Mode:           "RegionBound",
+					RegionSelector: []string{"neu", "weu"},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, dnsPolicy)).Should(Succeed())
+
+			dnsPolicyLookupKey := types.NamespacedName{
+				Name:      dnsPolicy.Name,
+				Namespace: dnsPolicy.Namespace,
+			}
+			createdDNSPolicy := &routingv1alpha1.DNSPolicy{}
+
+			// RegionBound selects all controllers, the selector drops frc
+			Eventually(func() []string {
+				err := k8sClient.Get(ctx, dnsPolicyLookupKey, createdDNSPolicy)
+				if err != nil {
+					return nil
+				}
+				return createdDNSPolicy.Status.ActiveControllers
+			}, timeout, interval).Should(ConsistOf("external-dns-neu", "external-dns-weu", "external-dns-neu-1", "external-dns-neu-2"))
+
+			Expect(k8sClient.Delete(ctx, dnsPolicy)).Should(Succeed())
+		})
+
+		It("should use the explicit controllers instead of the mode", func() {
+			dnsPolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dnspolicy-controllers",
+					Namespace: "default",
+				},
+				Spec: routingv1alpha1.DNSPolicySpec{
+					Mode:        "Active",
+					Controllers: []string{"external-dns-weu", "external-dns-neu"},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, dnsPolicy)).Should(Succeed())
+
+			dnsPolicyLookupKey := types.NamespacedName{
+				Name:      dnsPolicy.Name,
+				Namespace: dnsPolicy.Namespace,
+			}
+			createdDNSPolicy := &routingv1alpha1.DNSPolicy{}
+
+			Eventually(func() []string {
+				err := k8sClient.Get(ctx, dnsPolicyLookupKey, createdDNSPolicy)
+				if err != nil {
+					return nil
+				}
+				return createdDNSPolicy.Status.ActiveControllers
+			}, timeout, interval).Should(Equal([]string{"external-dns-neu", "external-dns-weu"}))
+
+			Expect(k8sClient.Delete(ctx, dnsPolicy)).Should(Succeed())
+		})
+
+		It("should take over a peer region in Failover mode when its heartbeat Lease lapses", func() {
+			dnsPolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dnspolicy-failover",
+					Namespace: "default",
+				},
+				Spec: routingv1alpha1.DNSPolicySpec{
+					Mode: "Failover",
+					Failover: &routingv1alpha1.DNSPolicyFailover{
+						Peers: []routingv1alpha1.DNSPolicyFailoverPeer{
+							{
+								Region: "weu",
+								Lease:  &routingv1alpha1.DNSPolicyLeaseProbe{Name: "weu-heartbeat"},
+							},
+						},
+						ProbeInterval:    &metav1.Duration{Duration: time.Second},
+						FailureThreshold: 1,
+						SuccessThreshold: 1,
+						MinHoldTime:      &metav1.Duration{Duration: 0},
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, dnsPolicy)).Should(Succeed())
+
+			dnsPolicyLookupKey := types.NamespacedName{
+				Name:      dnsPolicy.Name,
+				Namespace: dnsPolicy.Namespace,
+			}
+			createdDNSPolicy := &routingv1alpha1.DNSPolicy{}
+
+			// The heartbeat Lease of weu does not exist, so weu is taken over
+			Eventually(func() []string {
+				err := k8sClient.Get(ctx, dnsPolicyLookupKey, createdDNSPolicy)
+				if err != nil {
+					return nil
+				}
+				return createdDNSPolicy.Status.ActiveControllers
+			}, timeout, interval).Should(ConsistOf(
+				"external-dns-neu",
+				"external-dns-neu-1",
+				"external-dns-neu-2",
+				"external-dns-weu",
+			))
+			Expect(createdDNSPolicy.Status.Peers).To(HaveLen(1))
+			Expect(createdDNSPolicy.Status.Peers[0].TakenOver).To(BeTrue())
+			Expect(createdDNSPolicy.Status.Peers[0].Message).To(Equal("lease default/weu-heartbeat not found"))
+
+			// A renewed heartbeat hands weu back
+			lease := &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "weu-heartbeat",
+					Namespace: "default",
+				},
+				Spec: coordinationv1.LeaseSpec{
+					LeaseDurationSeconds: ptr.To[int32](3600),
+					RenewTime:            &metav1.MicroTime{Time: time.Now()},
+				},
+			}
+			Expect(k8sClient.Create(ctx, lease)).Should(Succeed())
+
+			Eventually(func() []string {
+				err := k8sClient.Get(ctx, dnsPolicyLookupKey, createdDNSPolicy)
+				if err != nil {
+					return nil
+				}
+				return createdDNSPolicy.Status.ActiveControllers
+			}, timeout, interval).Should(ConsistOf(
+				"external-dns-neu",
+				"external-dns-neu-1",
+				"external-dns-neu-2",
+			))
+
+			Expect(k8sClient.Delete(ctx, lease)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, dnsPolicy)).Should(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Failover peer health", func() {
+	failover := &routingv1alpha1.DNSPolicyFailover{
+		FailureThreshold: 2,
+		SuccessThreshold: 2,
+		MinHoldTime:      &metav1.Duration{Duration: time.Minute},
+	}
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	unhealthy := errors.New("probe failed")
+
+	It("should take over a peer only after the failure threshold", func() {
+		status := routingv1alpha1.DNSPolicyPeerStatus{Region: "weu"}
+
+		status = observePeerHealth(status, failover, unhealthy, start)
+		Expect(status.TakenOver).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(int32(1)))
+
+		status = observePeerHealth(status, failover, unhealthy, start.Add(time.Second))
+		Expect(status.TakenOver).To(BeTrue())
+		Expect(status.LastTransitionTime.Time).To(Equal(start.Add(time.Second)))
+
+		// Counters stay at the threshold while the peer keeps failing
+		status = observePeerHealth(status, failover, unhealthy, start.Add(2*time.Second))
+		Expect(status.ConsecutiveFailures).To(Equal(int32(2)))
+		Expect(status.Message).To(Equal("probe failed"))
+	})
+
+	It("should reset the failure count on a successful check", func() {
+		status := routingv1alpha1.DNSPolicyPeerStatus{Region: "weu"}
+
+		status = observePeerHealth(status, failover, unhealthy, start)
+		status = observePeerHealth(status, failover, nil, start.Add(time.Second))
+		status = observePeerHealth(status, failover, unhealthy, start.Add(2*time.Second))
+		Expect(status.TakenOver).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(int32(1)))
+	})
+
+	It("should not hand a peer back before the minimum hold time", func() {
+		status := routingv1alpha1.DNSPolicyPeerStatus{
+			Region:             "weu",
+			TakenOver:          true,
+			LastTransitionTime: &metav1.Time{Time: start},
+		}
+
+		status = observePeerHealth(status, failover, nil, start.Add(10*time.Second))
+		status = observePeerHealth(status, failover, nil, start.Add(20*time.Second))
+		Expect(status.TakenOver).To(BeTrue())
+		Expect(status.ConsecutiveSuccesses).To(Equal(int32(2)))
+
+		status = observePeerHealth(status, failover, nil, start.Add(time.Minute))
+		Expect(status.TakenOver).To(BeFalse())
+		Expect(status.Message).To(Equal("Peer region is healthy"))
+	})
+	It("should check a peer at most once per probe interval", func() {
+		checker := &countingPeerHealthChecker{err: unhealthy}
+		reconciler := &DNSPolicyReconciler{PeerHealth: checker}
+		dnsPolicy := &routingv1alpha1.DNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "failover", Namespace: "default"},
+			Spec: routingv1alpha1.DNSPolicySpec{
+				Mode: "Failover",
+				Failover: &routingv1alpha1.DNSPolicyFailover{
+					Peers:            []routingv1alpha1.DNSPolicyFailoverPeer{{Region: "weu"}},
+					ProbeInterval:    &metav1.Duration{Duration: time.Minute},
+					FailureThreshold: 2,
+					MinHoldTime:      &metav1.Duration{Duration: 0},
+				},
+			},
+		}
+
+		// Reconciles triggered by events in quick succession do not count as extra failures
+		for range 3 {
+			next := reconciler.probePeers(context.Background(), dnsPolicy)
+			Expect(next).To(BeNumerically(">", 50*time.Second))
+		}
+		Expect(checker.calls).To(Equal(1))
+		Expect(dnsPolicy.Status.Peers).To(HaveLen(1))
+		Expect(dnsPolicy.Status.Peers[0].ConsecutiveFailures).To(Equal(int32(1)))
+		Expect(dnsPolicy.Status.Peers[0].TakenOver).To(BeFalse())
+
+		// Once the interval has passed the peer is checked again
+		dnsPolicy.Status.Peers[0].LastProbeTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+		reconciler.probePeers(context.Background(), dnsPolicy)
+		Expect(checker.calls).To(Equal(2))
+		Expect(dnsPolicy.Status.Peers[0].TakenOver).To(BeTrue())
+	})
+
+	It("should only re-enqueue ServiceRoutes when a probe switches a peer", func() {
+		checker := &countingPeerHealthChecker{err: unhealthy}
+		reconciler := &DNSPolicyReconciler{PeerHealth: checker}
+		dnsPolicy := &routingv1alpha1.DNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "failover", Namespace: "default", Generation: 1},
+			Spec: routingv1alpha1.DNSPolicySpec{
+				Mode: "Failover",
+				Failover: &routingv1alpha1.DNSPolicyFailover{
+					Peers:            []routingv1alpha1.DNSPolicyFailoverPeer{{Region: "weu"}},
+					ProbeInterval:    &metav1.Duration{Duration: time.Minute},
+					FailureThreshold: 2,
+					MinHoldTime:      &metav1.Duration{Duration: 0},
+				},
+			},
+			Status: routingv1alpha1.DNSPolicyStatus{Active: true, ActiveControllers: []string{"external-dns-neu"}},
+		}
+		watch := policyRoutingPredicate()
+		probe := func() event.UpdateEvent {
+			old := dnsPolicy.DeepCopy()
+			for i := range dnsPolicy.Status.Peers {
+				dnsPolicy.Status.Peers[i].LastProbeTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			}
+			reconciler.probePeers(context.Background(), dnsPolicy)
+			return event.UpdateEvent{ObjectOld: old, ObjectNew: dnsPolicy.DeepCopy()}
+		}
+
+		// The first failure only counts, the peer is not switched
+		Expect(watch.Update(probe())).To(BeFalse())
+		Expect(dnsPolicy.Status.Peers[0].LastProbeTime).NotTo(BeNil())
+
+		// The second failure takes the peer over
+		Expect(watch.Update(probe())).To(BeTrue())
+		Expect(dnsPolicy.Status.Peers[0].TakenOver).To(BeTrue())
+
+		// Further failures keep it taken over
+		Expect(watch.Update(probe())).To(BeFalse())
+		Expect(checker.calls).To(Equal(3))
+	})
+})
+
+// countingPeerHealthChecker returns a fixed result and counts the health checks
+type countingPeerHealthChecker struct {
+	err   error
+	calls int
+}
+
+func (c *countingPeerHealthChecker) Check(context.Context, string, routingv1alpha1.DNSPolicyFailoverPeer) error {
+	c.calls++
+	return c.err
+}
+
+var _ = Describe("ClusterDNSPolicy selection", func() {
+	clusterPolicy := func(name string, priority int32, matchLabels map[string]string) routingv1alpha1.ClusterDNSPolicy {
+		policy := routingv1alpha1.ClusterDNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       routingv1alpha1.ClusterDNSPolicySpec{Priority: priority},
+		}
+		if matchLabels != nil {
+			policy.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+		}
+		return policy
+	}
+
+	It("should apply a policy without selector to every namespace", func() {
+		selected := selectClusterDNSPolicy([]routingv1alpha1.ClusterDNSPolicy{
+			clusterPolicy("default", 0, nil),
+		}, nil)
+		Expect(selected).NotTo(BeNil())
+		Expect(selected.Name).To(Equal("default"))
+	})
+
+	It("should skip policies whose selector does not match", func() {
+		selected := selectClusterDNSPolicy([]routingv1alpha1.ClusterDNSPolicy{
+			clusterPolicy("tenants", 0, map[string]string{"router.io/tenant": "true"}),
+		}, map[string]string{"kubernetes.io/metadata.name": "kube-system"})
+		Expect(selected).To(BeNil())
+	})
+
+	It("should prefer the highest priority, then the first name", func() {
+		policies := []routingv1alpha1.ClusterDNSPolicy{
+			clusterPolicy("b-default", 0, nil),
+			clusterPolicy("tenants", 10, map[string]string{"router.io/tenant": "true"}),
+			clusterPolicy("a-default", 0, nil),
+		}
+
+		selected := selectClusterDNSPolicy(policies, map[string]string{"router.io/tenant": "true"})
+		Expect(selected.Name).To(Equal("tenants"))
+
+		selected = selectClusterDNSPolicy(policies, map[string]string{})
+		Expect(selected.Name).To(Equal("a-default"))
+	})
+
+	It("should only re-enqueue the ServiceRoutes of the namespaces a policy selects", func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		route := func(namespace, name string) *routingv1alpha1.ServiceRoute {
+			return &routingv1alpha1.ServiceRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		}
+		formerTenant := route("former-tenant", "api")
+		formerTenant.Status.DNSPolicyRef = &routingv1alpha1.ServiceRouteDNSPolicyReference{Kind: "ClusterDNSPolicy", Name: "tenants"}
+		reconciler := &ServiceRouteReconciler{
+			Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: map[string]string{"router.io/tenant": "true"}}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "former-tenant"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+				route("tenant", "api"), formerTenant, route("kube-system", "api"),
+			).Build(),
+		}
+		policy := clusterPolicy("tenants", 0, map[string]string{"router.io/tenant": "true"})
+
+		// Routes the policy applied to before follow the selector change
+		Expect(reconciler.mapClusterDNSPolicyToServiceRoutes(context.Background(), &policy)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "tenant"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "former-tenant"}},
+		))
+
+		policy = clusterPolicy("default", 0, nil)
+		Expect(reconciler.mapClusterDNSPolicyToServiceRoutes(context.Background(), &policy)).To(HaveLen(3))
+	})
+
+	It("should ignore status writes that do not change routing", func() {
+		watch := policyRoutingPredicate()
+		policy := clusterPolicy("tenants", 0, nil)
+		policy.Generation = 1
+		policy.Status = routingv1alpha1.DNSPolicyStatus{
+			Active:            true,
+			ActiveControllers: []string{"external-dns-neu"},
+			Peers:             []routingv1alpha1.DNSPolicyPeerStatus{{Region: "weu", ConsecutiveFailures: 1}},
+		}
+
+		probed := policy.DeepCopy()
+		probed.Status.Peers[0].ConsecutiveFailures = 2
+		probed.Status.Peers[0].LastProbeTime = &metav1.Time{Time: time.Now()}
+		Expect(watch.Update(event.UpdateEvent{ObjectOld: &policy, ObjectNew: probed})).To(BeFalse())
+
+		takenOver := probed.DeepCopy()
+		takenOver.Status.Peers[0].TakenOver = true
+		Expect(watch.Update(event.UpdateEvent{ObjectOld: probed, ObjectNew: takenOver})).To(BeTrue())
+
+		deactivated := policy.DeepCopy()
+		deactivated.Status.Active = false
+		deactivated.Status.ActiveControllers = nil
+		Expect(watch.Update(event.UpdateEvent{ObjectOld: &policy, ObjectNew: deactivated})).To(BeTrue())
+
+		respecified := policy.DeepCopy()
+		respecified.Generation = 2
+		Expect(watch.Update(event.UpdateEvent{ObjectOld: &policy, ObjectNew: respecified})).To(BeTrue())
+	})
+})
+
+var _ = Describe("DNSPolicy route selection", func() {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	dnsPolicy := func(name string, age time.Duration, matchLabels map[string]string) routingv1alpha1.DNSPolicy {
+		policy := routingv1alpha1.DNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.Time{Time: created.Add(-age)},
+			},
+		}
+		if matchLabels != nil {
+			policy.Spec.RouteSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+		}
+		return policy
+	}
+	route := func(name string, routeLabels map[string]string) routingv1alpha1.ServiceRoute {
+		return routingv1alpha1.ServiceRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: routeLabels}}
+	}
+	critical := map[string]string{"tier": "critical"}
+
+	It("should prefer a policy selecting the route over the namespace default", func() {
+		policies := []routingv1alpha1.DNSPolicy{
+			dnsPolicy("default", time.Hour, nil),
+			dnsPolicy("critical", 0, critical),
+		}
+
+		Expect(selectDNSPolicy(policies, critical).Name).To(Equal("critical"))
+		Expect(selectDNSPolicy(policies, map[string]string{"tier": "standard"}).Name).To(Equal("default"))
+	})
+
+	It("should return no policy when none selects the route", func() {
+		policies := []routingv1alpha1.DNSPolicy{dnsPolicy("critical", 0, critical)}
+		Expect(selectDNSPolicy(policies, nil)).To(BeNil())
+	})
+
+	It("should let the oldest of two overlapping policies win and report the conflict on both", func() {
+		policies := []routingv1alpha1.DNSPolicy{
+			dnsPolicy("critical-new", 0, critical),
+			dnsPolicy("critical-old", time.Hour, critical),
+			dnsPolicy("default", time.Hour, nil),
+		}
+		routes := []routingv1alpha1.ServiceRoute{
+			route("payments", critical),
+			route("web", nil),
+		}
+
+		Expect(selectDNSPolicy(policies, critical).Name).To(Equal("critical-old"))
+		Expect(routeSelectorConflicts(&policies[0], policies, routes)).To(Equal([]string{
+			"DNSPolicy critical-old also selects ServiceRoute payments",
+		}))
+		Expect(routeSelectorConflicts(&policies[1], policies, routes)).To(Equal([]string{
+			"DNSPolicy critical-new also selects ServiceRoute payments",
+		}))
+		Expect(routeSelectorConflicts(&policies[2], policies, routes)).To(BeEmpty())
+	})
+
+	It("should report two namespace defaults as a conflict", func() {
+		policies := []routingv1alpha1.DNSPolicy{
+			dnsPolicy("a", 0, nil),
+			dnsPolicy("b", 0, nil),
+		}
+		Expect(routeSelectorConflicts(&policies[0], policies, nil)).To(Equal([]string{
+			"DNSPolicy b also applies to every ServiceRoute",
+		}))
+		Expect(selectDNSPolicy(policies, nil).Name).To(Equal("a"))
 	})
 })
@@ -0,0 +1,268 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+const (
+	// defaultFailoverProbeInterval is the time between two health checks of a peer region
+	defaultFailoverProbeInterval = 10 * time.Second
+
+	// defaultFailoverThreshold is the number of consecutive checks needed to switch a peer region
+	defaultFailoverThreshold = 3
+
+	// defaultFailoverMinHoldTime is the minimum time between two switches of a peer region
+	defaultFailoverMinHoldTime = 5 * time.Minute
+
+	// defaultHTTPProbeTimeout is the timeout of a single HTTP health check
+	defaultHTTPProbeTimeout = 5 * time.Second
+)
+
+// PeerHealthChecker checks the health signal of a peer region in Failover mode
+type PeerHealthChecker interface {
+	// Check returns nil when the peer region is healthy, or an error describing why it is not.
+	// namespace is the namespace of the DNSPolicy the peer belongs to, empty for a ClusterDNSPolicy.
+	Check(ctx context.Context, namespace string, peer routingv1alpha1.DNSPolicyFailoverPeer) error
+}
+
+// probePeerHealthChecker checks peer regions with HTTP GET probes or heartbeat Leases
+type probePeerHealthChecker struct {
+	client     client.Client
+	httpClient *http.Client
+}
+
+// newProbePeerHealthChecker returns the default PeerHealthChecker.
+// Redirects are not followed, a 3xx response already counts as healthy.
+func newProbePeerHealthChecker(c client.Client) PeerHealthChecker {
+	return &probePeerHealthChecker{
+		client: c,
+		httpClient: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Check implements PeerHealthChecker.
+// A namespaced DNSPolicy may only probe a Lease in its own namespace, see validation.DNSPolicy.
+func (c *probePeerHealthChecker) Check(ctx context.Context, namespace string, peer routingv1alpha1.DNSPolicyFailoverPeer) error {
+	if peer.HTTPGet != nil {
+		if namespace != "" {
+			return fmt.Errorf("httpGet probes are only supported in a ClusterDNSPolicy")
+		}
+		return c.checkHTTPGet(ctx, peer.HTTPGet)
+	}
+	if peer.Lease != nil {
+		return c.checkLease(ctx, namespace, peer.Lease)
+	}
+	return fmt.Errorf("no health signal configured")
+}
+
+// checkHTTPGet probes a URL, any 2xx or 3xx response is healthy
+func (c *probePeerHealthChecker) checkHTTPGet(ctx context.Context, probe *routingv1alpha1.DNSPolicyHTTPGetProbe) error {
+	timeout := defaultHTTPProbeTimeout
+	if probe.Timeout != nil && probe.Timeout.Duration > 0 {
+		timeout = probe.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("probe of %s failed: %w", probe.URL, err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("probe of %s returned %d", probe.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// checkLease judges a peer by its heartbeat Lease, which must be renewed within its lease duration
+func (c *probePeerHealthChecker) checkLease(ctx context.Context, namespace string, probe *routingv1alpha1.DNSPolicyLeaseProbe) error {
+	if probe.Namespace != "" && namespace != "" && probe.Namespace != namespace {
+		return fmt.Errorf("lease %s/%s is outside the namespace of the DNSPolicy", probe.Namespace, probe.Name)
+	}
+	if probe.Namespace != "" {
+		namespace = probe.Namespace
+	}
+
+	var lease coordinationv1.Lease
+	if err := c.client.Get(ctx, client.ObjectKey{Name: probe.Name, Namespace: namespace}, &lease); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("lease %s/%s not found", namespace, probe.Name)
+		}
+		return err
+	}
+
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return fmt.Errorf("lease %s/%s has never been renewed", namespace, probe.Name)
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	if time.Now().After(expiry) {
+		return fmt.Errorf("lease %s/%s has expired", namespace, probe.Name)
+	}
+	return nil
+}
+
+// failoverProbeInterval returns the configured or default probe interval
+func failoverProbeInterval(failover *routingv1alpha1.DNSPolicyFailover) time.Duration {
+	if failover.ProbeInterval != nil && failover.ProbeInterval.Duration > 0 {
+		return failover.ProbeInterval.Duration
+	}
+	return defaultFailoverProbeInterval
+}
+
+// observePeerHealth advances the hysteresis of a peer region with the result of one health check.
+//
+// A healthy peer is taken over after FailureThreshold consecutive failed checks, and handed back
+// after SuccessThreshold consecutive successful checks. Neither switch happens sooner than
+// MinHoldTime after the previous one, so a peer flapping around the thresholds cannot move
+// DNS back and forth. The counters are capped at their threshold to keep the status stable
+// while the peer stays in the same state.
+func observePeerHealth(
+	status routingv1alpha1.DNSPolicyPeerStatus,
+	failover *routingv1alpha1.DNSPolicyFailover,
+	healthErr error,
+	now time.Time,
+) routingv1alpha1.DNSPolicyPeerStatus {
+	failureThreshold := failover.FailureThreshold
+	if failureThreshold < 1 {
+		failureThreshold = defaultFailoverThreshold
+	}
+	successThreshold := failover.SuccessThreshold
+	if successThreshold < 1 {
+		successThreshold = defaultFailoverThreshold
+	}
+	minHoldTime := defaultFailoverMinHoldTime
+	if failover.MinHoldTime != nil {
+		minHoldTime = failover.MinHoldTime.Duration
+	}
+
+	if healthErr != nil {
+		status.ConsecutiveSuccesses = 0
+		status.ConsecutiveFailures = min(status.ConsecutiveFailures+1, failureThreshold)
+		status.Message = healthErr.Error()
+	} else {
+		status.ConsecutiveFailures = 0
+		status.ConsecutiveSuccesses = min(status.ConsecutiveSuccesses+1, successThreshold)
+		status.Message = "Peer region is healthy"
+	}
+
+	holdElapsed := status.LastTransitionTime == nil || now.Sub(status.LastTransitionTime.Time) >= minHoldTime
+
+	switch {
+	case !status.TakenOver && status.ConsecutiveFailures >= failureThreshold && holdElapsed:
+		status.TakenOver = true
+		status.LastTransitionTime = &metav1.Time{Time: now}
+	case status.TakenOver && status.ConsecutiveSuccesses >= successThreshold && holdElapsed:
+		status.TakenOver = false
+		status.LastTransitionTime = &metav1.Time{Time: now}
+	}
+
+	return status
+}
+
+// probePeers checks the peer regions of a Failover mode DNSPolicy that are due for a health check
+// and updates the peer status. A peer is checked at most once per ProbeInterval, however often the
+// policy is reconciled, so the thresholds always span FailureThreshold or SuccessThreshold intervals.
+// Peers that were removed from the spec are dropped, new peers start out healthy.
+// It returns the time until the next peer is due.
+func (r *DNSPolicyReconciler) probePeers(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) time.Duration {
+	logger := log.FromContext(ctx)
+	failover := dnsPolicy.Spec.Failover
+	interval := failoverProbeInterval(failover)
+
+	previous := make(map[string]routingv1alpha1.DNSPolicyPeerStatus, len(dnsPolicy.Status.Peers))
+	for _, status := range dnsPolicy.Status.Peers {
+		previous[status.Region] = status
+	}
+
+	now := time.Now()
+	next := interval
+	peers := make([]routingv1alpha1.DNSPolicyPeerStatus, 0, len(failover.Peers))
+	for _, peer := range failover.Peers {
+		status, exists := previous[peer.Region]
+		if !exists {
+			status = routingv1alpha1.DNSPolicyPeerStatus{Region: peer.Region}
+		}
+
+		if status.LastProbeTime != nil {
+			if due := status.LastProbeTime.Add(interval).Sub(now); due > 0 {
+				next = min(next, due)
+				peers = append(peers, status)
+				continue
+			}
+		}
+
+		healthErr := r.PeerHealth.Check(ctx, dnsPolicy.Namespace, peer)
+		updated := observePeerHealth(status, failover, healthErr, now)
+		updated.LastProbeTime = &metav1.Time{Time: now}
+		if updated.TakenOver != status.TakenOver {
+			logger.Info("Failover peer region switched", "region", peer.Region, "takenOver", updated.TakenOver, "message", updated.Message)
+			event := metrics.FailoverEventHandback
+			if updated.TakenOver {
+				event = metrics.FailoverEventTakeover
+				recorder.Eventf(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeWarning, consts.ReasonPeerRegionTakenOver,
+					"Failover", "Taking over region %s: %s", peer.Region, updated.Message)
+			} else {
+				recorder.Eventf(r.Recorder, r.eventObject(dnsPolicy), corev1.EventTypeNormal, consts.ReasonPeerRegionHandedBack,
+					"Failover", "Handing region %s back: %s", peer.Region, updated.Message)
+			}
+			metrics.FailoverEvents.WithLabelValues(dnsPolicyReference(dnsPolicy).Kind, dnsPolicy.Namespace, dnsPolicy.Name,
+				peer.Region, event).Inc()
+		}
+		peers = append(peers, updated)
+	}
+
+	dnsPolicy.Status.Peers = peers
+	return next
+}
+
+// takenOverRegions returns the peer regions currently taken over by a Failover mode DNSPolicy
+func takenOverRegions(dnsPolicy *routingv1alpha1.DNSPolicy) map[string]bool {
+	regions := make(map[string]bool)
+	for _, status := range dnsPolicy.Status.Peers {
+		if status.TakenOver {
+			regions[status.Region] = true
+		}
+	}
+	return regions
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// dnsPolicyMatchesRoute reports whether a DNSPolicy applies to a ServiceRoute with the given labels.
+// A policy without routeSelector applies to every ServiceRoute, a policy with an invalid selector to none.
+func dnsPolicyMatchesRoute(dnsPolicy *routingv1alpha1.DNSPolicy, routeLabels map[string]string) bool {
+	if dnsPolicy.Spec.RouteSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(dnsPolicy.Spec.RouteSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(routeLabels))
+}
+
+// dnsPolicyPrecedes reports whether DNSPolicy a takes precedence over b for a ServiceRoute both apply to.
+// A policy with a routeSelector wins over a policy without one. Between policies of the same kind
+// the oldest wins, like the owner of a hostname, and equal creation times are decided by name.
+func dnsPolicyPrecedes(a, b *routingv1alpha1.DNSPolicy) bool {
+	if (a.Spec.RouteSelector != nil) != (b.Spec.RouteSelector != nil) {
+		return a.Spec.RouteSelector != nil
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// selectDNSPolicy returns the DNSPolicy that applies to a ServiceRoute with the given labels, or nil
+func selectDNSPolicy(dnsPolicies []routingv1alpha1.DNSPolicy, routeLabels map[string]string) *routingv1alpha1.DNSPolicy {
+	var selected *routingv1alpha1.DNSPolicy
+	for i := range dnsPolicies {
+		policy := &dnsPolicies[i]
+		if policy.DeletionTimestamp != nil || !dnsPolicyMatchesRoute(policy, routeLabels) {
+			continue
+		}
+		if selected == nil || dnsPolicyPrecedes(policy, selected) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// routeSelectorConflicts describes the DNSPolicies in the namespace that compete with dnsPolicy.
+//
+// Two policies without routeSelector always compete. Two policies with a routeSelector compete
+// when they select the same ServiceRoute. A policy with a routeSelector never competes with one
+// without, it takes precedence by design. The result is sorted so the condition message is stable.
+func routeSelectorConflicts(
+	dnsPolicy *routingv1alpha1.DNSPolicy,
+	dnsPolicies []routingv1alpha1.DNSPolicy,
+	serviceRoutes []routingv1alpha1.ServiceRoute,
+) []string {
+	var conflicts []string
+	for i := range dnsPolicies {
+		other := &dnsPolicies[i]
+		if other.Name == dnsPolicy.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		if (other.Spec.RouteSelector != nil) != (dnsPolicy.Spec.RouteSelector != nil) {
+			continue
+		}
+
+		if dnsPolicy.Spec.RouteSelector == nil {
+			conflicts = append(conflicts, fmt.Sprintf("DNSPolicy %s also applies to every ServiceRoute", other.Name))
+			continue
+		}
+
+		var shared []string
+		for _, route := range serviceRoutes {
+			if dnsPolicyMatchesRoute(dnsPolicy, route.Labels) && dnsPolicyMatchesRoute(other, route.Labels) {
+				shared = append(shared, route.Name)
+			}
+		}
+		if len(shared) > 0 {
+			sort.Strings(shared)
+			conflicts = append(conflicts, fmt.Sprintf("DNSPolicy %s also selects ServiceRoute %s", other.Name, strings.Join(shared, ", ")))
+		}
+	}
+
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// setRouteSelectorConflictCondition reports the DNSPolicies competing with dnsPolicy for its ServiceRoutes.
+// The condition is only present while there is a conflict.
+func (r *DNSPolicyReconciler) setRouteSelectorConflictCondition(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) error {
+	var dnsPolicies routingv1alpha1.DNSPolicyList
+	if err := r.List(ctx, &dnsPolicies, client.InNamespace(dnsPolicy.Namespace)); err != nil {
+		return err
+	}
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes, client.InNamespace(dnsPolicy.Namespace)); err != nil {
+		return err
+	}
+
+	conflicts := routeSelectorConflicts(dnsPolicy, dnsPolicies.Items, serviceRoutes.Items)
+	if len(conflicts) == 0 {
+		meta.RemoveStatusCondition(&dnsPolicy.Status.Conditions, consts.ConditionTypeRouteSelectorConflict)
+		return nil
+	}
+
+	meta.SetStatusCondition(&dnsPolicy.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeRouteSelectorConflict,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dnsPolicy.Generation,
+		Reason:             consts.ReasonOverlappingRouteSelector,
+		Message:            strings.Join(conflicts, "; "),
+	})
+	return nil
+}
+
+// mapToNamespaceDNSPolicies returns the other DNSPolicies in the namespace of a DNSPolicy or ServiceRoute,
+// whose conflicts may have changed
+func (r *DNSPolicyReconciler) mapToNamespaceDNSPolicies(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var dnsPolicies routingv1alpha1.DNSPolicyList
+	if err := r.List(ctx, &dnsPolicies, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	_, isPolicy := obj.(*routingv1alpha1.DNSPolicy)
+
+	var requests []reconcile.Request
+	for _, policy := range dnsPolicies.Items {
+		if isPolicy && policy.Name == obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      policy.Name,
+				Namespace: policy.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// takeoverConflicts describes the ServiceRoutes published under dnsPolicy that withhold
+// hostnames claimed by another cluster. The result is sorted so the condition message is stable.
+func takeoverConflicts(
+	dnsPolicy *routingv1alpha1.DNSPolicy,
+	serviceRoutes []routingv1alpha1.ServiceRoute,
+) []string {
+	reference := dnsPolicyReference(dnsPolicy)
+
+	var conflicts []string
+	for _, route := range serviceRoutes {
+		if route.Status.DNSPolicyRef == nil || *route.Status.DNSPolicyRef != *reference {
+			continue
+		}
+		condition := meta.FindStatusCondition(route.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("ServiceRoute %s/%s: %s", route.Namespace, route.Name, condition.Message))
+	}
+
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// setTakeoverConflictCondition reports the hostnames of the policy's ServiceRoutes that another
+// cluster claims, as reported by the ServiceRoutes. The condition is only present while there is a conflict.
+func (r *DNSPolicyReconciler) setTakeoverConflictCondition(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) error {
+	// A ClusterDNSPolicy applies to ServiceRoutes in any namespace
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes, client.InNamespace(dnsPolicy.Namespace)); err != nil {
+		return err
+	}
+
+	conflicts := takeoverConflicts(dnsPolicy, serviceRoutes.Items)
+	if len(conflicts) == 0 {
+		meta.RemoveStatusCondition(&dnsPolicy.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+		return nil
+	}
+
+	meta.SetStatusCondition(&dnsPolicy.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeTakeoverConflict,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: dnsPolicy.Generation,
+		Reason:             consts.ReasonClaimedByOtherCluster,
+		Message:            strings.Join(conflicts, "; "),
+	})
+	return nil
+}
+
+// takeoverConflictChanged passes ServiceRoute updates that raise, change or resolve a takeover conflict
+var takeoverConflictChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldRoute, ok := e.ObjectOld.(*routingv1alpha1.ServiceRoute)
+		if !ok {
+			return false
+		}
+		newRoute, ok := e.ObjectNew.(*routingv1alpha1.ServiceRoute)
+		if !ok {
+			return false
+		}
+		oldCondition := meta.FindStatusCondition(oldRoute.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+		newCondition := meta.FindStatusCondition(newRoute.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+		if oldCondition == nil || newCondition == nil {
+			return oldCondition != newCondition
+		}
+		return oldCondition.Status != newCondition.Status || oldCondition.Message != newCondition.Message ||
+			!equality.Semantic.DeepEqual(oldRoute.Status.DNSPolicyRef, newRoute.Status.DNSPolicyRef)
+	},
+}
+
+// mapServiceRouteToClusterDNSPolicy returns the ClusterDNSPolicy a ServiceRoute is published under
+func mapServiceRouteToClusterDNSPolicy(_ context.Context, obj client.Object) []reconcile.Request {
+	serviceRoute, ok := obj.(*routingv1alpha1.ServiceRoute)
+	if !ok || serviceRoute.Status.DNSPolicyRef == nil || serviceRoute.Status.DNSPolicyRef.Kind != "ClusterDNSPolicy" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: serviceRoute.Status.DNSPolicyRef.Name},
+	}}
+}
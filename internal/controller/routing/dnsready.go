@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// dnsReadyRecheckInterval is how often record sets not yet processed by the DNS provider are checked.
+// DNSEndpoint changes are watched, the recheck covers backends that emit no events.
+const dnsReadyRecheckInterval = 30 * time.Second
+
+// recordSetStates returns the processing state of the desired record sets.
+// A record set is only synced when the published one holds the desired records and the DNS provider
+// observed its current generation, so a cache lagging behind the last write does not report the
+// previous revision as processed.
+func recordSetStates(desired, published []*dnsbackend.RecordSet) []metrics.RecordSetState {
+	publishedMap := make(map[string]*dnsbackend.RecordSet, len(published))
+	for _, set := range published {
+		publishedMap[set.Key()] = set
+	}
+
+	states := make([]metrics.RecordSetState, 0, len(desired))
+	for _, set := range desired {
+		current, exists := publishedMap[set.Key()]
+		states = append(states, metrics.RecordSetState{
+			Key:        set.Key(),
+			Controller: set.Labels["router.io/controller"],
+			Synced:     exists && dnsbackend.Equal(current, set) && current.Synced(),
+		})
+	}
+	return states
+}
+
+// gatewayRecordSetStates returns the processing state of the published A records of a gateway.
+// A record set is only synced when its A records point at ip and the DNS provider observed them.
+func gatewayRecordSetStates(published []*dnsbackend.RecordSet, ip string) []metrics.RecordSetState {
+	states := make([]metrics.RecordSetState, 0, len(published))
+	for _, set := range published {
+		targetsIP := true
+		for _, record := range set.Records {
+			if record.RecordType == "A" && !slices.Equal(record.Targets, []string{ip}) {
+				targetsIP = false
+			}
+		}
+		states = append(states, metrics.RecordSetState{
+			Key:        set.Key(),
+			Controller: set.Labels["router.io/controller"],
+			Synced:     targetsIP && set.Synced(),
+		})
+	}
+	return states
+}
+
+// observedDNSStatus reports whether every controller processed its record set, with a message naming
+// the controllers that did or, while any is pending, the ones still to process their record set
+func observedDNSStatus(states []metrics.RecordSetState) (bool, string) {
+	var synced, pending []string
+	for _, state := range states {
+		if state.Synced {
+			synced = append(synced, state.Controller)
+		} else {
+			pending = append(pending, state.Controller)
+		}
+	}
+	sort.Strings(synced)
+	sort.Strings(pending)
+
+	if len(pending) > 0 {
+		return false, "Waiting for " + strings.Join(pending, ", ") + " to process the DNS records"
+	}
+	return true, "DNS records observed by " + strings.Join(synced, ", ")
+}
+
+// setServiceRouteDNSReady reports whether the DNS provider processed the published records of a ServiceRoute
+func setServiceRouteDNSReady(serviceRoute *routingv1alpha1.ServiceRoute, states []metrics.RecordSetState) {
+	condition := metav1.Condition{
+		Type:               consts.ConditionTypeDNSReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: serviceRoute.Generation,
+	}
+
+	if len(states) == 0 {
+		condition.Reason = consts.ReasonNoExternalDNSController
+		condition.Message = "No ExternalDNS controller publishes the hostnames"
+	} else if ready, message := observedDNSStatus(states); ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = consts.ReasonDNSRecordsObserved
+		condition.Message = message
+	} else {
+		condition.Reason = consts.ReasonDNSNotReady
+		condition.Message = message
+	}
+
+	meta.SetStatusCondition(&serviceRoute.Status.Conditions, condition)
+}
+
+// dnsEndpointObserved passes DNSEndpoint updates in which the DNS provider observed another generation
+var dnsEndpointObserved = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldEndpoint, ok := e.ObjectOld.(*externaldnsv1alpha1.DNSEndpoint)
+		if !ok {
+			return false
+		}
+		newEndpoint, ok := e.ObjectNew.(*externaldnsv1alpha1.DNSEndpoint)
+		if !ok {
+			return false
+		}
+		return oldEndpoint.Status.ObservedGeneration != newEndpoint.Status.ObservedGeneration
+	},
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+)
+
+var _ = Describe("DNS readiness", func() {
+	var (
+		ctx          context.Context
+		c            client.Client
+		serviceRoute *routingv1alpha1.ServiceRoute
+		desired      []*dnsbackend.RecordSet
+	)
+
+	recordSet := func(name, controller, target string) *dnsbackend.RecordSet {
+		return &dnsbackend.RecordSet{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "service-router-operator",
+				"router.io/serviceroute":       "api",
+				"router.io/source-namespace":   "default",
+				"router.io/controller":         controller,
+			},
+			Records: []dnsbackend.Record{
+				{DNSName: "api.example.com", RecordType: "CNAME", Targets: []string{target}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(testScheme).
+			WithStatusSubresource(&externaldnsv1alpha1.DNSEndpoint{}).Build()
+
+		serviceRoute = &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		}
+		desired = []*dnsbackend.RecordSet{
+			recordSet("api-external-dns-weu", "external-dns-weu", "aks-weu-internal.example.com"),
+			recordSet("api-external-dns-neu", "external-dns-neu", "aks-weu-internal.example.com"),
+		}
+	})
+
+	It("should only report DNSReady once every controller observed the current generation", func() {
+		reconciler := &ServiceRouteReconciler{Client: c, DNSBackend: dnsbackend.NewExternalDNS(c)}
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, desired)).To(Succeed())
+
+		// ExternalDNS has not processed the records yet
+		for _, set := range desired {
+			var dnsEndpoint externaldnsv1alpha1.DNSEndpoint
+			Expect(c.Get(ctx, types.NamespacedName{Name: set.Name, Namespace: set.Namespace}, &dnsEndpoint)).To(Succeed())
+			dnsEndpoint.Generation = 1
+			Expect(c.Update(ctx, &dnsEndpoint)).To(Succeed())
+		}
+
+		states, err := reconciler.observeRecordSets(ctx, serviceRoute, desired)
+		Expect(err).NotTo(HaveOccurred())
+		setServiceRouteDNSReady(serviceRoute, states)
+		condition := meta.FindStatusCondition(serviceRoute.Status.Conditions, "DNSReady")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("DNSNotReady"))
+		Expect(condition.Message).To(Equal("Waiting for external-dns-neu, external-dns-weu to process the DNS records"))
+
+		var dnsEndpoint externaldnsv1alpha1.DNSEndpoint
+		Expect(c.Get(ctx, types.NamespacedName{Name: "api-external-dns-weu", Namespace: "default"}, &dnsEndpoint)).To(Succeed())
+		dnsEndpoint.Status.ObservedGeneration = dnsEndpoint.Generation
+		Expect(c.Status().Update(ctx, &dnsEndpoint)).To(Succeed())
+
+		states, err = reconciler.observeRecordSets(ctx, serviceRoute, desired)
+		Expect(err).NotTo(HaveOccurred())
+		setServiceRouteDNSReady(serviceRoute, states)
+		condition = meta.FindStatusCondition(serviceRoute.Status.Conditions, "DNSReady")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(Equal("Waiting for external-dns-neu to process the DNS records"))
+
+		Expect(c.Get(ctx, types.NamespacedName{Name: "api-external-dns-neu", Namespace: "default"}, &dnsEndpoint)).To(Succeed())
+		dnsEndpoint.Status.ObservedGeneration = dnsEndpoint.Generation
+		Expect(c.Status().Update(ctx, &dnsEndpoint)).To(Succeed())
+
+		states, err = reconciler.observeRecordSets(ctx, serviceRoute, desired)
+		Expect(err).NotTo(HaveOccurred())
+		setServiceRouteDNSReady(serviceRoute, states)
+		condition = meta.FindStatusCondition(serviceRoute.Status.Conditions, "DNSReady")
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("DNSRecordsObserved"))
+		Expect(condition.Message).To(Equal("DNS records observed by external-dns-neu, external-dns-weu"))
+
+		clearDNSStatus(serviceRoute)
+		Expect(meta.FindStatusCondition(serviceRoute.Status.Conditions, "DNSReady")).To(BeNil())
+	})
+
+	It("should not take a published record set lagging behind the desired records for processed", func() {
+		published := []*dnsbackend.RecordSet{
+			recordSet("api-external-dns-weu", "external-dns-weu", "aks-neu-internal.example.com"),
+		}
+
+		states := recordSetStates(desired, published)
+		Expect(states).To(HaveLen(2))
+		Expect(states[0].Synced).To(BeFalse())
+		Expect(states[1].Synced).To(BeFalse())
+
+		published[0].Records = desired[0].Records
+		Expect(recordSetStates(desired, published)[0].Synced).To(BeTrue())
+	})
+
+	It("should only report gateway A records pointing at the current address as processed", func() {
+		published := []*dnsbackend.RecordSet{{
+			Name:      "gateway-controller-aks-ingress-internal-external-dns-weu",
+			Namespace: "istio-system",
+			Labels:    map[string]string{"router.io/controller": "external-dns-weu"},
+			Records: []dnsbackend.Record{
+				{DNSName: "aks-weu-internal.example.com", RecordType: "A", Targets: []string{"10.0.0.1"}},
+			},
+			Generation:         2,
+			ObservedGeneration: 2,
+		}}
+
+		ready, message := observedDNSStatus(gatewayRecordSetStates(published, "10.0.0.1"))
+		Expect(ready).To(BeTrue())
+		Expect(message).To(Equal("DNS records observed by external-dns-weu"))
+
+		ready, _ = observedDNSStatus(gatewayRecordSetStates(published, "10.0.0.2"))
+		Expect(ready).To(BeFalse())
+
+		published[0].Generation = 3
+		ready, _ = observedDNSStatus(gatewayRecordSetStates(published, "10.0.0.1"))
+		Expect(ready).To(BeFalse())
+	})
+
+	It("should map gateway DNSEndpoints to the Gateways publishing them", func() {
+		for _, gateway := range []*routingv1alpha1.Gateway{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "istio-system"},
+				Spec:       routingv1alpha1.GatewaySpec{Controller: "aks-ingress", TargetPostfix: "internal"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "istio-system"},
+				Spec:       routingv1alpha1.GatewaySpec{Controller: "aks-ingress", TargetPostfix: "external"},
+			},
+		} {
+			Expect(c.Create(ctx, gateway)).To(Succeed())
+		}
+		reconciler := &GatewayReconciler{Client: c}
+
+		gatewayService := &externaldnsv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway-controller-aks-ingress-internal-external-dns-weu",
+			Namespace: "aks-istio-ingress",
+			Labels:    gatewayServiceRecordLabels("aks-ingress", "internal"),
+		}}
+		Expect(reconciler.mapDNSEndpointToGateways(ctx, gatewayService)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "internal", Namespace: "istio-system"},
+		}))
+
+		gatewayAPI := &externaldnsv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway-public-external-external-dns-weu",
+			Namespace: "gateways",
+			Labels: map[string]string{
+				"router.io/resource-type": "gateway-api",
+				"router.io/gateway":       "public",
+			},
+		}}
+		Expect(reconciler.mapDNSEndpointToGateways(ctx, gatewayAPI)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "public", Namespace: "gateways"},
+		}))
+
+		serviceRouteEndpoint := &externaldnsv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-external-dns-weu",
+			Namespace: "default",
+			Labels:    map[string]string{"router.io/serviceroute": "api"},
+		}}
+		Expect(reconciler.mapDNSEndpointToGateways(ctx, serviceRouteEndpoint)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+)
+
+// controllerAnnotation selects the ExternalDNS deployment that publishes a DNSEndpoint
+const controllerAnnotation = "external-dns.alpha.kubernetes.io/controller"
+
+// controllerAnnotations returns the annotations of a record set published to an ExternalDNS controller.
+// The annotation value MUST match the ExternalDNS deployment's annotation filter. It defaults to the
+// region (not the controller name), which enables cross-cluster takeover.
+func controllerAnnotations(controller dnsconfiguration.ExternalDNSController) map[string]string {
+	return map[string]string{
+		controllerAnnotation: controller.AnnotationValue(),
+	}
+}
+
+// controllerRecords returns the records an ExternalDNS controller accepts according to its domain
+// filters and record types, with the given TTL applied (0 leaves the TTL to the DNS provider)
+func controllerRecords(controller dnsconfiguration.ExternalDNSController, ttl int64, records []dnsbackend.Record) []dnsbackend.Record {
+	var accepted []dnsbackend.Record
+	for _, record := range records {
+		if !controller.AcceptsRecord(record.DNSName, record.RecordType) {
+			continue
+		}
+		record.TTL = ttl
+		accepted = append(accepted, record)
+	}
+	return accepted
+}
+
+// serviceRouteTTL returns the TTL override of a ServiceRoute's records: the ServiceRoute TTL,
+// else the DNSPolicy TTL, else 0 to use the DNSConfiguration TTLs
+func serviceRouteTTL(serviceRoute *routingv1alpha1.ServiceRoute, dnsPolicy *routingv1alpha1.DNSPolicy) int64 {
+	if serviceRoute.Spec.TTL != nil {
+		return *serviceRoute.Spec.TTL
+	}
+	if dnsPolicy.Spec.TTL != nil {
+		return *dnsPolicy.Spec.TTL
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+)
+
+var _ = Describe("Events", func() {
+	var (
+		ctx          context.Context
+		c            client.Client
+		fakeRecorder *events.FakeRecorder
+	)
+
+	// recorded drains the events recorded so far
+	recorded := func() []string {
+		var recorded []string
+		for {
+			select {
+			case event := <-fakeRecorder.Events:
+				recorded = append(recorded, event)
+			default:
+				return recorded
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(istioclientv1beta1.AddToScheme(testScheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(testScheme).
+			WithStatusSubresource(&routingv1alpha1.ServiceRoute{}, &routingv1alpha1.DNSPolicy{}).Build()
+		fakeRecorder = events.NewFakeRecorder(20)
+	})
+
+	It("should record DNSEndpoint changes of a ServiceRoute only when they happen", func() {
+		serviceRoute := &routingv1alpha1.ServiceRoute{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+		reconciler := &ServiceRouteReconciler{Client: c, DNSBackend: dnsbackend.NewExternalDNS(c), Recorder: fakeRecorder}
+
+		recordSet := func(controller string) *dnsbackend.RecordSet {
+			return &dnsbackend.RecordSet{
+				Name:      "api-" + controller,
+				Namespace: "default",
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "service-router-operator",
+					"router.io/serviceroute":       "api",
+					"router.io/source-namespace":   "default",
+				},
+				Records: []dnsbackend.Record{
+					{DNSName: "api.example.com", RecordType: "CNAME", Targets: []string{"aks-weu-internal.example.com"}},
+				},
+			}
+		}
+		desired := []*dnsbackend.RecordSet{recordSet("external-dns-weu"), recordSet("external-dns-neu")}
+
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, desired)).To(Succeed())
+		Expect(recorded()).To(Equal([]string{
+			"Normal DNSEndpointsCreated Created DNSEndpoints api-external-dns-weu, api-external-dns-neu",
+		}))
+
+		// A steady-state reconcile records nothing
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, desired)).To(Succeed())
+		Expect(recorded()).To(BeEmpty())
+
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, desired[:1])).To(Succeed())
+		Expect(recorded()).To(Equal([]string{"Normal DNSEndpointsDeleted Deleted DNSEndpoints api-external-dns-neu"}))
+
+		Expect(reconciler.withdrawDNSEndpoints(ctx, serviceRoute)).To(Succeed())
+		Expect(reconciler.withdrawDNSEndpoints(ctx, serviceRoute)).To(Succeed())
+		Expect(recorded()).To(Equal([]string{"Normal DNSEndpointsDeleted Deleted DNSEndpoints api-external-dns-weu"}))
+	})
+
+	It("should record phase changes of a ServiceRoute once", func() {
+		serviceRoute := &routingv1alpha1.ServiceRoute{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+		Expect(c.Create(ctx, serviceRoute)).To(Succeed())
+		reconciler := &ServiceRouteReconciler{Client: c, Recorder: fakeRecorder}
+
+		for range 2 {
+			_, err := reconciler.updateStatusPending(ctx, serviceRoute, "DNSPolicyNotFound", "Waiting for DNSPolicy")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(recorded()).To(Equal([]string{"Normal DNSPolicyNotFound Waiting for DNSPolicy"}))
+
+		_, err := reconciler.updateStatusFailed(ctx, serviceRoute, "ValidationFailed", "spec.serviceName is required")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorded()).To(Equal([]string{"Warning ValidationFailed spec.serviceName is required"}))
+	})
+
+	It("should record activation changes of a DNSPolicy", func() {
+		dnsPolicy := &routingv1alpha1.DNSPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+		Expect(c.Create(ctx, dnsPolicy)).To(Succeed())
+		reconciler := &DNSPolicyReconciler{Client: c, Recorder: fakeRecorder}
+
+		for range 2 {
+			_, err := reconciler.updateStatusActive(ctx, dnsPolicy, []string{"external-dns-weu"})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(recorded()).To(Equal([]string{
+			"Normal PolicyActivated Policy is active for this cluster, publishing to external-dns-weu",
+		}))
+
+		_, err := reconciler.updateStatusActive(ctx, dnsPolicy, []string{"external-dns-weu", "external-dns-neu"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorded()).To(Equal([]string{
+			"Normal ActiveControllersChanged Publishing to external-dns-weu, external-dns-neu",
+		}))
+
+		for range 2 {
+			_, err := reconciler.updateStatusInactive(ctx, dnsPolicy, "sourceRegion neu does not match cluster region weu")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(recorded()).To(Equal([]string{
+			"Normal PolicyInactive Policy not active for this cluster: sourceRegion neu does not match cluster region weu",
+		}))
+	})
+
+	It("should record host changes of the Istio Gateway", func() {
+		gateway := &routingv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system", UID: "gateway-uid"},
+			Spec:       routingv1alpha1.GatewaySpec{Controller: "aks-istio-ingressgateway-internal"},
+		}
+		reconciler := &GatewayReconciler{Client: c, Recorder: fakeRecorder}
+
+		reconcileHosts := func(hosts ...string) {
+			desired, err := reconciler.generateIstioGateway(gateway, hosts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.reconcileIstioGateway(ctx, gateway, desired)).To(Succeed())
+		}
+
+		reconcileHosts("api.example.com", "web.example.com")
+		reconcileHosts("api.example.com", "web.example.com")
+		Expect(recorded()).To(Equal([]string{
+			"Normal IstioGatewayCreated Created Istio Gateway default-gateway with 2 hosts",
+		}))
+
+		reconcileHosts("web.example.com", "shop.example.com")
+		Expect(recorded()).To(Equal([]string{
+			"Normal IstioGatewayHostsChanged Istio Gateway hosts changed: added shop.example.com; removed api.example.com",
+		}))
+
+		Expect(reconciler.deleteIstioGateway(ctx, gateway)).To(Succeed())
+		Expect(reconciler.deleteIstioGateway(ctx, gateway)).To(Succeed())
+		Expect(recorded()).To(Equal([]string{"Normal IstioGatewayDeleted Deleted Istio Gateway default-gateway"}))
+	})
+})
@@ -19,7 +19,8 @@ package routing
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	networkingv1beta1 "istio.io/api/networking/v1beta1"
@@ -30,7 +31,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,9 +41,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 
-	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/hostname"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
 	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
@@ -55,17 +63,29 @@ type GatewayReconciler struct {
 	client.Client
 	Scheme                        *runtime.Scheme
 	DefaultRouterGatewayNamespace string
+	// GatewayAPIEnabled allows Gateways to be rendered as Gateway API resources
+	GatewayAPIEnabled bool
+	// Identity provides the cluster identity, defaults to the manager's informer cache
+	Identity clusteridentity.IdentityProvider
+	// Config provides the DNS configuration, defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// DNSBackend reads the published gateway records, defaults to ExternalDNS DNSEndpoints
+	DNSBackend dnsbackend.DNSBackend
+	// Recorder records phase changes and Istio Gateway host changes as events
+	Recorder events.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=routing.router.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=routing.router.io,resources=gateways/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=routing.router.io,resources=gateways/finalizers,verbs=update
 //+kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 //+kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -80,6 +100,8 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err := r.Get(ctx, req.NamespacedName, &gateway); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Gateway deleted", "name", req.Name, "namespace", req.Namespace)
+			metrics.GatewayHosts.DeleteLabelValues(req.Namespace, req.Name)
+			metrics.DNSReady.Forget("Gateway", "gateway-api/"+req.Namespace+"/"+req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch Gateway")
@@ -87,13 +109,13 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// Validate configuration to fail early on invalid input.
-	if err := r.validateGateway(&gateway); err != nil {
+	if err := validation.Gateway(&gateway, r.GatewayAPIEnabled); err != nil {
 		logger.Error(err, "validation failed")
 		return r.updateStatusFailed(ctx, &gateway, consts.ReasonValidationFailed, err.Error())
 	}
 
 	// ClusterIdentity is needed to generate correct hostnames (region, domain).
-	clusterIdentity, err := clusteridentity.Fetch(ctx, r.Client)
+	clusterIdentity, err := r.Identity.Identity(ctx)
 	if err != nil {
 		logger.Error(err, "failed to get ClusterIdentity")
 		return r.updateStatusPending(ctx, &gateway, consts.ReasonClusterIdentityNotAvailable,
@@ -106,20 +128,34 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			"Waiting for ClusterIdentity to be configured", "", false, "ClusterIdentity not available")
 	}
 
+	// The target hostname must fit the DNS limits before any record points to it.
+	if err := validation.GatewayHostnames(&gateway, clusterIdentity); err != nil {
+		logger.Error(err, "hostname validation failed")
+		return r.updateStatusFailed(ctx, &gateway, consts.ReasonValidationFailed, err.Error())
+	}
+
+	// During a hostname migration the gateway keeps accepting the hostnames of the previous identities
+	previousIdentities, err := r.Identity.PreviousIdentities(ctx)
+	if err != nil {
+		logger.Error(err, "failed to get previous cluster identities")
+		return ctrl.Result{}, err
+	}
+
 	// We need to aggregate all hosts from ServiceRoutes that reference this Gateway
 	// to configure the Istio Gateway's servers block.
-	hosts, err := r.collectHostsFromServiceRoutes(ctx, &gateway, clusterIdentity)
+	hosts, err := r.collectHostsFromServiceRoutes(ctx, &gateway, clusterIdentity, previousIdentities)
 	if err != nil {
 		logger.Error(err, "failed to collect hosts from ServiceRoutes")
 		return ctrl.Result{}, err
 	}
+	metrics.GatewayHosts.WithLabelValues(gateway.Namespace, gateway.Name).Set(float64(len(hosts)))
 
 	// Check DNS status for LoadBalancer IP (independent of ServiceRoutes)
 	lbIP, dnsReady, dnsMsg := r.checkDNSStatus(ctx, &gateway)
 
 	// Check if no ServiceRoutes reference this Gateway
 	if len(hosts) == 0 {
-		logger.Info("No ServiceRoutes found for Gateway, deleting Istio Gateway if it exists")
+		logger.Info("No ServiceRoutes found for Gateway, deleting generated gateway if it exists")
 
 		// Delete Istio Gateway if it exists
 		if err := r.deleteIstioGateway(ctx, &gateway); err != nil {
@@ -127,10 +163,26 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, err
 		}
 
+		// Delete Gateway API Gateway if it exists
+		if err := r.deleteGatewayAPIGateway(ctx, &gateway); err != nil {
+			logger.Error(err, "failed to delete Gateway API Gateway")
+			return ctrl.Result{}, err
+		}
+
 		return r.updateStatusPending(ctx, &gateway, consts.ReasonNoServiceRoutes,
 			"Waiting for ServiceRoutes to reference this Gateway", lbIP, dnsReady, dnsMsg)
 	}
 
+	if usesGatewayAPI(&gateway) {
+		return r.reconcileGatewayAPI(ctx, &gateway, hosts, lbIP, dnsReady, dnsMsg)
+	}
+
+	// Remove a Gateway API Gateway left over from a previous implementation
+	if err := r.deleteGatewayAPIGateway(ctx, &gateway); err != nil {
+		logger.Error(err, "failed to delete Gateway API Gateway")
+		return ctrl.Result{}, err
+	}
+
 	// Translate the generic Gateway CRD into an Istio-specific Gateway resource.
 	istioGateway, err := r.generateIstioGateway(&gateway, hosts)
 	if err != nil {
@@ -139,7 +191,10 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// Enforce the Istio Gateway configuration to match the desired state.
-	if err := r.reconcileIstioGateway(ctx, istioGateway); err != nil {
+	if err := r.reconcileIstioGateway(ctx, &gateway, istioGateway); err != nil {
+		if isNotControlled(err) {
+			return r.updateStatusFailed(ctx, &gateway, consts.ReasonNotControlled, err.Error())
+		}
 		logger.Error(err, "failed to reconcile Istio Gateway")
 		return ctrl.Result{}, err
 	}
@@ -148,36 +203,61 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	return r.updateStatusActive(ctx, &gateway, lbIP, dnsReady, dnsMsg)
 }
 
-// validateGateway validates the Gateway configuration
-func (r *GatewayReconciler) validateGateway(gateway *routingv1alpha1.Gateway) error {
-	// Validate controller is not empty
-	if gateway.Spec.Controller == "" {
-		return fmt.Errorf("controller must be specified")
-	}
+// reconcileGatewayAPI renders the Gateway as a Gateway API Gateway and removes the Istio Gateway
+func (r *GatewayReconciler) reconcileGatewayAPI(
+	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
+	hosts []string,
+	lbIP string,
+	dnsReady bool,
+	dnsMsg string,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
 
-	// Validate credentialName is not empty
-	if gateway.Spec.CredentialName == "" {
-		return fmt.Errorf("credentialName must be specified")
+	// Remove an Istio Gateway left over from a previous implementation
+	if err := r.deleteIstioGateway(ctx, gateway); err != nil {
+		logger.Error(err, "failed to delete Istio Gateway")
+		return ctrl.Result{}, err
 	}
 
-	// Validate targetPostfix is not empty
-	if gateway.Spec.TargetPostfix == "" {
-		return fmt.Errorf("targetPostfix must be specified")
+	desired, err := generateGatewayAPIGateway(gateway, hosts)
+	if err != nil {
+		logger.Error(err, "failed to generate Gateway API Gateway")
+		return r.updateStatusFailed(ctx, gateway, consts.ReasonGatewayAPIGenerationFailed, err.Error())
 	}
+	if err := reconcileUnstructured(ctx, r.Client, gateway, desired); err != nil {
+		if isNotControlled(err) {
+			return r.updateStatusFailed(ctx, gateway, consts.ReasonNotControlled, err.Error())
+		}
+		logger.Error(err, "failed to reconcile Gateway API Gateway")
+		return r.updateStatusFailed(ctx, gateway, consts.ReasonGatewayAPIGenerationFailed, err.Error())
+	}
+
+	return r.updateStatusActive(ctx, gateway, lbIP, dnsReady, dnsMsg)
+}
 
-	// Validate targetPostfix format (should be lowercase alphanumeric with hyphens)
-	if !regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`).MatchString(gateway.Spec.TargetPostfix) {
-		return fmt.Errorf("targetPostfix must be lowercase alphanumeric with hyphens: %s", gateway.Spec.TargetPostfix)
+// deleteGatewayAPIGateway deletes the Gateway API Gateway generated for a Gateway if it exists
+func (r *GatewayReconciler) deleteGatewayAPIGateway(
+	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
+) error {
+	if !r.GatewayAPIEnabled {
+		return nil
 	}
 
-	return nil
+	return deleteUnstructured(ctx, r.Client, gatewayAPIGatewayGVK, client.ObjectKey{
+		Name:      gateway.Name,
+		Namespace: gateway.Namespace,
+	}, gateway)
 }
 
-// collectHostsFromServiceRoutes collects all unique hosts from ServiceRoutes using this Gateway
+// collectHostsFromServiceRoutes collects all unique hosts from ServiceRoutes using this Gateway,
+// including the hosts of previous cluster identities during a hostname migration
 func (r *GatewayReconciler) collectHostsFromServiceRoutes(
 	ctx context.Context,
 	gateway *routingv1alpha1.Gateway,
 	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
 ) ([]string, error) {
 	// List all ServiceRoutes
 	var serviceRoutes routingv1alpha1.ServiceRouteList
@@ -204,14 +284,27 @@ func (r *GatewayReconciler) collectHostsFromServiceRoutes(
 
 		// Construct the source hostname
 		if clusterIdentity != nil {
-			sourceHost := fmt.Sprintf("%s-ns-%s-%s-%s.%s",
+			sourceHost, err := hostname.SourceHost(clusterIdentity,
 				route.Spec.ServiceName,
-				clusterIdentity.EnvironmentLetter,
 				route.Spec.Environment,
 				route.Spec.Application,
-				clusterIdentity.Domain,
 			)
+			if err != nil {
+				return nil, err
+			}
 			hostSet[sourceHost] = true
+
+			routeHosts := []string{sourceHost}
+			for _, alias := range hostname.Aliases(clusterIdentity, sourceHost, route.Spec.Aliases) {
+				hostSet[alias] = true
+				routeHosts = append(routeHosts, alias)
+			}
+
+			for _, migrating := range serviceRouteMigratingHosts(&route, routeHosts, previousIdentities) {
+				for _, host := range migrating.hosts() {
+					hostSet[host] = true
+				}
+			}
 		}
 	}
 
@@ -267,9 +360,11 @@ func (r *GatewayReconciler) generateIstioGateway(
 	}, nil
 }
 
-// reconcileIstioGateway manages the Istio Gateway resource
+// reconcileIstioGateway manages the Istio Gateway resource.
+// Creating the Istio Gateway and changing its hosts are recorded as events on the Gateway.
 func (r *GatewayReconciler) reconcileIstioGateway(
 	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
 	desired *istioclientv1beta1.Gateway,
 ) error {
 	var existing istioclientv1beta1.Gateway
@@ -281,23 +376,82 @@ func (r *GatewayReconciler) reconcileIstioGateway(
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Create
-			return r.Create(ctx, desired)
+			if err := r.Create(ctx, desired); err != nil {
+				return err
+			}
+			recorder.Eventf(r.Recorder, gateway, corev1.EventTypeNormal, consts.ReasonIstioGatewayCreated,
+				"ConfigureIstioGateway", "Created Istio Gateway %s with %d hosts", desired.Name, len(istioGatewayHosts(desired)))
+			return nil
 		}
 		return err
 	}
 
+	if !metav1.IsControlledBy(&existing, gateway) {
+		return &notControlledError{kind: "Istio Gateway", key: client.ObjectKeyFromObject(&existing)}
+	}
+
 	// Update if needed
 	if r.istioGatewayNeedsUpdate(&existing, desired) {
+		added, removed := hostChanges(istioGatewayHosts(&existing), istioGatewayHosts(desired))
 		patch := client.MergeFrom(existing.DeepCopy())
 		desired.Spec.DeepCopyInto(&existing.Spec)
 		existing.Labels = desired.Labels
-		return r.Patch(ctx, &existing, patch)
+		if err := r.Patch(ctx, &existing, patch); err != nil {
+			return err
+		}
+		if len(added)+len(removed) > 0 {
+			recorder.Eventf(r.Recorder, gateway, corev1.EventTypeNormal, consts.ReasonIstioGatewayHostsChanged,
+				"ConfigureIstioGateway", "%s", describeHostChanges(added, removed))
+		}
 	}
 
 	return nil
 }
 
-// deleteIstioGateway deletes the Istio Gateway resource if it exists
+// istioGatewayHosts returns the hosts of the server of an Istio Gateway
+func istioGatewayHosts(gateway *istioclientv1beta1.Gateway) []string {
+	if len(gateway.Spec.Servers) == 0 {
+		return nil
+	}
+	return gateway.Spec.Servers[0].Hosts
+}
+
+// hostChanges returns the sorted hosts only in desired and only in existing
+func hostChanges(existing, desired []string) (added, removed []string) {
+	existingSet := make(map[string]bool, len(existing))
+	for _, host := range existing {
+		existingSet[host] = true
+	}
+	desiredSet := make(map[string]bool, len(desired))
+	for _, host := range desired {
+		desiredSet[host] = true
+		if !existingSet[host] {
+			added = append(added, host)
+		}
+	}
+	for _, host := range existing {
+		if !desiredSet[host] {
+			removed = append(removed, host)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// describeHostChanges describes the hosts added to and removed from an Istio Gateway in an event
+func describeHostChanges(added, removed []string) string {
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, "added "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, "removed "+strings.Join(removed, ", "))
+	}
+	return fmt.Sprintf("Istio Gateway hosts changed: %s", strings.Join(changes, "; "))
+}
+
+// deleteIstioGateway deletes the Istio Gateway resource if it exists and is controlled by the Gateway
 func (r *GatewayReconciler) deleteIstioGateway(
 	ctx context.Context,
 	gateway *routingv1alpha1.Gateway,
@@ -315,7 +469,17 @@ func (r *GatewayReconciler) deleteIstioGateway(
 		return err
 	}
 
-	return r.Delete(ctx, &existing)
+	// Never delete an Istio Gateway the operator does not own
+	if !metav1.IsControlledBy(&existing, gateway) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, &existing); err != nil {
+		return err
+	}
+	recorder.Eventf(r.Recorder, gateway, corev1.EventTypeNormal, consts.ReasonIstioGatewayDeleted,
+		"ConfigureIstioGateway", "Deleted Istio Gateway %s", existing.Name)
+	return nil
 }
 
 // istioGatewayNeedsUpdate checks if the Istio Gateway needs updating
@@ -382,6 +546,7 @@ func (r *GatewayReconciler) updateStatusActive(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(gateway.Status.Conditions)
 	gateway.Status.Phase = consts.PhaseActive
 	gateway.Status.LoadBalancerIP = lbIP
 
@@ -399,7 +564,7 @@ func (r *GatewayReconciler) updateStatusActive(
 	dnsReason := consts.ReasonDNSNotReady
 	if dnsReady {
 		dnsStatus = metav1.ConditionTrue
-		dnsReason = consts.ReasonDNSEndpointsCreated
+		dnsReason = consts.ReasonDNSRecordsObserved
 	} else if lbIP == "" {
 		dnsReason = consts.ReasonLoadBalancerIPPending
 	}
@@ -414,6 +579,7 @@ func (r *GatewayReconciler) updateStatusActive(
 
 	if err := r.Status().Update(ctx, gateway); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("Gateway").Inc()
 			logger.Info("Gateway status update conflict (Active), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -421,6 +587,8 @@ func (r *GatewayReconciler) updateStatusActive(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, gateway, corev1.EventTypeNormal, previous, gateway.Status.Conditions)
+
 	// If DNS is not ready (likely waiting for IP), requeue after delay
 	if !dnsReady {
 		logger.Info("Gateway waiting for LoadBalancer IP/DNS", "phase", gateway.Status.Phase, "dnsReady", dnsReady)
@@ -443,6 +611,7 @@ func (r *GatewayReconciler) updateStatusPending(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(gateway.Status.Conditions)
 	gateway.Status.Phase = consts.PhasePending
 	gateway.Status.LoadBalancerIP = lbIP
 
@@ -460,7 +629,7 @@ func (r *GatewayReconciler) updateStatusPending(
 	dnsReason := consts.ReasonDNSNotReady
 	if dnsReady {
 		dnsStatus = metav1.ConditionTrue
-		dnsReason = consts.ReasonDNSEndpointsCreated
+		dnsReason = consts.ReasonDNSRecordsObserved
 	} else if lbIP == "" {
 		dnsReason = consts.ReasonLoadBalancerIPPending
 	}
@@ -475,6 +644,7 @@ func (r *GatewayReconciler) updateStatusPending(
 
 	if err := r.Status().Update(ctx, gateway); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("Gateway").Inc()
 			logger.Info("Gateway status update conflict (Pending), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -482,6 +652,8 @@ func (r *GatewayReconciler) updateStatusPending(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, gateway, corev1.EventTypeNormal, previous, gateway.Status.Conditions)
+
 	// If DNS is not ready (likely waiting for IP), requeue after delay
 	if !dnsReady {
 		logger.Info("Gateway Pending, waiting for LoadBalancer IP/DNS", "phase", gateway.Status.Phase, "dnsReady", dnsReady)
@@ -496,6 +668,7 @@ func (r *GatewayReconciler) updateStatusPending(
 func (r *GatewayReconciler) updateStatusFailed(ctx context.Context, gateway *routingv1alpha1.Gateway, reason, message string) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(gateway.Status.Conditions)
 	gateway.Status.Phase = consts.PhaseFailed
 	meta.SetStatusCondition(&gateway.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
@@ -507,6 +680,7 @@ func (r *GatewayReconciler) updateStatusFailed(ctx context.Context, gateway *rou
 
 	if err := r.Status().Update(ctx, gateway); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("Gateway").Inc()
 			logger.Info("Gateway status update conflict (Failed), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -514,6 +688,8 @@ func (r *GatewayReconciler) updateStatusFailed(ctx context.Context, gateway *rou
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, gateway, corev1.EventTypeWarning, previous, gateway.Status.Conditions)
+
 	logger.Info("Gateway marked as Failed", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
@@ -564,6 +740,10 @@ func (r *GatewayReconciler) checkDNSStatus(
 	ctx context.Context,
 	gateway *routingv1alpha1.Gateway,
 ) (string, bool, string) {
+	if usesGatewayAPI(gateway) && r.GatewayAPIEnabled {
+		return r.checkGatewayAPIDNSStatus(ctx, gateway)
+	}
+
 	svc, err := r.getLoadBalancerService(ctx, gateway.Spec.Controller)
 	if err != nil || svc == nil {
 		return "", false, "LoadBalancer Service not found"
@@ -578,7 +758,84 @@ func (r *GatewayReconciler) checkDNSStatus(
 		return "", false, "LoadBalancer IP empty"
 	}
 
-	return ip, true, "DNSEndpoints provisioned"
+	ready, message := r.checkGatewayRecords(ctx, "gateway-service/"+gateway.Spec.Controller+"/"+gateway.Spec.TargetPostfix,
+		"", gatewayServiceRecordLabels(gateway.Spec.Controller, gateway.Spec.TargetPostfix), ip)
+	return ip, ready, message
+}
+
+// checkGatewayAPIDNSStatus reads the address assigned to the Gateway API Gateway
+func (r *GatewayReconciler) checkGatewayAPIDNSStatus(
+	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
+) (string, bool, string) {
+	existing := newGatewayAPIObject(gatewayAPIGatewayGVK)
+	if err := r.Get(ctx, client.ObjectKey{Name: gateway.Name, Namespace: gateway.Namespace}, existing); err != nil {
+		return "", false, "Gateway API Gateway not found"
+	}
+
+	ip := gatewayAPIAddress(existing)
+	if ip == "" {
+		return "", false, "Gateway API Gateway address pending"
+	}
+
+	ready, message := r.checkGatewayRecords(ctx, "gateway-api/"+gateway.Namespace+"/"+gateway.Name,
+		gateway.Namespace, gatewayAPIRecordLabels(gateway), ip)
+	return ip, ready, message
+}
+
+// checkGatewayRecords reports whether the DNS provider processed the A records pointing the target
+// host of a Gateway at ip. The records are published by the IngressDNS controller; Gateways sharing
+// an Istio controller and target postfix share their records, so owner identifies the records
+// rather than the Gateway.
+func (r *GatewayReconciler) checkGatewayRecords(
+	ctx context.Context,
+	owner string,
+	namespace string,
+	labels map[string]string,
+	ip string,
+) (bool, string) {
+	published, err := r.DNSBackend.List(ctx, namespace, labels)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list the gateway DNSEndpoints")
+		return false, "Failed to read the gateway DNSEndpoints"
+	}
+	if len(published) == 0 {
+		return false, "Waiting for the A records of the target host to be published"
+	}
+
+	states := gatewayRecordSetStates(published, ip)
+	metrics.DNSReady.Observe("Gateway", owner, states)
+	return observedDNSStatus(states)
+}
+
+// mapDNSEndpointToGateways returns reconcile requests for the Gateways whose A records a DNSEndpoint publishes
+func (r *GatewayReconciler) mapDNSEndpointToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+
+	switch labels["router.io/resource-type"] {
+	case "gateway-api":
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{Name: labels["router.io/gateway"], Namespace: obj.GetNamespace()},
+		}}
+	case "gateway-service":
+		var gateways routingv1alpha1.GatewayList
+		if err := r.List(ctx, &gateways); err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, gw := range gateways.Items {
+			if gw.Spec.Controller == labels["router.io/istio-controller"] &&
+				gw.Spec.TargetPostfix == labels["router.io/target-postfix"] {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace},
+				})
+			}
+		}
+		return requests
+	}
+
+	return nil
 }
 
 // mapServiceToGateways returns reconcile requests for Gateways using the updated Service
@@ -614,8 +871,9 @@ func (r *GatewayReconciler) mapServiceToGateways(ctx context.Context, obj client
 	return requests
 }
 
-// mapDNSConfigToGateways returns reconcile requests for all Gateways when DNSConfiguration changes
-func (r *GatewayReconciler) mapDNSConfigToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+// mapGlobalConfigToGateways returns reconcile requests for all Gateways when the ClusterIdentity
+// or DNSConfiguration changes
+func (r *GatewayReconciler) mapGlobalConfigToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
 	var gateways routingv1alpha1.GatewayList
 	if err := r.List(ctx, &gateways); err != nil {
 		return nil
@@ -649,21 +907,47 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&routingv1alpha1.Gateway{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
-		Owns(&istioclientv1beta1.Gateway{}).
+	if r.DNSBackend == nil {
+		r.DNSBackend = dnsbackend.NewExternalDNS(mgr.GetClient())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("gateway-controller")
+	}
+
+	// Status updates are filtered, except for the DNSEndpoints: ExternalDNS reports processing
+	// the records in their status
+	generationChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&routingv1alpha1.Gateway{}, generationChanged).
+		Owns(&istioclientv1beta1.Gateway{}, generationChanged)
+
+	// Only watch Gateway API resources when the CRDs are expected to be installed
+	if r.GatewayAPIEnabled {
+		// The LoadBalancer address of a Gateway API Gateway is only published in its status
+		controllerBuilder = controllerBuilder.Owns(newGatewayAPIObject(gatewayAPIGatewayGVK),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, gatewayAPIAddressChanged)))
+	}
+
+	return controllerBuilder.
 		Watches(
 			&routingv1alpha1.ServiceRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.mapServiceRouteToGateway),
+			generationChanged,
 		).
 		Watches(
 			&corev1.Service{},
 			handler.EnqueueRequestsFromMapFunc(r.mapServiceToGateways),
+			generationChanged,
 		).
+		// DNSReady follows ExternalDNS processing the A records of the target host
 		Watches(
-			&clusterv1alpha1.DNSConfiguration{},
-			handler.EnqueueRequestsFromMapFunc(r.mapDNSConfigToGateways),
+			&externaldnsv1alpha1.DNSEndpoint{},
+			handler.EnqueueRequestsFromMapFunc(r.mapDNSEndpointToGateways),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, dnsEndpointObserved)),
 		).
+		// Hostnames follow the ClusterIdentity, including the end of a hostname migration
+		WatchesRawSource(r.Identity.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToGateways))).
+		WatchesRawSource(r.Config.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalConfigToGateways))).
 		Complete(r)
 }
@@ -22,7 +22,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
@@ -429,5 +432,92 @@ var _ = Describe("Gateway Controller", func() {
 			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, gateway)).Should(Succeed())
 		})
+
+		It("should set status to Failed when GatewayAPI is used without gatewayClassName", func() {
+			gateway := &routingv1alpha1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gateway-api-no-class",
+					Namespace: "istio-system",
+				},
+				Spec: routingv1alpha1.GatewaySpec{
+					Controller:     "aks-istio-ingressgateway-internal",
+					CredentialName: "wildcard-cert",
+					TargetPostfix:  "internal",
+					Implementation: "GatewayAPI",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, gateway)).Should(Succeed())
+
+			gatewayLookupKey := types.NamespacedName{Name: gateway.Name, Namespace: "istio-system"}
+			createdGateway := &routingv1alpha1.Gateway{}
+
+			Eventually(func() string {
+				err := k8sClient.Get(ctx, gatewayLookupKey, createdGateway)
+				if err != nil {
+					return ""
+				}
+				return createdGateway.Status.Phase
+			}, timeout, interval).Should(Equal("Failed"))
+
+			Expect(k8sClient.Delete(ctx, gateway)).Should(Succeed())
+		})
+
+		It("should generate a Gateway API Gateway instead of an Istio Gateway", func() {
+			gateway := &routingv1alpha1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gateway-api",
+					Namespace: "istio-system",
+				},
+				Spec: routingv1alpha1.GatewaySpec{
+					Controller:       "aks-istio-ingressgateway-internal",
+					CredentialName:   "wildcard-cert",
+					TargetPostfix:    "internal",
+					Implementation:   "GatewayAPI",
+					GatewayClassName: "istio",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, gateway)).Should(Succeed())
+
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-route-for-gateway-api",
+					Namespace: "default",
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					GatewayName:      gateway.Name,
+					GatewayNamespace: "istio-system",
+					ServiceName:      "api",
+					Environment:      "dev",
+					Application:      "shop",
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			lookupKey := types.NamespacedName{Name: gateway.Name, Namespace: "istio-system"}
+
+			// The Gateway API Gateway carries one HTTPS listener per host
+			gatewayAPIGateway := newGatewayAPIObject(gatewayAPIGatewayGVK)
+			Eventually(func() error {
+				return k8sClient.Get(ctx, lookupKey, gatewayAPIGateway)
+			}, timeout, interval).Should(Succeed())
+
+			className, _, _ := unstructured.NestedString(gatewayAPIGateway.Object, "spec", "gatewayClassName")
+			Expect(className).To(Equal("istio"))
+			listeners, _, _ := unstructured.NestedSlice(gatewayAPIGateway.Object, "spec", "listeners")
+			Expect(listeners).To(HaveLen(1))
+			Expect(listeners[0].(map[string]interface{})["hostname"]).To(Equal("api-ns-d-dev-shop.example.com"))
+
+			// No Istio Gateway is generated
+			Consistently(func() bool {
+				err := k8sClient.Get(ctx, lookupKey, &istioclientv1beta1.Gateway{})
+				return apierrors.IsNotFound(err)
+			}, time.Second*2, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, gateway)).Should(Succeed())
+		})
 	})
 })
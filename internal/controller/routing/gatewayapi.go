@@ -0,0 +1,347 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+// Gateway API resources are handled as unstructured objects so the operator does not
+// depend on the gateway-api Go module. Only the fields the operator sets are compared
+// during drift detection, leaving server-side defaults untouched.
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+var (
+	// gatewayAPIGatewayGVK is the Gateway API Gateway kind
+	gatewayAPIGatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+
+	// gatewayAPIHTTPRouteGVK is the Gateway API HTTPRoute kind
+	gatewayAPIHTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+)
+
+// usesGatewayAPI reports whether the Gateway should be rendered as a Gateway API Gateway
+func usesGatewayAPI(gateway *routingv1alpha1.Gateway) bool {
+	return gateway.Spec.Implementation == consts.ImplementationGatewayAPI
+}
+
+// newGatewayAPIObject returns an empty unstructured object of the given kind
+func newGatewayAPIObject(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
+
+// maxGatewayAPIListeners is the maximum number of listeners of a Gateway API Gateway
+const maxGatewayAPIListeners = 64
+
+// maxGatewayAPIListenerName is the maximum length of a Gateway API listener name (a SectionName)
+const maxGatewayAPIListenerName = 253
+
+// gatewayAPIListenerName returns the name of the listener of host. Names that would exceed the
+// SectionName limit are truncated and end in a hash of the hostname, so they stay unique.
+func gatewayAPIListenerName(host string) string {
+	name := "https-" + host
+	if len(name) <= maxGatewayAPIListenerName {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(host))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return strings.TrimRight(name[:maxGatewayAPIListenerName-len(suffix)], ".-") + suffix
+}
+
+// generateGatewayAPIGateway builds a Gateway API Gateway with one HTTPS listener per host.
+// Listeners accept routes from all namespaces so ServiceRoute HTTPRoutes can attach.
+// It fails when there are more hosts than a Gateway API Gateway allows listeners.
+func generateGatewayAPIGateway(gateway *routingv1alpha1.Gateway, hosts []string) (*unstructured.Unstructured, error) {
+	if len(hosts) > maxGatewayAPIListeners {
+		return nil, fmt.Errorf("%d hostnames use Gateway %s/%s but a Gateway API Gateway has at most %d listeners, "+
+			"spread the ServiceRoutes over more Gateways", len(hosts), gateway.Namespace, gateway.Name, maxGatewayAPIListeners)
+	}
+
+	sorted := append([]string(nil), hosts...)
+	sort.Strings(sorted)
+
+	listeners := make([]interface{}, 0, len(sorted))
+	for _, host := range sorted {
+		listeners = append(listeners, map[string]interface{}{
+			"name":     gatewayAPIListenerName(host),
+			"hostname": host,
+			"port":     int64(443),
+			"protocol": "HTTPS",
+			"tls": map[string]interface{}{
+				"mode": "Terminate",
+				"certificateRefs": []interface{}{
+					map[string]interface{}{
+						"kind": "Secret",
+						"name": gateway.Spec.CredentialName,
+					},
+				},
+			},
+			"allowedRoutes": map[string]interface{}{
+				"namespaces": map[string]interface{}{
+					"from": "All",
+				},
+			},
+		})
+	}
+
+	obj := newGatewayAPIObject(gatewayAPIGatewayGVK)
+	obj.SetName(gateway.Name)
+	obj.SetNamespace(gateway.Namespace)
+	obj.SetLabels(map[string]string{
+		"app.kubernetes.io/managed-by": "service-router-operator",
+		"router.io/gateway":            gateway.Name,
+	})
+	obj.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(gateway, routingv1alpha1.GroupVersion.WithKind("Gateway")),
+	})
+	obj.Object["spec"] = map[string]interface{}{
+		"gatewayClassName": gateway.Spec.GatewayClassName,
+		"listeners":        listeners,
+	}
+
+	return obj, nil
+}
+
+// generateHTTPRoute builds a Gateway API HTTPRoute that attaches the ServiceRoute hostnames
+// to the Gateway API Gateway and forwards them to the backend Service.
+func generateHTTPRoute(
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	gateway *routingv1alpha1.Gateway,
+	hosts []string,
+) *unstructured.Unstructured {
+	backend := serviceRoute.Spec.Backend
+
+	rule := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"name": backend.ServiceName,
+				"port": int64(backend.Port),
+			},
+		},
+	}
+
+	if len(backend.PathPrefixes) > 0 {
+		matches := make([]interface{}, 0, len(backend.PathPrefixes))
+		for _, prefix := range backend.PathPrefixes {
+			matches = append(matches, map[string]interface{}{
+				"path": map[string]interface{}{
+					"type":  "PathPrefix",
+					"value": prefix,
+				},
+			})
+		}
+		rule["matches"] = matches
+	}
+
+	// Unsupported timeouts are rejected by validation.ServiceRouteGateway before the HTTPRoute is generated
+	if backend.Timeout != nil {
+		if timeout, err := validation.GatewayAPIDuration(backend.Timeout.Duration); err == nil {
+			rule["timeouts"] = map[string]interface{}{
+				"request": timeout,
+			}
+		}
+	}
+
+	hostnames := make([]interface{}, 0, len(hosts))
+	for _, host := range hosts {
+		hostnames = append(hostnames, host)
+	}
+
+	obj := newGatewayAPIObject(gatewayAPIHTTPRouteGVK)
+	obj.SetName(serviceRoute.Name)
+	obj.SetNamespace(serviceRoute.Namespace)
+	obj.SetLabels(map[string]string{
+		"app.kubernetes.io/managed-by": "service-router-operator",
+		"router.io/serviceroute":       serviceRoute.Name,
+		"router.io/gateway":            gateway.Name,
+	})
+	obj.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(serviceRoute, routingv1alpha1.GroupVersion.WithKind("ServiceRoute")),
+	})
+	obj.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"group":     gatewayAPIGatewayGVK.Group,
+				"kind":      gatewayAPIGatewayGVK.Kind,
+				"name":      gateway.Name,
+				"namespace": gateway.Namespace,
+			},
+		},
+		"hostnames": hostnames,
+		"rules":     []interface{}{rule},
+	}
+
+	return obj
+}
+
+// reconcileUnstructured creates the desired object or patches its spec and labels on drift.
+// An existing object that is not controlled by owner is never patched.
+func reconcileUnstructured(
+	ctx context.Context,
+	c client.Client,
+	owner metav1.Object,
+	desired *unstructured.Unstructured,
+) error {
+	existing := newGatewayAPIObject(desired.GroupVersionKind())
+	err := c.Get(ctx, client.ObjectKey{
+		Name:      desired.GetName(),
+		Namespace: desired.GetNamespace(),
+	}, existing)
+
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Create
+			return c.Create(ctx, desired)
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(existing, owner) {
+		return &notControlledError{kind: desired.GetKind(), key: client.ObjectKeyFromObject(existing)}
+	}
+
+	// Update if needed
+	labelsMatch := true
+	for k, v := range desired.GetLabels() {
+		if existing.GetLabels()[k] != v {
+			labelsMatch = false
+			break
+		}
+	}
+	if labelsMatch && isSubset(desired.Object["spec"], existing.Object["spec"]) {
+		return nil
+	}
+
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetLabels(desired.GetLabels())
+	return c.Patch(ctx, existing, patch)
+}
+
+// deleteUnstructured deletes the named object if it exists and is controlled by owner
+func deleteUnstructured(
+	ctx context.Context,
+	c client.Client,
+	gvk schema.GroupVersionKind,
+	key client.ObjectKey,
+	owner metav1.Object,
+) error {
+	existing := newGatewayAPIObject(gvk)
+	if err := c.Get(ctx, key, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Never delete an object the operator does not own
+	if !metav1.IsControlledBy(existing, owner) {
+		return nil
+	}
+
+	return client.IgnoreNotFound(c.Delete(ctx, existing))
+}
+
+// gatewayAPIAddress returns the first address published in the Gateway API Gateway status
+func gatewayAPIAddress(obj *unstructured.Unstructured) string {
+	addresses, found, err := unstructured.NestedSlice(obj.Object, "status", "addresses")
+	if err != nil || !found {
+		return ""
+	}
+	for _, a := range addresses {
+		address, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := address["value"].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// gatewayAPIAddressChanged passes Gateway API Gateway updates in which the published address changed.
+// The address is only reported in the status, so it is missed by a generation filter.
+var gatewayAPIAddressChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldGateway, ok := e.ObjectOld.(*unstructured.Unstructured)
+		if !ok {
+			return false
+		}
+		newGateway, ok := e.ObjectNew.(*unstructured.Unstructured)
+		if !ok {
+			return false
+		}
+		return gatewayAPIAddress(oldGateway) != gatewayAPIAddress(newGateway)
+	},
+}
+
+// isSubset reports whether every field set in desired has the same value in existing.
+// Fields only present in existing (e.g., server-side defaults) are ignored.
+func isSubset(desired, existing interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		e, ok := existing.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if !isSubset(v, e[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		e, ok := existing.([]interface{})
+		if !ok || len(e) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !isSubset(d[i], e[i]) {
+				return false
+			}
+		}
+		return true
+	case int64:
+		switch e := existing.(type) {
+		case int64:
+			return d == e
+		case float64:
+			return float64(d) == e
+		}
+		return false
+	default:
+		return desired == existing
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+)
+
+var _ = Describe("Gateway API Gateway generation", func() {
+	gateway := &routingv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "crowded-gateway", Namespace: "istio-system"},
+		Spec: routingv1alpha1.GatewaySpec{
+			CredentialName:   "wildcard-cert",
+			Implementation:   "GatewayAPI",
+			GatewayClassName: "istio",
+		},
+	}
+
+	It("should generate one listener per host up to the Gateway API limit", func() {
+		hosts := make([]string, 0, maxGatewayAPIListeners)
+		for i := 0; i < maxGatewayAPIListeners; i++ {
+			hosts = append(hosts, fmt.Sprintf("api-%d.example.com", i))
+		}
+
+		desired, err := generateGatewayAPIGateway(gateway, hosts)
+		Expect(err).NotTo(HaveOccurred())
+		listeners, _, _ := unstructured.NestedSlice(desired.Object, "spec", "listeners")
+		Expect(listeners).To(HaveLen(maxGatewayAPIListeners))
+	})
+
+	It("should refuse more hosts than a Gateway API Gateway has listeners", func() {
+		hosts := make([]string, 0, maxGatewayAPIListeners+1)
+		for i := 0; i <= maxGatewayAPIListeners; i++ {
+			hosts = append(hosts, fmt.Sprintf("api-%d.example.com", i))
+		}
+
+		_, err := generateGatewayAPIGateway(gateway, hosts)
+		Expect(err).To(MatchError("65 hostnames use Gateway istio-system/crowded-gateway but a Gateway API Gateway " +
+			"has at most 64 listeners, spread the ServiceRoutes over more Gateways"))
+	})
+
+	It("should keep listener names within the SectionName limit", func() {
+		long := strings.Repeat(strings.Repeat("a", 59)+".", 4) + "example.com"
+		other := strings.Repeat(strings.Repeat("a", 59)+".", 4) + "example.org"
+
+		obj, err := generateGatewayAPIGateway(gateway, []string{"api.example.com", long, other})
+		Expect(err).NotTo(HaveOccurred())
+		listeners, _, _ := unstructured.NestedSlice(obj.Object, "spec", "listeners")
+		names := map[string]string{}
+		for _, listener := range listeners {
+			listener := listener.(map[string]interface{})
+			names[listener["hostname"].(string)] = listener["name"].(string)
+		}
+
+		Expect(names["api.example.com"]).To(Equal("https-api.example.com"))
+		for _, host := range []string{long, other} {
+			Expect(len(names[host])).To(BeNumerically("<=", 253))
+			Expect(names[host]).To(MatchRegexp(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`))
+		}
+		Expect(names[long]).NotTo(Equal(names[other]))
+	})
+
+	It("should write HTTPRoute timeouts in whole units", func() {
+		serviceRoute := &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				Backend: &routingv1alpha1.ServiceRouteBackend{
+					ServiceName: "api",
+					Port:        8080,
+					Timeout:     &metav1.Duration{Duration: 1500 * time.Millisecond},
+				},
+			},
+		}
+
+		httpRoute := generateHTTPRoute(serviceRoute, gateway, []string{"api.example.com"})
+		rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+		Expect(rules).To(HaveLen(1))
+		timeout, _, _ := unstructured.NestedString(rules[0].(map[string]interface{}), "timeouts", "request")
+		Expect(timeout).To(Equal("1500ms"))
+	})
+})
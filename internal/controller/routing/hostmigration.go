@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/hostname"
+)
+
+// migrationLabel marks the record sets publishing the hostnames of a previous cluster identity
+// during a hostname migration, see clusteridentity.IdentityProvider
+const migrationLabel = "router.io/migration"
+
+// migratingHosts are the hostnames of a ServiceRoute under a previous cluster identity
+type migratingHosts struct {
+	identity   *clusteridentity.ClusterIdentity
+	sourceHost string
+	aliases    []string
+}
+
+// hosts returns the source host followed by the aliases
+func (m migratingHosts) hosts() []string {
+	return append([]string{m.sourceHost}, m.aliases...)
+}
+
+// serviceRouteMigratingHosts returns the hostnames of a ServiceRoute under each previous cluster identity.
+// A previous identity rendering one of the current hosts as source host is skipped, the current records
+// already publish it, and so are aliases that did not change. Hostnames a previous identity no longer
+// renders are skipped as well: they were valid while the identity was current.
+func serviceRouteMigratingHosts(
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	currentHosts []string,
+	previous []*clusteridentity.ClusterIdentity,
+) []migratingHosts {
+	seen := make(map[string]bool, len(currentHosts))
+	for _, host := range currentHosts {
+		seen[host] = true
+	}
+
+	var migrating []migratingHosts
+	for _, identity := range previous {
+		sourceHost, err := hostname.SourceHost(identity,
+			serviceRoute.Spec.ServiceName,
+			serviceRoute.Spec.Environment,
+			serviceRoute.Spec.Application,
+		)
+		if err != nil || seen[sourceHost] {
+			continue
+		}
+		seen[sourceHost] = true
+
+		m := migratingHosts{identity: identity, sourceHost: sourceHost}
+		for _, alias := range hostname.Aliases(identity, sourceHost, serviceRoute.Spec.Aliases) {
+			if !seen[alias] {
+				seen[alias] = true
+				m.aliases = append(m.aliases, alias)
+			}
+		}
+		migrating = append(migrating, m)
+	}
+	return migrating
+}
+
+// migratingTargetHosts returns the gateway target hostnames of the previous cluster identities
+// that differ from the current target host
+func migratingTargetHosts(targetHost, targetPostfix string, previous []*clusteridentity.ClusterIdentity) []string {
+	seen := map[string]bool{targetHost: true}
+	var hosts []string
+	for _, identity := range previous {
+		host, err := hostname.TargetHost(identity, targetPostfix)
+		if err != nil || seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// gatewayTargetRecords returns the A records of the current target host and, during a hostname
+// migration, of the previous target hosts, all pointing at the gateway address
+func gatewayTargetRecords(targetHost, targetPostfix, ip string, previous []*clusteridentity.ClusterIdentity) []dnsbackend.Record {
+	records := gatewayARecords(targetHost, ip)
+	for _, host := range migratingTargetHosts(targetHost, targetPostfix, previous) {
+		records = append(records, gatewayARecords(host, ip)...)
+	}
+	return records
+}
+
+// migrationRecordSetName returns the name of the record set publishing a migrating source host.
+// The name derives from the hostname, so it is stable while other migrations start or end.
+func migrationRecordSetName(recordSetName, sourceHost string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(sourceHost))
+	return fmt.Sprintf("%s-migration-%08x", recordSetName, h.Sum32())
+}
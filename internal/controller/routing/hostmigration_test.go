@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+)
+
+var _ = Describe("Hostname migration", func() {
+	var (
+		identity     *clusteridentity.ClusterIdentity
+		previous     []*clusteridentity.ClusterIdentity
+		config       *dnsconfiguration.DNSConfiguration
+		serviceRoute *routingv1alpha1.ServiceRoute
+		dnsPolicy    *routingv1alpha1.DNSPolicy
+		gateway      *routingv1alpha1.Gateway
+	)
+
+	BeforeEach(func() {
+		identity = &clusteridentity.ClusterIdentity{
+			Region:            "weu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		}
+		previous = []*clusteridentity.ClusterIdentity{{
+			Region:            "neu",
+			Cluster:           "aks",
+			Domain:            "old.example.com",
+			EnvironmentLetter: "d",
+		}}
+		config = &dnsconfiguration.DNSConfiguration{
+			ExternalDNSControllers: []dnsconfiguration.ExternalDNSController{
+				{Name: "external-dns-weu", Region: "weu"},
+			},
+		}
+		serviceRoute = &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				Environment: "dev",
+				Application: "shop",
+				Aliases:     []string{"shop", "shop.partner.com."},
+			},
+		}
+		dnsPolicy = &routingv1alpha1.DNSPolicy{
+			Status: routingv1alpha1.DNSPolicyStatus{ActiveControllers: []string{"external-dns-weu"}},
+		}
+		gateway = &routingv1alpha1.Gateway{Spec: routingv1alpha1.GatewaySpec{TargetPostfix: "internal"}}
+	})
+
+	It("should publish the previous hostnames in a record set of their own pointing at the previous target", func() {
+		r := &ServiceRouteReconciler{}
+
+		recordSets, err := r.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, previous, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets).To(HaveLen(2))
+
+		Expect(recordSets[0].Name).To(Equal("api-external-dns-weu"))
+		Expect(recordSets[0].Labels).NotTo(HaveKey(migrationLabel))
+		Expect(recordSets[0].Records[0].Targets).To(Equal([]string{"aks-weu-internal.example.com"}))
+
+		migrating := recordSets[1]
+		Expect(migrating.Name).To(HavePrefix("api-external-dns-weu-migration-"))
+		Expect(migrating.Labels).To(HaveKeyWithValue(migrationLabel, "true"))
+		Expect(migrating.Records).To(HaveLen(4))
+		Expect(migrating.Records[0].DNSName).To(Equal("api-ns-d-dev-shop.old.example.com"))
+		Expect(migrating.Records[0].Targets).To(Equal([]string{"aks-neu-internal.old.example.com"}))
+		// The fully-qualified alias did not change, the current record set publishes it
+		Expect(migrating.Records[1].DNSName).To(Equal("shop.old.example.com"))
+		Expect(migrating.Records[2].DNSName).To(Equal("_router-owner.api-ns-d-dev-shop.old.example.com"))
+		Expect(migrating.Records[3].DNSName).To(Equal("_router-owner.shop.old.example.com"))
+
+		status := dnsEndpointStatus(migrating)
+		Expect(status.Migrating).To(BeTrue())
+		Expect(status.SourceHost).To(Equal("api-ns-d-dev-shop.old.example.com"))
+	})
+
+	It("should not publish a previous identity rendering the current hostnames", func() {
+		r := &ServiceRouteReconciler{}
+		previous[0].Domain = "example.com"
+
+		recordSets, err := r.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, previous, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets).To(HaveLen(1))
+	})
+
+	It("should point the previous gateway target hosts at the gateway address", func() {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"}}
+
+		recordSets, err := gatewayServiceRecordSets("aks-istio", "internal", svc, "10.0.0.1", identity, previous, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets).To(HaveLen(1))
+		Expect(recordSets[0].Records).To(HaveLen(2))
+		Expect(recordSets[0].Records[0].DNSName).To(Equal("aks-weu-internal.example.com"))
+		Expect(recordSets[0].Records[1].DNSName).To(Equal("aks-neu-internal.old.example.com"))
+		Expect(recordSets[0].Records[1].Targets).To(Equal([]string{"10.0.0.1"}))
+	})
+
+	It("should keep the previous hostnames on the backend route", func() {
+		hosts := []string{"api-ns-d-dev-shop.example.com", "shop.example.com", "shop.partner.com"}
+
+		migrating := serviceRouteMigratingHosts(serviceRoute, hosts, previous)
+		Expect(migrating).To(HaveLen(1))
+		Expect(migrating[0].hosts()).To(Equal([]string{"api-ns-d-dev-shop.old.example.com", "shop.old.example.com"}))
+	})
+})
@@ -22,33 +22,45 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
-	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
 
-	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
 	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/hostname"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
 // IngressDNSReconciler reconciles global DNS infrastructure for Gateways
 type IngressDNSReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// DNSBackend publishes the gateway records, defaults to ExternalDNS DNSEndpoints
+	DNSBackend dnsbackend.DNSBackend
+	// Identity provides the cluster identity, defaults to the manager's informer cache
+	Identity clusteridentity.IdentityProvider
+	// Config provides the DNS configuration, defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// Recorder records the outcome of publishing the gateway records as events on the Gateways
+	Recorder events.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=routing.router.io,resources=gateways,verbs=get;list;watch
+//+kubebuilder:rbac:groups=routing.router.io,resources=gateways/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 //+kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=dnsconfigurations,verbs=get;list;watch
 
@@ -56,6 +68,10 @@ type IngressDNSReconciler struct {
 // It aggregates all Gateway configurations to ensure:
 // 1. DNSEndpoints exist for every active Controller+Postfix combination.
 // 2. Orphaned DNSEndpoints (no active Gateway remaining) are garbage collected.
+// 3. Every Gateway reports in its InfrastructureDNSReady condition which controllers publish its A records.
+//
+// A failure for one configuration does not stop the others; the failures are aggregated and
+// returned so the reconciliation is retried with backoff.
 func (r *IngressDNSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -67,154 +83,329 @@ func (r *IngressDNSReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	activeConfigs := make(map[gatewayControllerConfig]bool)
+	activeConfigs, gatewayAPIGateways := partitionGateways(gateways.Items)
 
-	for _, gw := range gateways.Items {
-		// Skip gateways that are being deleted
-		if gw.DeletionTimestamp != nil {
-			continue
-		}
-
-		config := gatewayControllerConfig{
-			controller:    gw.Spec.Controller,
-			targetPostfix: gw.Spec.TargetPostfix,
-		}
-		activeConfigs[config] = true
-	}
+	var errs []error
 
 	// Cleanup orphaned DNSEndpoints
 	if err := r.cleanupOrphanedDNSEndpoints(ctx, activeConfigs); err != nil {
 		logger.Error(err, "failed to cleanup orphaned DNS endpoints")
+		errs = append(errs, fmt.Errorf("failed to cleanup orphaned DNS endpoints: %w", err))
+	}
+	if err := r.cleanupOrphanedGatewayAPIDNSEndpoints(ctx, gatewayAPIGateways); err != nil {
+		logger.Error(err, "failed to cleanup orphaned Gateway API DNS endpoints")
+		errs = append(errs, fmt.Errorf("failed to cleanup orphaned Gateway API DNS endpoints: %w", err))
 	}
 
-	clusterIdentity, err := clusteridentity.Fetch(ctx, r.Client)
+	clusterIdentity, err := r.Identity.Identity(ctx)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, kerrors.NewAggregate(append(errs, err))
 	}
 	if clusterIdentity == nil {
 		logger.Info("ClusterIdentity not available, skipping DNS creation/update")
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+		errs = append(errs, r.reportAllInfrastructureDNS(ctx, gateways.Items, gatewayDNSResult{
+			reason:  consts.ReasonClusterIdentityNotAvailable,
+			message: "Waiting for ClusterIdentity to be configured",
+		})...)
+		return ctrl.Result{RequeueAfter: time.Minute}, kerrors.NewAggregate(errs)
 	}
 
-	dnsConfig, err := dnsconfiguration.Fetch(ctx, r.Client)
+	// During a hostname migration the previous target hosts keep resolving to the gateways
+	previousIdentities, err := r.Identity.PreviousIdentities(ctx)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, kerrors.NewAggregate(append(errs, err))
+	}
+
+	dnsConfig, err := r.Config.Config(ctx)
+	if err != nil {
+		return ctrl.Result{}, kerrors.NewAggregate(append(errs, err))
 	}
 	if dnsConfig == nil {
 		logger.Info("DNSConfiguration not available, skipping DNS creation/update")
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+		errs = append(errs, r.reportAllInfrastructureDNS(ctx, gateways.Items, gatewayDNSResult{
+			reason:  consts.ReasonDNSConfigurationNotAvailable,
+			message: "Waiting for DNSConfiguration to be configured",
+		})...)
+		return ctrl.Result{RequeueAfter: time.Minute}, kerrors.NewAggregate(errs)
 	}
 
+	// Gateways sharing an Istio controller configuration share its records and its outcome
+	configResults := make(map[gatewayControllerConfig]gatewayDNSResult, len(activeConfigs))
 	for config := range activeConfigs {
-		if err := r.reconcileDNSEndpointsForConfig(ctx, config.controller, config.targetPostfix, clusterIdentity, dnsConfig); err != nil {
+		result, err := r.reconcileDNSEndpointsForConfig(ctx, config.controller, config.targetPostfix, clusterIdentity, previousIdentities, dnsConfig)
+		if err != nil {
 			logger.Error(err, "failed to reconcile DNS endpoints", "controller", config.controller, "postfix", config.targetPostfix)
-			// Continue with other controllers, but return error at end?
-			// For now we log and continue to try to reconcile as much as possible.
+			errs = append(errs, fmt.Errorf("controller %s (%s): %w", config.controller, config.targetPostfix, err))
+			result = gatewayDNSResult{err: err}
 		}
+		configResults[config] = result
 	}
 
-	return ctrl.Result{}, nil
+	for i := range gateways.Items {
+		gw := &gateways.Items[i]
+		if gw.DeletionTimestamp != nil || usesGatewayAPI(gw) {
+			continue
+		}
+		result := configResults[gatewayControllerConfig{controller: gw.Spec.Controller, targetPostfix: gw.Spec.TargetPostfix}]
+		if err := r.reportInfrastructureDNS(ctx, gw, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for i := range gatewayAPIGateways {
+		gw := &gatewayAPIGateways[i]
+		result, err := r.reconcileDNSEndpointsForGatewayAPI(ctx, gw, clusterIdentity, previousIdentities, dnsConfig)
+		if err != nil {
+			logger.Error(err, "failed to reconcile Gateway API DNS endpoints", "gateway", gw.Name, "namespace", gw.Namespace)
+			errs = append(errs, fmt.Errorf("gateway %s/%s: %w", gw.Namespace, gw.Name, err))
+			result = gatewayDNSResult{err: err}
+		}
+		if err := r.reportInfrastructureDNS(ctx, gw, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return ctrl.Result{}, kerrors.NewAggregate(errs)
 }
 
 // reconcileDNSEndpointsForConfig creates/updates DNSEndpoints for a specific controller configuration
+// and returns the ExternalDNS controllers the A records are published to
 func (r *IngressDNSReconciler) reconcileDNSEndpointsForConfig(
 	ctx context.Context,
 	controller string,
 	targetPostfix string,
 	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
 	dnsConfig *dnsconfiguration.DNSConfiguration,
-) error {
+) (gatewayDNSResult, error) {
 	svc, err := r.getLoadBalancerService(ctx, controller)
 	if err != nil {
-		return err
+		return gatewayDNSResult{}, err
 	}
 	if svc == nil {
 		// Service not found, cannot create DNS records yet
-		return nil
+		return gatewayDNSResult{
+			reason:  consts.ReasonLoadBalancerIPPending,
+			message: fmt.Sprintf("No LoadBalancer Service labelled istio=%s found", controller),
+		}, nil
 	}
 
-	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+	ip := loadBalancerIP(svc)
+	if ip == "" {
 		// IP not assigned yet
-		return nil
+		return gatewayDNSResult{
+			reason:  consts.ReasonLoadBalancerIPPending,
+			message: fmt.Sprintf("Service %s/%s has no LoadBalancer IP yet", svc.Namespace, svc.Name),
+		}, nil
 	}
-	ip := svc.Status.LoadBalancer.Ingress[0].IP
-	if ip == "" {
-		return nil
+
+	desired, err := gatewayServiceRecordSets(controller, targetPostfix, svc, ip, clusterIdentity, previousIdentities, dnsConfig)
+	if err != nil {
+		return gatewayDNSResult{}, err
+	}
+
+	existing, err := r.DNSBackend.List(ctx, svc.Namespace, gatewayServiceRecordLabels(controller, targetPostfix))
+	if err != nil {
+		return gatewayDNSResult{}, err
+	}
+
+	if _, err := dnsbackend.Sync(ctx, r.DNSBackend, existing, desired); err != nil {
+		return gatewayDNSResult{}, err
+	}
+	return publishedResult(desired), nil
+}
+
+// reconcileDNSEndpointsForGatewayAPI creates/updates DNSEndpoints for a Gateway that uses the
+// GatewayAPI implementation. The address is taken from the Gateway status, which the Gateway
+// controller copies from the Gateway API Gateway.
+func (r *IngressDNSReconciler) reconcileDNSEndpointsForGatewayAPI(
+	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) (gatewayDNSResult, error) {
+	if gateway.Status.LoadBalancerIP == "" {
+		// Address not assigned yet
+		return gatewayDNSResult{
+			reason:  consts.ReasonLoadBalancerIPPending,
+			message: "Gateway has no address yet",
+		}, nil
+	}
+
+	desired, err := gatewayAPIRecordSets(gateway, clusterIdentity, previousIdentities, dnsConfig)
+	if err != nil {
+		return gatewayDNSResult{}, err
+	}
+
+	existing, err := r.DNSBackend.List(ctx, gateway.Namespace, gatewayAPIRecordLabels(gateway))
+	if err != nil {
+		return gatewayDNSResult{}, err
+	}
+
+	if _, err := dnsbackend.Sync(ctx, r.DNSBackend, existing, desired); err != nil {
+		return gatewayDNSResult{}, err
+	}
+	return publishedResult(desired), nil
+}
+
+// partitionGateways splits the Gateways that are not being deleted into the Istio
+// controller configurations sharing a LoadBalancer Service and the Gateway API Gateways
+func partitionGateways(gateways []routingv1alpha1.Gateway) (map[gatewayControllerConfig]bool, []routingv1alpha1.Gateway) {
+	activeConfigs := make(map[gatewayControllerConfig]bool)
+	var gatewayAPIGateways []routingv1alpha1.Gateway
+
+	for _, gw := range gateways {
+		// Skip gateways that are being deleted
+		if gw.DeletionTimestamp != nil {
+			continue
+		}
+
+		// Gateway API gateways get their address from the Gateway status rather than
+		// from a shared Istio LoadBalancer Service, so they are handled per Gateway.
+		if usesGatewayAPI(&gw) {
+			gatewayAPIGateways = append(gatewayAPIGateways, gw)
+			continue
+		}
+
+		config := gatewayControllerConfig{
+			controller:    gw.Spec.Controller,
+			targetPostfix: gw.Spec.TargetPostfix,
+		}
+		activeConfigs[config] = true
+	}
+
+	return activeConfigs, gatewayAPIGateways
+}
+
+// loadBalancerIP returns the first ingress IP of a LoadBalancer Service, empty until one is assigned
+func loadBalancerIP(svc *corev1.Service) string {
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return ""
 	}
+	return svc.Status.LoadBalancer.Ingress[0].IP
+}
 
-	targetHost := fmt.Sprintf("%s-%s-%s.%s",
-		clusterIdentity.Cluster,
-		clusterIdentity.Region,
-		targetPostfix,
-		clusterIdentity.Domain,
-	)
+// gatewayServiceRecordLabels returns the labels identifying the record sets of an Istio controller configuration
+func gatewayServiceRecordLabels(controller, targetPostfix string) map[string]string {
+	return map[string]string{
+		"router.io/istio-controller":   controller,
+		"router.io/target-postfix":     targetPostfix,
+		"router.io/resource-type":      "gateway-service",
+		"app.kubernetes.io/managed-by": "service-router-operator",
+	}
+}
 
+// gatewayServiceRecordSets generates one record set per ExternalDNS controller managing the target host,
+// pointing the target host of an Istio controller configuration at its LoadBalancer Service address.
+// During a hostname migration the target hosts of the previous identities point at the same address.
+func gatewayServiceRecordSets(
+	controller string,
+	targetPostfix string,
+	svc *corev1.Service,
+	ip string,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) ([]*dnsbackend.RecordSet, error) {
+	targetHost, err := hostname.TargetHost(clusterIdentity, targetPostfix)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordSets []*dnsbackend.RecordSet
 	for _, extDNS := range dnsConfig.ExternalDNSControllers {
-		name := fmt.Sprintf("gateway-controller-%s-%s-%s", controller, targetPostfix, extDNS.Name)
-
-		desired := &externaldnsv1alpha1.DNSEndpoint{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: svc.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					{
-						APIVersion: "v1",
-						Kind:       "Service",
-						Name:       svc.Name,
-						UID:        svc.UID,
-						Controller: tryBool(true),
-					},
-				},
-				Labels: map[string]string{
-					"router.io/istio-controller":   controller,
-					"router.io/target-postfix":     targetPostfix,
-					"router.io/region":             extDNS.Region,
-					"router.io/resource-type":      "gateway-service",
-					"app.kubernetes.io/managed-by": "service-router-operator",
-				},
-			},
-			Spec: externaldnsv1alpha1.DNSEndpointSpec{
-				Endpoints: []*externaldnsendpoint.Endpoint{
-					{
-						DNSName:    targetHost,
-						RecordType: "A",
-						Targets:    externaldnsendpoint.Targets{ip},
-						RecordTTL:  externaldnsendpoint.TTL(300),
-					},
+		records := controllerRecords(extDNS, dnsConfig.GatewayRecordTTL(extDNS), gatewayTargetRecords(targetHost, targetPostfix, ip, previousIdentities))
+		if len(records) == 0 {
+			// The controller does not manage the target host
+			continue
+		}
+
+		labels := gatewayServiceRecordLabels(controller, targetPostfix)
+		labels["router.io/controller"] = extDNS.Name
+		labels["router.io/region"] = extDNS.Region
+
+		recordSets = append(recordSets, &dnsbackend.RecordSet{
+			Name:      fmt.Sprintf("gateway-controller-%s-%s-%s", controller, targetPostfix, extDNS.Name),
+			Namespace: svc.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       svc.Name,
+					UID:        svc.UID,
+					Controller: tryBool(true),
 				},
 			},
-		}
+			Labels:      labels,
+			Annotations: controllerAnnotations(extDNS),
+			Records:     records,
+		})
+	}
 
-		// Create or Update
-		var existing externaldnsv1alpha1.DNSEndpoint
-		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: svc.Namespace}, &existing); err != nil {
-			if apierrors.IsNotFound(err) {
-				if err := r.Create(ctx, desired); err != nil {
-					return err
-				}
-			} else {
-				return err
-			}
-		} else {
-			// Update if needed
-			// Check for equality safely to avoid panics on malformed resources
-			needsUpdate := len(existing.Spec.Endpoints) == 0 ||
-				len(existing.Spec.Endpoints[0].Targets) == 0 ||
-				existing.Spec.Endpoints[0].Targets[0] != ip ||
-				existing.Spec.Endpoints[0].DNSName != targetHost
-
-			if needsUpdate {
-				patch := client.MergeFrom(existing.DeepCopy())
-				existing.Spec = desired.Spec
-				existing.Labels = desired.Labels
-				if err := r.Patch(ctx, &existing, patch); err != nil {
-					return err
-				}
-			}
+	return recordSets, nil
+}
+
+// gatewayAPIRecordLabels returns the labels identifying the record sets of a Gateway API Gateway
+func gatewayAPIRecordLabels(gateway *routingv1alpha1.Gateway) map[string]string {
+	return map[string]string{
+		"router.io/gateway":            gateway.Name,
+		"router.io/target-postfix":     gateway.Spec.TargetPostfix,
+		"router.io/resource-type":      "gateway-api",
+		"app.kubernetes.io/managed-by": "service-router-operator",
+	}
+}
+
+// gatewayAPIRecordSets generates one record set per ExternalDNS controller managing the target host,
+// pointing the target host of a Gateway API Gateway at the address in its status.
+// During a hostname migration the target hosts of the previous identities point at the same address.
+func gatewayAPIRecordSets(
+	gateway *routingv1alpha1.Gateway,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) ([]*dnsbackend.RecordSet, error) {
+	targetHost, err := hostname.TargetHost(clusterIdentity, gateway.Spec.TargetPostfix)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordSets []*dnsbackend.RecordSet
+	for _, extDNS := range dnsConfig.ExternalDNSControllers {
+		records := controllerRecords(extDNS, dnsConfig.GatewayRecordTTL(extDNS), gatewayTargetRecords(targetHost, gateway.Spec.TargetPostfix, gateway.Status.LoadBalancerIP, previousIdentities))
+		if len(records) == 0 {
+			// The controller does not manage the target host
+			continue
 		}
+
+		labels := gatewayAPIRecordLabels(gateway)
+		labels["router.io/controller"] = extDNS.Name
+		labels["router.io/region"] = extDNS.Region
+
+		recordSets = append(recordSets, &dnsbackend.RecordSet{
+			Name:      fmt.Sprintf("gateway-%s-%s-%s", gateway.Name, gateway.Spec.TargetPostfix, extDNS.Name),
+			Namespace: gateway.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(gateway, routingv1alpha1.GroupVersion.WithKind("Gateway")),
+			},
+			Labels:      labels,
+			Annotations: controllerAnnotations(extDNS),
+			Records:     records,
+		})
 	}
 
-	return nil
+	return recordSets, nil
+}
+
+// gatewayARecords returns the A record pointing a gateway target hostname at its address
+func gatewayARecords(targetHost, ip string) []dnsbackend.Record {
+	return []dnsbackend.Record{
+		{
+			DNSName:    targetHost,
+			RecordType: "A",
+			Targets:    []string{ip},
+		},
+	}
 }
 
 // cleanupOrphanedDNSEndpoints removes DNSEndpoints for controllers that are no longer active
@@ -222,39 +413,51 @@ func (r *IngressDNSReconciler) cleanupOrphanedDNSEndpoints(
 	ctx context.Context,
 	activeConfigs map[gatewayControllerConfig]bool,
 ) error {
-	// Find all namespaces that have Istio services
-	var services corev1.ServiceList
-	if err := r.List(ctx, &services, client.HasLabels{"istio"}); err != nil {
+	existing, err := r.DNSBackend.List(ctx, "", map[string]string{"router.io/resource-type": "gateway-service"})
+	if err != nil {
 		return err
 	}
 
-	namespaces := make(map[string]bool)
-	for _, svc := range services.Items {
-		namespaces[svc.Namespace] = true
-	}
+	for _, set := range existing {
+		config := gatewayControllerConfig{
+			controller:    set.Labels["router.io/istio-controller"],
+			targetPostfix: set.Labels["router.io/target-postfix"],
+		}
 
-	for ns := range namespaces {
-		var endpoints externaldnsv1alpha1.DNSEndpointList
-		if err := r.List(ctx, &endpoints, client.InNamespace(ns), client.MatchingLabels{"router.io/resource-type": "gateway-service"}); err != nil {
-			// Continue to next namespace if list fails
-			continue
+		if !activeConfigs[config] {
+			// This endpoint is no longer used by any gateway
+			if err := r.DNSBackend.Delete(ctx, set); err != nil {
+				return err
+			}
 		}
+	}
 
-		for _, ep := range endpoints.Items {
-			controller := ep.Labels["router.io/istio-controller"]
-			postfix := ep.Labels["router.io/target-postfix"]
+	return nil
+}
 
-			config := gatewayControllerConfig{
-				controller:    controller,
-				targetPostfix: postfix,
-			}
+// cleanupOrphanedGatewayAPIDNSEndpoints removes DNSEndpoints of Gateways that no longer
+// use the GatewayAPI implementation or no longer exist
+func (r *IngressDNSReconciler) cleanupOrphanedGatewayAPIDNSEndpoints(
+	ctx context.Context,
+	activeGateways []routingv1alpha1.Gateway,
+) error {
+	active := make(map[types.NamespacedName]bool, len(activeGateways))
+	for _, gw := range activeGateways {
+		active[types.NamespacedName{Name: gw.Name, Namespace: gw.Namespace}] = true
+	}
 
-			if !activeConfigs[config] {
-				// This endpoint is no longer used by any gateway
-				if err := r.Delete(ctx, &ep); err != nil {
-					return client.IgnoreNotFound(err)
-				}
-			}
+	existing, err := r.DNSBackend.List(ctx, "", map[string]string{"router.io/resource-type": "gateway-api"})
+	if err != nil {
+		return err
+	}
+
+	for _, set := range existing {
+		key := types.NamespacedName{Name: set.Labels["router.io/gateway"], Namespace: set.Namespace}
+		if active[key] {
+			continue
+		}
+		if err := r.DNSBackend.Delete(ctx, set); err != nil {
+			return err
 		}
 	}
 
@@ -303,6 +506,19 @@ func (r *IngressDNSReconciler) mapServiceToRequest(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *IngressDNSReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.DNSBackend == nil {
+		r.DNSBackend = dnsbackend.NewExternalDNS(mgr.GetClient())
+	}
+	if r.Identity == nil {
+		r.Identity = clusteridentity.NewProvider(mgr.GetCache())
+	}
+	if r.Config == nil {
+		r.Config = dnsconfiguration.NewProvider(mgr.GetCache())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("ingress-dns-controller")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("ingress-dns-controller").
 		// Watch Gateways: any change might require DNS update/cleanup
@@ -310,9 +526,9 @@ func (r *IngressDNSReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// Watch Services: if LoadBalancer IP changes, we must update DNS
 		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(r.mapServiceToRequest)).
 		// Watch ClusterIdentity: domain/region changes affect all DNS
-		Watches(&clusterv1alpha1.ClusterIdentity{}, handler.EnqueueRequestsFromMapFunc(r.mapGlobalEventsToRequest)).
+		WatchesRawSource(r.Identity.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalEventsToRequest))).
 		// Watch DNSConfiguration: provider changes affect all DNS
-		Watches(&clusterv1alpha1.DNSConfiguration{}, handler.EnqueueRequestsFromMapFunc(r.mapGlobalEventsToRequest)).
+		WatchesRawSource(r.Config.Watch(handler.EnqueueRequestsFromMapFunc(r.mapGlobalEventsToRequest))).
 		Complete(r)
 }
 
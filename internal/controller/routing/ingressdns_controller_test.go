@@ -9,14 +9,45 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsexport"
 )
 
+// observeDNSEndpoints marks the DNSEndpoints matching labels as processed, the way ExternalDNS
+// reports processing them, and returns how many it marked
+func observeDNSEndpoints(ctx context.Context, namespace string, labels map[string]string) (int, error) {
+	var dnsEndpoints externaldnsv1alpha1.DNSEndpointList
+	if err := k8sClient.List(ctx, &dnsEndpoints, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return 0, err
+	}
+	for i := range dnsEndpoints.Items {
+		dnsEndpoint := &dnsEndpoints.Items[i]
+		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
+			continue
+		}
+		dnsEndpoint.Status.ObservedGeneration = dnsEndpoint.Generation
+		if err := k8sClient.Status().Update(ctx, dnsEndpoint); err != nil {
+			return 0, err
+		}
+	}
+	return len(dnsEndpoints.Items), nil
+}
+
 var _ = Describe("IngressDNS Controller", func() {
 	const (
 		timeout  = time.Second * 10
@@ -167,7 +198,7 @@ var _ = Describe("IngressDNS Controller", func() {
 				Namespace: gateway.Namespace,
 			}
 			updatedGateway := &routingv1alpha1.Gateway{}
-			Eventually(func() string {
+			dnsReady := func() string {
 				err := k8sClient.Get(ctx, gatewayLookupKey, updatedGateway)
 				if err != nil {
 					return ""
@@ -178,7 +209,16 @@ var _ = Describe("IngressDNS Controller", func() {
 					}
 				}
 				return ""
-			}, timeout, interval).Should(Equal("True"))
+			}
+
+			// DNSReady waits for ExternalDNS to process the A records
+			Eventually(dnsReady, timeout, interval).Should(Equal("False"))
+			Consistently(dnsReady, time.Second, interval).Should(Equal("False"))
+
+			Expect(observeDNSEndpoints(ctx, serviceNamespace, map[string]string{
+				"router.io/istio-controller": controllerName,
+			})).Should(Equal(1))
+			Eventually(dnsReady, timeout, interval).Should(Equal("True"))
 
 			Expect(k8sClient.Delete(ctx, gateway)).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, service)).Should(Succeed())
@@ -254,5 +294,321 @@ var _ = Describe("IngressDNS Controller", func() {
 				return apierrors.IsNotFound(err)
 			}, timeout, interval).Should(BeTrue(), "DNSEndpoint should be deleted when Gateway is removed")
 		})
+
+		It("should export the gateway A records", func() {
+			controllerName := "test-controller-export"
+			serviceNamespace := "export-ns"
+
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: serviceNamespace}}
+			_ = k8sClient.Create(ctx, ns)
+			defer func() { _ = k8sClient.Delete(ctx, ns) }()
+
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "istio-ingressgateway-export",
+					Namespace: serviceNamespace,
+					Labels: map[string]string{
+						"istio": controllerName,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{{Port: 80}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, service)).Should(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, service) }()
+
+			gateway := &routingv1alpha1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gateway-export",
+					Namespace: "default",
+				},
+				Spec: routingv1alpha1.GatewaySpec{
+					Controller:     controllerName,
+					CredentialName: "wildcard-cert",
+					TargetPostfix:  "export",
+				},
+			}
+			Expect(k8sClient.Create(ctx, gateway)).Should(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, gateway) }()
+
+			exporter := &RecordExporter{
+				Client:                        k8sClient,
+				DefaultRouterGatewayNamespace: "default",
+				Identity:                      clusteridentity.NewReaderProvider(k8sClient),
+				Config:                        dnsconfiguration.NewReaderProvider(k8sClient),
+			}
+			source := fmt.Sprintf("Gateway controller %s (export)", controllerName)
+
+			// Without an address the gateway is reported as skipped
+			Eventually(func() []dnsexport.Skipped {
+				export, err := exporter.Export(ctx)
+				if err != nil {
+					return nil
+				}
+				return export.Skipped
+			}, timeout, interval).Should(ContainElement(HaveField("Source", source)))
+
+			service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{
+				{IP: "40.40.40.40"},
+			}
+			Expect(k8sClient.Status().Update(ctx, service)).Should(Succeed())
+
+			Eventually(func() []dnsexport.Record {
+				export, err := exporter.Export(ctx)
+				if err != nil {
+					return nil
+				}
+				return export.Records
+			}, timeout, interval).Should(ContainElement(dnsexport.Record{
+				Name:       "aks-neu-export.example.com",
+				Type:       "A",
+				TTL:        300,
+				Targets:    []string{"40.40.40.40"},
+				Controller: "external-dns-private",
+				Region:     "neu",
+				RecordSet:  fmt.Sprintf("%s/gateway-controller-%s-export-external-dns-private", serviceNamespace, controllerName),
+				Source:     source,
+			}))
+		})
+	})
+})
+
+var _ = Describe("ExternalDNS controller settings", func() {
+	var (
+		identity *clusteridentity.ClusterIdentity
+		config   *dnsconfiguration.DNSConfiguration
+		svc      *corev1.Service
+	)
+
+	BeforeEach(func() {
+		identity = &clusteridentity.ClusterIdentity{
+			Region:            "neu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		}
+		config = &dnsconfiguration.DNSConfiguration{
+			ExternalDNSControllers: []dnsconfiguration.ExternalDNSController{
+				{Name: "external-dns-neu", Region: "neu"},
+				{
+					Name:          "external-dns-public",
+					Region:        "neu",
+					Annotation:    "public",
+					DomainFilters: []string{"public.example.com"},
+				},
+				{
+					Name:        "external-dns-private",
+					Region:      "neu",
+					Annotation:  "private",
+					RecordTypes: []string{"A"},
+					TTL:         60,
+				},
+			},
+		}
+		svc = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system"}}
+	})
+
+	It("should annotate gateway records per controller and honour its filters and TTL", func() {
+		recordSets, err := gatewayServiceRecordSets("aks-istio", "internal", svc, "10.0.0.1", identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets).To(HaveLen(2))
+
+		Expect(recordSets[0].Labels["router.io/controller"]).To(Equal("external-dns-neu"))
+		Expect(recordSets[0].Annotations).To(HaveKeyWithValue("external-dns.alpha.kubernetes.io/controller", "external-dns-neu"))
+		Expect(recordSets[0].Records[0].TTL).To(Equal(int64(300)))
+
+		Expect(recordSets[1].Labels["router.io/controller"]).To(Equal("external-dns-private"))
+		Expect(recordSets[1].Annotations).To(HaveKeyWithValue("external-dns.alpha.kubernetes.io/controller", "private"))
+		Expect(recordSets[1].Records[0].TTL).To(Equal(int64(60)))
+	})
+
+	It("should publish ServiceRoute records only to the controllers managing the source host", func() {
+		r := &ServiceRouteReconciler{}
+		serviceRoute := &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				Environment: "dev",
+				Application: "shop",
+			},
+		}
+		dnsPolicy := &routingv1alpha1.DNSPolicy{
+			Status: routingv1alpha1.DNSPolicyStatus{
+				ActiveControllers: []string{"external-dns-neu", "external-dns-public", "external-dns-private"},
+			},
+		}
+		gateway := &routingv1alpha1.Gateway{Spec: routingv1alpha1.GatewaySpec{TargetPostfix: "internal"}}
+
+		recordSets, err := r.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets).To(HaveLen(1))
+		Expect(recordSets[0].Annotations).To(HaveKeyWithValue("external-dns.alpha.kubernetes.io/controller", "external-dns-neu"))
+
+		dnsPolicy.Status.ActiveControllers = []string{"external-dns-public", "external-dns-private"}
+		_, err = r.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, nil, config)
+		Expect(err).To(MatchError(ContainSubstring("none of the active controllers")))
+	})
+
+	It("should resolve record TTLs from the ServiceRoute, DNSPolicy and DNSConfiguration", func() {
+		r := &ServiceRouteReconciler{}
+		serviceRoute := &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				Environment: "dev",
+				Application: "shop",
+			},
+		}
+		dnsPolicy := &routingv1alpha1.DNSPolicy{
+			Status: routingv1alpha1.DNSPolicyStatus{ActiveControllers: []string{"external-dns-neu"}},
+		}
+		gateway := &routingv1alpha1.Gateway{Spec: routingv1alpha1.GatewaySpec{TargetPostfix: "internal"}}
+		serviceTTL := func() int64 {
+			recordSets, err := r.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, nil, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recordSets).To(HaveLen(1))
+			return recordSets[0].Records[0].TTL
+		}
+
+		// Without any TTL the DNS provider default applies
+		Expect(serviceTTL()).To(BeZero())
+
+		config.ServiceTTL = 600
+		config.GatewayTTL = 900
+		Expect(serviceTTL()).To(Equal(int64(600)))
+
+		policyTTL, routeTTL := int64(120), int64(60)
+		dnsPolicy.Spec.TTL = &policyTTL
+		Expect(serviceTTL()).To(Equal(int64(120)))
+		serviceRoute.Spec.TTL = &routeTTL
+		Expect(serviceTTL()).To(Equal(int64(60)))
+
+		recordSets, err := gatewayServiceRecordSets("aks-istio", "internal", svc, "10.0.0.1", identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets[0].Records[0].TTL).To(Equal(int64(900)))
+
+		// Pre-failover mode caps every TTL
+		config.PreFailoverTTL = 30
+		Expect(serviceTTL()).To(Equal(int64(30)))
+		recordSets, err = gatewayServiceRecordSets("aks-istio", "internal", svc, "10.0.0.1", identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets[0].Records[0].TTL).To(Equal(int64(30)))
+		Expect(recordSets[1].Records[0].TTL).To(Equal(int64(30)))
+	})
+})
+
+// failingBackend fails to publish record sets while fail is set
+type failingBackend struct {
+	dnsbackend.DNSBackend
+	fail bool
+}
+
+func (b *failingBackend) Create(ctx context.Context, desired *dnsbackend.RecordSet) error {
+	if b.fail {
+		return fmt.Errorf("backend unavailable")
+	}
+	return b.DNSBackend.Create(ctx, desired)
+}
+
+var _ = Describe("IngressDNS error reporting", func() {
+	var (
+		ctx        context.Context
+		c          client.Client
+		backend    *failingBackend
+		recorder   *events.FakeRecorder
+		reconciler *IngressDNSReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(clusterv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		c = fake.NewClientBuilder().
+			WithScheme(testScheme).
+			WithObjects(
+				&clusterv1alpha1.ClusterIdentity{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+					Spec: clusterv1alpha1.ClusterIdentitySpec{
+						Region: "neu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d",
+					},
+				},
+				&clusterv1alpha1.DNSConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "dns-config"},
+					Spec: clusterv1alpha1.DNSConfigurationSpec{
+						ExternalDNSControllers: []clusterv1alpha1.ExternalDNSController{
+							{Name: "external-dns-neu", Region: "neu"},
+						},
+					},
+				},
+				&routingv1alpha1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"},
+					Spec: routingv1alpha1.GatewaySpec{
+						Controller:     "aks-istio",
+						TargetPostfix:  "internal",
+						CredentialName: "cert",
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "istio-ingressgateway",
+						Namespace: "istio-system",
+						Labels:    map[string]string{"istio": "aks-istio"},
+					},
+					Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+					Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+					}},
+				},
+			).
+			WithStatusSubresource(&routingv1alpha1.Gateway{}).
+			Build()
+
+		backend = &failingBackend{DNSBackend: dnsbackend.NewExternalDNS(c), fail: true}
+		recorder = events.NewFakeRecorder(10)
+		reconciler = &IngressDNSReconciler{
+			Client:     c,
+			DNSBackend: backend,
+			Identity:   clusteridentity.NewReaderProvider(c),
+			Config:     dnsconfiguration.NewReaderProvider(c),
+			Recorder:   recorder,
+		}
+	})
+
+	infrastructureDNSReady := func() *metav1.Condition {
+		var gateway routingv1alpha1.Gateway
+		Expect(c.Get(ctx, types.NamespacedName{Name: "default-gateway", Namespace: "istio-system"}, &gateway)).To(Succeed())
+		return meta.FindStatusCondition(gateway.Status.Conditions, "InfrastructureDNSReady")
+	}
+
+	It("should return the failure and report it on the Gateway until the records are published", func() {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "global"}})
+		Expect(err).To(MatchError(ContainSubstring("backend unavailable")))
+
+		condition := infrastructureDNSReady()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("DNSEndpointGenerationFailed"))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning DNSEndpointGenerationFailed")))
+
+		// A repeated failure leaves the condition unchanged and records no further event
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "global"}})
+		Expect(err).To(MatchError(ContainSubstring("backend unavailable")))
+		Expect(recorder.Events).NotTo(Receive())
+
+		backend.fail = false
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "global"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		condition = infrastructureDNSReady()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("ARecordsPublished"))
+		Expect(condition.Message).To(Equal("A records published to external-dns-neu"))
+		Expect(recorder.Events).To(Receive(Equal("Normal ARecordsPublished A records published to external-dns-neu")))
 	})
 })
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// gatewayDNSResult is the outcome of publishing the A records of a gateway target host
+type gatewayDNSResult struct {
+	// controllers are the ExternalDNS controllers the records are published to
+	controllers []string
+	// reason and message explain why no record is published when there are no controllers
+	reason  string
+	message string
+	// err is the failure of the last attempt
+	err error
+}
+
+// publishedResult returns the result of publishing the given record sets
+func publishedResult(recordSets []*dnsbackend.RecordSet) gatewayDNSResult {
+	if len(recordSets) == 0 {
+		return gatewayDNSResult{
+			reason:  consts.ReasonNoExternalDNSController,
+			message: "No ExternalDNS controller manages the target hostname",
+		}
+	}
+
+	controllers := make([]string, 0, len(recordSets))
+	for _, set := range recordSets {
+		controllers = append(controllers, set.Labels["router.io/controller"])
+	}
+	sort.Strings(controllers)
+	return gatewayDNSResult{controllers: controllers}
+}
+
+// infrastructureDNSCondition converts a result into the InfrastructureDNSReady condition of a Gateway
+func infrastructureDNSCondition(gateway *routingv1alpha1.Gateway, result gatewayDNSResult) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               consts.ConditionTypeInfrastructureDNSReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gateway.Generation,
+	}
+
+	switch {
+	case result.err != nil:
+		condition.Reason = consts.ReasonDNSEndpointGenerationFailed
+		condition.Message = result.err.Error()
+	case len(result.controllers) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = consts.ReasonARecordsPublished
+		condition.Message = "A records published to " + strings.Join(result.controllers, ", ")
+	default:
+		condition.Reason = result.reason
+		condition.Message = result.message
+	}
+	return condition
+}
+
+// reportInfrastructureDNS writes the result into the InfrastructureDNSReady condition of a Gateway.
+// Events are only recorded when the condition transitions: a Warning event when publishing starts
+// failing, a Normal event when the records become published. The status is only patched when the
+// condition changes.
+func (r *IngressDNSReconciler) reportInfrastructureDNS(
+	ctx context.Context,
+	gateway *routingv1alpha1.Gateway,
+	result gatewayDNSResult,
+) error {
+	condition := infrastructureDNSCondition(gateway, result)
+
+	previous := meta.FindStatusCondition(gateway.Status.Conditions, condition.Type)
+	transitioned := previous == nil || previous.Status != condition.Status || previous.Reason != condition.Reason
+	becameReady := condition.Status == metav1.ConditionTrue &&
+		(transitioned || previous.Message != condition.Message)
+
+	patch := client.MergeFromWithOptions(gateway.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if !meta.SetStatusCondition(&gateway.Status.Conditions, condition) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, gateway, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("Gateway").Inc()
+		}
+		return fmt.Errorf("failed to update status of Gateway %s/%s: %w", gateway.Namespace, gateway.Name, err)
+	}
+
+	switch {
+	case result.err != nil && transitioned:
+		r.recordEvent(gateway, corev1.EventTypeWarning, consts.ReasonDNSEndpointGenerationFailed,
+			"Failed to publish the A records: %s", result.err.Error())
+	case becameReady:
+		r.recordEvent(gateway, corev1.EventTypeNormal, consts.ReasonARecordsPublished, "%s", condition.Message)
+	}
+	return nil
+}
+
+// reportAllInfrastructureDNS writes the same result into every Gateway not being deleted
+func (r *IngressDNSReconciler) reportAllInfrastructureDNS(
+	ctx context.Context,
+	gateways []routingv1alpha1.Gateway,
+	result gatewayDNSResult,
+) []error {
+	var errs []error
+	for i := range gateways {
+		if gateways[i].DeletionTimestamp != nil {
+			continue
+		}
+		if err := r.reportInfrastructureDNS(ctx, &gateways[i], result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// recordEvent records an event on a Gateway when a recorder is configured
+func (r *IngressDNSReconciler) recordEvent(gateway *routingv1alpha1.Gateway, eventType, reason, note string, args ...any) {
+	recorder.Eventf(r.Recorder, gateway, eventType, reason, "PublishInfrastructureDNS", note, args...)
+}
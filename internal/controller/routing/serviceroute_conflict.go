@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// hostnameConflict describes a hostname that is owned by another ServiceRoute
+type hostnameConflict struct {
+	host  string
+	owner types.NamespacedName
+}
+
+// findHostnameConflict returns the first hostname of the ServiceRoute that is owned by
+// another ServiceRoute, or nil if the ServiceRoute owns all of its hostnames.
+func (r *ServiceRouteReconciler) findHostnameConflict(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+) (*hostnameConflict, error) {
+	hosts, err := r.collectHosts(serviceRoute, clusterIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes); err != nil {
+		return nil, err
+	}
+
+	for i := range serviceRoutes.Items {
+		other := &serviceRoutes.Items[i]
+		if other.UID == serviceRoute.UID || other.DeletionTimestamp != nil {
+			continue
+		}
+
+		host, shared := r.sharedHost(other, clusterIdentity, hosts)
+		if shared && ownsHostname(other, serviceRoute) {
+			return &hostnameConflict{
+				host:  host,
+				owner: types.NamespacedName{Name: other.Name, Namespace: other.Namespace},
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ownsHostname reports whether a wins a hostname shared with b.
+// An explicit claim wins first, then the oldest creationTimestamp,
+// and finally namespace/name order so the outcome is always deterministic.
+// A claim only counts between ServiceRoutes in the same namespace, so one namespace
+// cannot take over the hostnames of another when the admission webhooks are disabled.
+func ownsHostname(a, b *routingv1alpha1.ServiceRoute) bool {
+	if a.Namespace == b.Namespace {
+		aClaim := a.Annotations[consts.AnnotationHostnameClaim] == "true"
+		bClaim := b.Annotations[consts.AnnotationHostnameClaim] == "true"
+		if aClaim != bClaim {
+			return aClaim
+		}
+	}
+
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	return fmt.Sprintf("%s/%s", a.Namespace, a.Name) < fmt.Sprintf("%s/%s", b.Namespace, b.Name)
+}
+
+// containsHost reports whether hosts contains host
+func containsHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// mapServiceRouteToConflictingRoutes returns the other ServiceRoutes sharing a hostname with
+// the given ServiceRoute, so a losing route takes over once the owner is changed or deleted.
+// Routes currently failing with a hostname conflict are always returned, because an update
+// event only carries the new hostnames and the route may have conflicted on the old ones.
+func (r *ServiceRouteReconciler) mapServiceRouteToConflictingRoutes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	serviceRoute := obj.(*routingv1alpha1.ServiceRoute)
+
+	clusterIdentity, err := r.Identity.Identity(ctx)
+	if err != nil || clusterIdentity == nil {
+		return nil
+	}
+
+	hosts, err := r.collectHosts(serviceRoute, clusterIdentity)
+	if err != nil {
+		return nil
+	}
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range serviceRoutes.Items {
+		other := &serviceRoutes.Items[i]
+		if other.UID == serviceRoute.UID {
+			continue
+		}
+
+		if _, shared := r.sharedHost(other, clusterIdentity, hosts); shared || hasHostnameConflict(other) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      other.Name,
+					Namespace: other.Namespace,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// hasHostnameConflict reports whether the ServiceRoute is currently failing on a hostname conflict
+func hasHostnameConflict(serviceRoute *routingv1alpha1.ServiceRoute) bool {
+	condition := meta.FindStatusCondition(serviceRoute.Status.Conditions, consts.ConditionTypeReady)
+	return condition != nil && condition.Reason == consts.ReasonHostnameConflict
+}
+
+// sharedHost returns the first hostname of the ServiceRoute that is also in hosts.
+// Routes that cannot render hostnames never publish, so they share none.
+func (r *ServiceRouteReconciler) sharedHost(
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	hosts []string,
+) (string, bool) {
+	routeHosts, err := r.collectHosts(serviceRoute, clusterIdentity)
+	if err != nil {
+		return "", false
+	}
+	for _, host := range routeHosts {
+		if containsHost(hosts, host) {
+			return host, true
+		}
+	}
+	return "", false
+}
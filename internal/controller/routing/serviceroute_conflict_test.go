@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+var _ = Describe("Hostname claims without admission webhooks", func() {
+	var (
+		ctx      context.Context
+		identity *clusteridentity.ClusterIdentity
+	)
+
+	newRoute := func(namespace, name string, created time.Time) *routingv1alpha1.ServiceRoute {
+		return &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				UID:               types.UID(namespace + "-" + name),
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				GatewayName: "default-gateway",
+				Environment: "dev",
+				Application: "shop",
+				Aliases:     []string{"shop.example.com."},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		identity = &clusteridentity.ClusterIdentity{Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d"}
+	})
+
+	It("should not let a claim take over a hostname of another namespace", func() {
+		now := time.Now()
+		owner := newRoute("team-a", "api", now.Add(-time.Hour))
+		claiming := newRoute("team-b", "web", now)
+		claiming.Spec.ServiceName = "web"
+		claiming.Annotations = map[string]string{consts.AnnotationHostnameClaim: "true"}
+
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		reconciler := &ServiceRouteReconciler{
+			Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(owner, claiming).Build(),
+		}
+
+		conflict, err := reconciler.findHostnameConflict(ctx, claiming, identity)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflict).NotTo(BeNil())
+		Expect(conflict.host).To(Equal("shop.example.com"))
+		Expect(conflict.owner).To(Equal(types.NamespacedName{Name: "api", Namespace: "team-a"}))
+
+		conflict, err = reconciler.findHostnameConflict(ctx, owner, identity)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conflict).To(BeNil())
+	})
+
+	It("should let a claim win within its namespace", func() {
+		now := time.Now()
+		older := newRoute("team-a", "api", now.Add(-time.Hour))
+		claiming := newRoute("team-a", "web", now)
+		claiming.Spec.ServiceName = "web"
+		claiming.Annotations = map[string]string{consts.AnnotationHostnameClaim: "true"}
+
+		Expect(ownsHostname(claiming, older)).To(BeTrue())
+		Expect(ownsHostname(older, claiming)).To(BeFalse())
+	})
+})
@@ -19,25 +19,39 @@ package routing
 import (
 	"context"
 	"fmt"
-	"reflect"
-
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
-	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
 
-	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
 	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
 	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/hostname"
+	"github.com/AshwinSarimin/service-router-operator/internal/metrics"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+	"github.com/AshwinSarimin/service-router-operator/internal/recorder"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
 	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
@@ -46,15 +60,35 @@ type ServiceRouteReconciler struct {
 	client.Client
 	Scheme                        *runtime.Scheme
 	DefaultRouterGatewayNamespace string
+	// GatewayAPIEnabled allows backends to be routed with Gateway API HTTPRoutes
+	GatewayAPIEnabled bool
+	// DNSBackend publishes the generated records, defaults to ExternalDNS DNSEndpoints
+	DNSBackend dnsbackend.DNSBackend
+	// Identity provides the cluster identity, defaults to the manager's informer cache
+	Identity clusteridentity.IdentityProvider
+	// Config provides the DNS configuration, defaults to the manager's informer cache
+	Config dnsconfiguration.ConfigProvider
+	// Ownership looks up the ownership records of other clusters before a hostname is published
+	// in the zone of another region, nil publishes without checking for conflicting claims
+	Ownership ownership.Resolver
+	// Recorder records phase changes and published DNSEndpoint changes as events
+	Recorder events.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=routing.router.io,resources=serviceroutes/finalizers,verbs=update
 //+kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=routing.router.io,resources=dnspolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=routing.router.io,resources=clusterdnspolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups=routing.router.io,resources=gateways,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.router.io,resources=clusteridentities,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -70,7 +104,8 @@ func (r *ServiceRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.Get(ctx, req.NamespacedName, &serviceRoute); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("ServiceRoute deleted", "name", req.Name, "namespace", req.Namespace, "action", "cleaning up DNSEndpoints")
-			if err := r.deleteDNSEndpointsForServiceRoute(ctx, req.NamespacedName); err != nil {
+			metrics.DNSReady.Forget("ServiceRoute", req.NamespacedName.String())
+			if _, err := r.deleteDNSEndpointsForServiceRoute(ctx, req.NamespacedName); err != nil {
 				logger.Error(err, "failed to delete DNSEndpoints for deleted ServiceRoute")
 				return ctrl.Result{}, err
 			}
@@ -80,198 +115,366 @@ func (r *ServiceRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
-	// Validate to ensure we have a complete specification before attempting generation.
-	if err := r.validateServiceRoute(&serviceRoute); err != nil {
-		logger.Error(err, "validation failed")
-		return r.updateStatusFailed(ctx, &serviceRoute, consts.ReasonValidationFailed, err.Error())
+	// Compute the records the ServiceRoute publishes, or the step that blocks it.
+	desired, err := r.desiredRecordSets(ctx, &serviceRoute)
+	if err != nil {
+		logger.Error(err, "failed to compute DNSEndpoints")
+		return ctrl.Result{}, err
+	}
+	if desired.dnsPolicy != nil {
+		serviceRoute.Status.DNSPolicyRef = dnsPolicyReference(desired.dnsPolicy)
+	}
+	if desired.backendChecked {
+		setBackendReadyCondition(&serviceRoute, desired.health)
 	}
+	if desired.blocked != nil {
+		return r.reconcileBlocked(ctx, &serviceRoute, desired)
+	}
+	claims := desired.claims
+	recordSets := claims.recordSets
+	gateway := desired.gateway
 
-	// Fetch the DNSPolicy for the namespace to determine the routing strategy.
-	dnsPolicy, err := r.getDNSPolicyForNamespace(ctx, serviceRoute.Namespace)
+	// Publish the record sets through the DNS backend.
+	// This ensures that the published records match what we calculated.
+	if err := r.reconcileRecordSets(ctx, &serviceRoute, recordSets); err != nil {
+		logger.Error(err, "failed to reconcile DNSEndpoints")
+		return ctrl.Result{}, err
+	}
+
+	// The records are only resolvable once the DNS provider has processed them
+	states, err := r.observeRecordSets(ctx, &serviceRoute, recordSets)
 	if err != nil {
-		logger.Error(err, "failed to get DNSPolicy")
+		logger.Error(err, "failed to observe DNSEndpoints")
 		return ctrl.Result{}, err
 	}
-	if dnsPolicy == nil {
-		logger.Info("DNSPolicy not found, requeueing", "namespace", serviceRoute.Namespace)
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonDNSPolicyNotFound,
-			"Waiting for DNSPolicy to be configured in namespace")
+
+	if len(recordSets) == 0 && len(claims.conflicts) > 0 {
+		clearDNSStatus(&serviceRoute)
+		setServiceRouteTakeoverConflict(&serviceRoute, claims)
+		result, err := r.updateStatusFailed(ctx, &serviceRoute, consts.ReasonClaimedByOtherCluster,
+			takeoverConflictMessage(claims.conflicts))
+		if err == nil && !result.Requeue {
+			result.RequeueAfter = ownershipRecheckInterval
+		}
+		return result, err
 	}
+	setServiceRouteTakeoverConflict(&serviceRoute, claims)
+	setServiceRouteDNSReady(&serviceRoute, states)
 
-	// If the associated policy is inactive (e.g., wrong region), delete any existing endpoints.
-	if !dnsPolicy.Status.Active {
-		logger.Info("DNSPolicy is not active, cleaning up DNSEndpoints", "namespace", serviceRoute.Namespace)
+	// Route the hostnames to the backend Service when one is configured,
+	// otherwise remove any VirtualService or HTTPRoute generated earlier.
+	if result, err := r.reconcileBackendRoute(ctx, &serviceRoute, gateway, desired.clusterIdentity, desired.previousIdentities); result != nil || err != nil {
+		return *result, err
+	}
 
+	// Reflect the successful reconciliation in the status.
+	result, err := r.updateStatusActive(ctx, &serviceRoute, gateway, recordSets)
+	if err == nil && !result.Requeue && claims.checked {
+		// Claims of other clusters are only visible in DNS, there is no event when one appears
+		result.RequeueAfter = ownershipRecheckInterval
+	}
+	if err == nil && !result.Requeue && !meta.IsStatusConditionTrue(serviceRoute.Status.Conditions, consts.ConditionTypeDNSReady) {
+		result.RequeueAfter = dnsReadyRecheckInterval
+	}
+	return result, err
+}
+
+// reconcileBlocked withdraws what a blocked ServiceRoute must no longer publish and reports
+// the blocking step in the status
+func (r *ServiceRouteReconciler) reconcileBlocked(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	desired *desiredRoute,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	blocked := desired.blocked
+	message := blocked.message
+
+	switch blocked.reason {
+	case consts.ReasonDNSPolicyNotFound:
+		logger.Info("DNSPolicy not found, requeueing", "namespace", serviceRoute.Namespace)
+		serviceRoute.Status.DNSPolicyRef = nil
+
+	case consts.ReasonDNSPolicyInactive:
 		// Delete DNSEndpoints to prevent race conditions with external-dns controllers
-		if err := r.deleteDNSEndpointsForServiceRoute(ctx, types.NamespacedName{
-			Name:      serviceRoute.Name,
-			Namespace: serviceRoute.Namespace,
-		}); err != nil {
+		logger.Info("DNSPolicy is not active, cleaning up DNSEndpoints", "namespace", serviceRoute.Namespace)
+		if err := r.withdrawDNSEndpoints(ctx, serviceRoute); err != nil {
 			logger.Error(err, "failed to delete DNSEndpoints for inactive DNSPolicy")
 			return ctrl.Result{}, err
 		}
+		clearDNSStatus(serviceRoute)
+		message += " DNSEndpoints have been removed to prevent conflicts."
+
+	case consts.ReasonHostnameConflict:
+		logger.Info("Hostname is owned by another ServiceRoute", "message", message)
+		if err := r.withdrawServiceRoute(ctx, serviceRoute); err != nil {
+			logger.Error(err, "failed to withdraw resources for conflicting ServiceRoute")
+			return ctrl.Result{}, err
+		}
+
+	case consts.ReasonBackendNotReady:
+		// The backend route is still reconciled, so traffic flows as soon as DNS is published.
+		logger.Info("Backend not ready, withholding DNSEndpoints", "reason", desired.health.reason)
+		if err := r.withdrawDNSEndpoints(ctx, serviceRoute); err != nil {
+			logger.Error(err, "failed to delete DNSEndpoints for unhealthy backend")
+			return ctrl.Result{}, err
+		}
+		clearDNSStatus(serviceRoute)
 
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonDNSPolicyInactive,
-			"DNSPolicy is not active for this cluster (sourceRegion/sourceCluster mismatch). DNSEndpoints have been removed to prevent conflicts.")
+		if result, err := r.reconcileBackendRoute(ctx, serviceRoute, desired.gateway,
+			desired.clusterIdentity, desired.previousIdentities); result != nil || err != nil {
+			return *result, err
+		}
 	}
 
-	// Need DNSConfiguration to map controller names to regions.
-	// We use the cached configuration with CRD fallback to ensure availability.
-	dnsConfig, err := dnsconfiguration.Fetch(ctx, r.Client)
-	if err != nil {
-		logger.Error(err, "failed to get DNSConfiguration")
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonDNSConfigurationNotAvailable,
-			"Waiting for DNSConfiguration to be configured")
-	}
-	if dnsConfig == nil {
-		logger.Info("DNSConfiguration not available, requeueing")
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonDNSConfigurationNotAvailable,
-			"Waiting for DNSConfiguration to be configured")
-	}
-
-	// Fetch the Gateway to determine the target host and postfix.
-	var gateway routingv1alpha1.Gateway
-	gatewayNamespace := serviceRoute.Spec.GatewayNamespace
-	if gatewayNamespace == "" {
-		gatewayNamespace = r.DefaultRouterGatewayNamespace
-	}
-	if err := r.Get(ctx, client.ObjectKey{
-		Name:      serviceRoute.Spec.GatewayName,
-		Namespace: gatewayNamespace,
-	}, &gateway); err != nil {
-		if apierrors.IsNotFound(err) {
-			return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonGatewayNotFound,
-				fmt.Sprintf("Gateway %s not found in namespace %s", serviceRoute.Spec.GatewayName, gatewayNamespace))
+	if blocked.phase == consts.PhaseFailed {
+		return r.updateStatusFailed(ctx, serviceRoute, blocked.reason, message)
+	}
+	return r.updateStatusPending(ctx, serviceRoute, blocked.reason, message)
+}
+
+// reconcileBackendRoute generates the VirtualService or HTTPRoute for the ServiceRoute backend,
+// depending on the implementation of the referenced Gateway. A non-nil result means the
+// reconciliation must stop and return it.
+func (r *ServiceRouteReconciler) reconcileBackendRoute(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	gateway *routingv1alpha1.Gateway,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
+) (*ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	serviceRoute.Status.VirtualService = ""
+	serviceRoute.Status.HTTPRoute = ""
+
+	if serviceRoute.Spec.Backend == nil {
+		if err := r.deleteVirtualService(ctx, serviceRoute); err != nil {
+			logger.Error(err, "failed to delete VirtualService")
+			return &ctrl.Result{}, err
 		}
-		logger.Error(err, "failed to fetch Gateway")
-		return ctrl.Result{}, err
+		if err := r.deleteHTTPRoute(ctx, serviceRoute); err != nil {
+			logger.Error(err, "failed to delete HTTPRoute")
+			return &ctrl.Result{}, err
+		}
+		return nil, nil
 	}
 
-	// ClusterIdentity provides the domain and region information for hostname generation.
-	clusterIdentity, err := clusteridentity.Fetch(ctx, r.Client)
+	hosts, err := r.collectHosts(serviceRoute, clusterIdentity)
 	if err != nil {
-		logger.Error(err, "failed to get ClusterIdentity")
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonClusterIdentityNotAvailable,
-			"Waiting for ClusterIdentity to be configured")
+		logger.Error(err, "failed to render hostnames for backend route")
+		result, err := r.updateStatusFailed(ctx, serviceRoute, consts.ReasonVirtualServiceGenerationFailed, err.Error())
+		return &result, err
 	}
-	if clusterIdentity == nil {
-		logger.Info("ClusterIdentity not available, requeueing")
-		return r.updateStatusPending(ctx, &serviceRoute, consts.ReasonClusterIdentityNotAvailable,
-			"Waiting for ClusterIdentity to be configured")
+	// Previous hostnames keep reaching the backend while they are published
+	for _, migrating := range serviceRouteMigratingHosts(serviceRoute, hosts, previousIdentities) {
+		hosts = append(hosts, migrating.hosts()...)
 	}
 
-	// Generate the desired DNSEndpoints based on the active controllers and route spec.
-	dnsEndpoints, err := r.generateDNSEndpoints(&serviceRoute, dnsPolicy, &gateway, clusterIdentity, dnsConfig)
-	if err != nil {
-		logger.Error(err, "failed to generate DNSEndpoints")
-		return r.updateStatusFailed(ctx, &serviceRoute, consts.ReasonDNSEndpointGenerationFailed, err.Error())
+	if usesGatewayAPI(gateway) {
+		if !r.GatewayAPIEnabled {
+			result, err := r.updateStatusFailed(ctx, serviceRoute, consts.ReasonHTTPRouteGenerationFailed,
+				fmt.Sprintf("Gateway %s/%s uses %s but the operator runs without --enable-gateway-api",
+					gateway.Namespace, gateway.Name, consts.ImplementationGatewayAPI))
+			return &result, err
+		}
+		if err := validation.ServiceRouteGateway(serviceRoute, gateway); err != nil {
+			result, err := r.updateStatusFailed(ctx, serviceRoute, consts.ReasonHTTPRouteGenerationFailed, err.Error())
+			return &result, err
+		}
+
+		if err := r.deleteVirtualService(ctx, serviceRoute); err != nil {
+			logger.Error(err, "failed to delete VirtualService")
+			return &ctrl.Result{}, err
+		}
+
+		httpRoute := generateHTTPRoute(serviceRoute, gateway, hosts)
+		if err := reconcileUnstructured(ctx, r.Client, serviceRoute, httpRoute); err != nil {
+			if isNotControlled(err) {
+				result, err := r.updateStatusFailed(ctx, serviceRoute, consts.ReasonNotControlled, err.Error())
+				return &result, err
+			}
+			logger.Error(err, "failed to reconcile HTTPRoute")
+			return &ctrl.Result{}, err
+		}
+		serviceRoute.Status.HTTPRoute = httpRoute.GetName()
+		return nil, nil
 	}
 
-	// Reconcile the DNSEndpoints to match the generated desired state.
-	// This ensures that the actual cluster resources match what we calculated.
-	if err := r.reconcileDNSEndpoints(ctx, &serviceRoute, dnsEndpoints); err != nil {
-		logger.Error(err, "failed to reconcile DNSEndpoints")
-		return ctrl.Result{}, err
+	if err := r.deleteHTTPRoute(ctx, serviceRoute); err != nil {
+		logger.Error(err, "failed to delete HTTPRoute")
+		return &ctrl.Result{}, err
 	}
 
-	// Reflect the successful reconciliation in the status.
-	return r.updateStatusActive(ctx, &serviceRoute, dnsEndpoints)
+	virtualService := r.generateVirtualService(serviceRoute, gateway, hosts)
+	if err := r.reconcileVirtualService(ctx, serviceRoute, virtualService); err != nil {
+		if isNotControlled(err) {
+			result, err := r.updateStatusFailed(ctx, serviceRoute, consts.ReasonNotControlled, err.Error())
+			return &result, err
+		}
+		logger.Error(err, "failed to reconcile VirtualService")
+		return &ctrl.Result{}, err
+	}
+	serviceRoute.Status.VirtualService = virtualService.Name
+	return nil, nil
 }
 
-// deleteDNSEndpointsForServiceRoute deletes DNSEndpoints created for the given ServiceRoute.
-func (r *ServiceRouteReconciler) deleteDNSEndpointsForServiceRoute(ctx context.Context, namespacedName types.NamespacedName) error {
-	logger := log.FromContext(ctx)
-
-	var list externaldnsv1alpha1.DNSEndpointList
-	if err := r.List(ctx, &list,
-		client.InNamespace(namespacedName.Namespace),
-		client.MatchingLabels{
-			"app.kubernetes.io/managed-by": "service-router-operator",
-			"router.io/serviceroute":       namespacedName.Name,
-			"router.io/source-namespace":   namespacedName.Namespace,
-		},
-	); err != nil {
+// withdrawServiceRoute removes the DNSEndpoints and backend routes generated for a ServiceRoute
+func (r *ServiceRouteReconciler) withdrawServiceRoute(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	if err := r.withdrawDNSEndpoints(ctx, serviceRoute); err != nil {
 		return err
 	}
+	if err := r.deleteVirtualService(ctx, serviceRoute); err != nil {
+		return err
+	}
+	if err := r.deleteHTTPRoute(ctx, serviceRoute); err != nil {
+		return err
+	}
+
+	clearDNSStatus(serviceRoute)
+	serviceRoute.Status.VirtualService = ""
+	serviceRoute.Status.HTTPRoute = ""
+	return nil
+}
+
+// deleteHTTPRoute deletes the HTTPRoute generated for a ServiceRoute if it exists
+func (r *ServiceRouteReconciler) deleteHTTPRoute(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	if !r.GatewayAPIEnabled {
+		return nil
+	}
 
-	deletedCount := 0
-	for i := range list.Items {
-		de := &list.Items[i]
-		if err := r.Delete(ctx, de); err != nil && !apierrors.IsNotFound(err) {
-			logger.Error(err, "failed to delete DNSEndpoint", "dnsEndpoint", de.Name)
-			return err
+	return deleteUnstructured(ctx, r.Client, gatewayAPIHTTPRouteGVK, client.ObjectKey{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	}, serviceRoute)
+}
+
+// serviceRouteRecordLabels returns the labels identifying the record sets of a ServiceRoute
+func serviceRouteRecordLabels(namespacedName types.NamespacedName) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/managed-by": "service-router-operator",
+		"router.io/serviceroute":       namespacedName.Name,
+		"router.io/source-namespace":   namespacedName.Namespace,
+	}
+}
+
+// withdrawDNSEndpoints deletes the record sets published for a ServiceRoute
+// and records an event when there were any
+func (r *ServiceRouteReconciler) withdrawDNSEndpoints(ctx context.Context, serviceRoute *routingv1alpha1.ServiceRoute) error {
+	// Withdrawn record sets are no longer waited for
+	metrics.DNSReady.Forget("ServiceRoute", serviceRoute.Namespace+"/"+serviceRoute.Name)
+
+	deleted, err := r.deleteDNSEndpointsForServiceRoute(ctx, types.NamespacedName{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	})
+	if len(deleted) > 0 {
+		recorder.Eventf(r.Recorder, serviceRoute, corev1.EventTypeNormal, consts.ReasonDNSEndpointsDeleted,
+			"WithdrawDNSEndpoints", "Deleted DNSEndpoints %s", strings.Join(deleted, ", "))
+	}
+	return err
+}
+
+// deleteDNSEndpointsForServiceRoute withdraws the record sets published for the given ServiceRoute
+// and returns the names of the deleted record sets.
+func (r *ServiceRouteReconciler) deleteDNSEndpointsForServiceRoute(
+	ctx context.Context,
+	namespacedName types.NamespacedName,
+) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	existing, err := r.DNSBackend.List(ctx, namespacedName.Namespace, serviceRouteRecordLabels(namespacedName))
+	if err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for _, set := range existing {
+		if err := r.DNSBackend.Delete(ctx, set); err != nil {
+			logger.Error(err, "failed to delete DNSEndpoint", "dnsEndpoint", set.Name)
+			return deleted, err
 		}
-		logger.Info("Deleted DNSEndpoint for ServiceRoute", "dnsEndpoint", de.Name)
-		deletedCount++
+		logger.Info("Deleted DNSEndpoint for ServiceRoute", "dnsEndpoint", set.Name)
+		deleted = append(deleted, set.Name)
 	}
 
-	if deletedCount > 0 {
+	if len(deleted) > 0 {
 		logger.Info("DNSEndpoint cleanup completed",
 			"serviceRoute", namespacedName.Name,
 			"namespace", namespacedName.Namespace,
-			"deletedCount", deletedCount)
+			"deletedCount", len(deleted))
 	} else {
 		logger.V(1).Info("No DNSEndpoints found to delete (idempotent operation)",
 			"serviceRoute", namespacedName.Name,
 			"namespace", namespacedName.Namespace)
 	}
 
-	return nil
+	return deleted, nil
 }
 
-// validateServiceRoute validates the ServiceRoute specification
-func (r *ServiceRouteReconciler) validateServiceRoute(serviceRoute *routingv1alpha1.ServiceRoute) error {
-	if serviceRoute.Spec.ServiceName == "" {
-		return fmt.Errorf("serviceName must be specified")
-	}
-	if serviceRoute.Spec.GatewayName == "" {
-		return fmt.Errorf("gatewayName must be specified")
-	}
-	if serviceRoute.Spec.Environment == "" {
-		return fmt.Errorf("environment must be specified")
-	}
-	if serviceRoute.Spec.Application == "" {
-		return fmt.Errorf("application must be specified")
+// collectHosts returns the generated hostname followed by the resolved aliases
+func (r *ServiceRouteReconciler) collectHosts(
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+) ([]string, error) {
+	sourceHost, err := hostname.SourceHost(clusterIdentity,
+		serviceRoute.Spec.ServiceName,
+		serviceRoute.Spec.Environment,
+		serviceRoute.Spec.Application,
+	)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	hosts := []string{sourceHost}
+	return append(hosts, hostname.Aliases(clusterIdentity, sourceHost, serviceRoute.Spec.Aliases)...), nil
 }
 
-// getDNSPolicyForNamespace fetches the DNSPolicy for a namespace
-func (r *ServiceRouteReconciler) getDNSPolicyForNamespace(ctx context.Context, namespace string) (*routingv1alpha1.DNSPolicy, error) {
+// getDNSPolicyForServiceRoute fetches the DNSPolicy that applies to a ServiceRoute, see selectDNSPolicy.
+// A DNSPolicy in the namespace always takes precedence; without one the ClusterDNSPolicy
+// selecting the namespace is returned as a DNSPolicy without namespace, see dnsPolicyView.
+func (r *ServiceRouteReconciler) getDNSPolicyForServiceRoute(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) (*routingv1alpha1.DNSPolicy, error) {
 	var dnsPolicies routingv1alpha1.DNSPolicyList
-	if err := r.List(ctx, &dnsPolicies, client.InNamespace(namespace)); err != nil {
+	if err := r.List(ctx, &dnsPolicies, client.InNamespace(serviceRoute.Namespace)); err != nil {
 		return nil, err
 	}
 
-	if len(dnsPolicies.Items) == 0 {
-		return nil, nil
+	if dnsPolicy := selectDNSPolicy(dnsPolicies.Items, serviceRoute.Labels); dnsPolicy != nil {
+		return dnsPolicy, nil
 	}
-
-	// Return the first DNSPolicy found (namespace-scoped singleton)
-	return &dnsPolicies.Items[0], nil
+	return getClusterDNSPolicyForNamespace(ctx, r.Client, serviceRoute.Namespace)
 }
 
-// generateDNSEndpoints generates DNSEndpoint resources based on active controllers.
+// generateRecordSets generates the record sets to publish based on active controllers.
 //
-// This function orchestrates the creation of DNSEndpoint CRDs that ExternalDNS will
-// consume to create actual DNS records in Azure Private DNS (or other DNS providers).
+// With the ExternalDNS backend every record set becomes a DNSEndpoint CRD that ExternalDNS
+// consumes to create actual DNS records in Azure Private DNS (or other DNS providers).
 //
 // The DNSPolicy controller determines which ExternalDNS controllers should be active
 // based on the DNSPolicy mode (Active or RegionBound) and the current cluster's region.
-// This function creates one DNSEndpoint per active controller.
+// This function creates one record set per active controller.
 //
-// DNSEndpoints are always created in the ServiceRoute's namespace to enable:
+// Record sets are always created in the ServiceRoute's namespace to enable:
 //   - Automatic cleanup via OwnerReferences when ServiceRoute is deleted
 //   - Namespace-based RBAC and isolation
 //   - Co-location of related resources for easier debugging
-func (r *ServiceRouteReconciler) generateDNSEndpoints(
+func (r *ServiceRouteReconciler) generateRecordSets(
 	serviceRoute *routingv1alpha1.ServiceRoute,
 	dnsPolicy *routingv1alpha1.DNSPolicy,
 	gateway *routingv1alpha1.Gateway,
 	clusterIdentity *clusteridentity.ClusterIdentity,
+	previousIdentities []*clusteridentity.ClusterIdentity,
 	dnsConfig *dnsconfiguration.DNSConfiguration,
-) ([]*externaldnsv1alpha1.DNSEndpoint, error) {
-	var dnsEndpoints []*externaldnsv1alpha1.DNSEndpoint
+) ([]*dnsbackend.RecordSet, error) {
+	var recordSets []*dnsbackend.RecordSet
 
 	activeControllers := dnsPolicy.Status.ActiveControllers
 	if len(activeControllers) == 0 {
@@ -280,28 +483,34 @@ func (r *ServiceRouteReconciler) generateDNSEndpoints(
 
 	targetNamespace := serviceRoute.Namespace
 
-	// Pattern: {serviceName}-ns-{envLetter}-{environment}-{application}.{domain}
-	sourceHost := fmt.Sprintf("%s-ns-%s-%s-%s.%s",
+	// Source and target hosts are rendered from the ClusterIdentity templates,
+	// defaulting to {serviceName}-ns-{envLetter}-{environment}-{application}.{domain}
+	// and {cluster}-{region}-{gatewayPostfix}.{domain}.
+	sourceHost, err := hostname.SourceHost(clusterIdentity,
 		serviceRoute.Spec.ServiceName,
-		clusterIdentity.EnvironmentLetter,
 		serviceRoute.Spec.Environment,
 		serviceRoute.Spec.Application,
-		clusterIdentity.Domain,
 	)
+	if err != nil {
+		return nil, err
+	}
 
-	// Pattern: {cluster}-{region}-{gatewayPostfix}.{domain}
-	targetHost := fmt.Sprintf("%s-%s-%s.%s",
-		clusterIdentity.Cluster,
-		clusterIdentity.Region,
-		gateway.Spec.TargetPostfix,
-		clusterIdentity.Domain,
-	)
+	targetHost, err := hostname.TargetHost(clusterIdentity, gateway.Spec.TargetPostfix)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aliases are published as extra CNAMEs to the same target
+	aliasHosts := hostname.Aliases(clusterIdentity, sourceHost, serviceRoute.Spec.Aliases)
 
 	controllerMap := make(map[string]dnsconfiguration.ExternalDNSController)
 	for _, controller := range dnsConfig.ExternalDNSControllers {
 		controllerMap[controller.Name] = controller
 	}
+	ttl := serviceRouteTTL(serviceRoute, dnsPolicy)
+	owner := ownerRecord(clusterIdentity, dnsPolicy)
 
+	var rejected []string
 	for _, controllerName := range activeControllers {
 		controller, exists := controllerMap[controllerName]
 		if !exists {
@@ -310,170 +519,234 @@ func (r *ServiceRouteReconciler) generateDNSEndpoints(
 			continue
 		}
 
-		dnsEndpoint := r.buildDNSEndpoint(serviceRoute, controller, targetNamespace, sourceHost, targetHost)
-		dnsEndpoints = append(dnsEndpoints, dnsEndpoint)
+		recordSet := r.buildRecordSet(serviceRoute, controller, dnsConfig.ServiceRecordTTL(controller, ttl),
+			owner, targetNamespace, sourceHost, aliasHosts, targetHost)
+		if recordSet == nil {
+			// The controller does not manage the source hostname
+			rejected = append(rejected, controller.Name)
+			continue
+		}
+		recordSets = append(recordSets, recordSet)
+	}
+
+	if len(recordSets) == 0 && len(rejected) > 0 {
+		return nil, fmt.Errorf("none of the active controllers %v manages hostname %s", rejected, sourceHost)
+	}
+
+	// During a hostname migration the previous hostnames keep pointing at the previous target host,
+	// published in record sets of their own so they are withdrawn once the migration ends
+	currentHosts := append([]string{sourceHost}, aliasHosts...)
+	for _, migrating := range serviceRouteMigratingHosts(serviceRoute, currentHosts, previousIdentities) {
+		previousTargetHost, err := hostname.TargetHost(migrating.identity, gateway.Spec.TargetPostfix)
+		if err != nil {
+			continue
+		}
+		for _, controllerName := range activeControllers {
+			controller, exists := controllerMap[controllerName]
+			if !exists {
+				continue
+			}
+			recordSet := r.buildRecordSet(serviceRoute, controller, dnsConfig.ServiceRecordTTL(controller, ttl),
+				owner, targetNamespace, migrating.sourceHost, migrating.aliases, previousTargetHost)
+			if recordSet == nil {
+				continue
+			}
+			recordSet.Name = migrationRecordSetName(recordSet.Name, migrating.sourceHost)
+			recordSet.Labels[migrationLabel] = "true"
+			recordSets = append(recordSets, recordSet)
+		}
 	}
 
-	return dnsEndpoints, nil
+	return recordSets, nil
 }
 
-// buildDNSEndpoint constructs a DNSEndpoint resource for ExternalDNS to process.
+// buildRecordSet constructs the record set published for one ExternalDNS controller.
 //
 // It sets up:
-// 1. Owner ID (TXT Record) for cross-cluster takeover (e.g., "_external-dns-owner.{sourceHost}").
-// 2. Controller Annotation ("external-dns.alpha.kubernetes.io/controller") matching the region.
-// 3. CNAME and TXT records.
-// 4. Labels for tracking and filtering.
-func (r *ServiceRouteReconciler) buildDNSEndpoint(
+// 1. Controller Annotation ("external-dns.alpha.kubernetes.io/controller") matching the region,
+// or the annotation configured for the controller.
+// 2. CNAME records, including one CNAME per alias, followed by the ownership TXT record of every
+// CNAME ("_router-owner.{host}"), restricted to the domains and record types the controller
+// manages, with the given TTL.
+// 3. Labels for tracking and filtering.
+//
+// It returns nil when the controller does not accept the source host CNAME; aliases are never
+// published without their source host.
+func (r *ServiceRouteReconciler) buildRecordSet(
 	serviceRoute *routingv1alpha1.ServiceRoute,
 	controller dnsconfiguration.ExternalDNSController,
+	ttl int64,
+	owner ownership.Record,
 	targetNamespace string,
 	sourceHost string,
+	aliasHosts []string,
 	targetHost string,
-) *externaldnsv1alpha1.DNSEndpoint {
+) *dnsbackend.RecordSet {
 
-	endpoints := []*externaldnsendpoint.Endpoint{
+	records := []dnsbackend.Record{
 		{
 			DNSName:    sourceHost,
 			RecordType: "CNAME",
-			Targets:    externaldnsendpoint.Targets{targetHost},
+			Targets:    []string{targetHost},
 		},
 	}
+	for _, alias := range aliasHosts {
+		records = append(records, dnsbackend.Record{
+			DNSName:    alias,
+			RecordType: "CNAME",
+			Targets:    []string{targetHost},
+		})
+	}
+	records = append(records, ownershipRecords(records, owner)...)
 
-	labels := map[string]string{
-		"app.kubernetes.io/managed-by": "service-router-operator",
-		"router.io/controller":         controller.Name,
-		"router.io/region":             controller.Region,
-		"router.io/serviceroute":       serviceRoute.Name,
-		"router.io/source-namespace":   serviceRoute.Namespace,
+	records = controllerRecords(controller, ttl, records)
+	if len(records) == 0 || records[0].DNSName != sourceHost {
+		return nil
 	}
 
-	dnsEndpoint := &externaldnsv1alpha1.DNSEndpoint{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "externaldns.k8s.io/v1alpha1",
-			Kind:       "DNSEndpoint",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			// Name uses controller.Name to ensure uniqueness when multiple controllers
-			// in the same region create DNSEndpoints for the same ServiceRoute
-			Name:      fmt.Sprintf("%s-%s", serviceRoute.Name, controller.Name),
-			Namespace: targetNamespace,
-			Labels:    labels,
-			Annotations: map[string]string{
-				// Controller annotation MUST match the ExternalDNS deployment's filter.
-				// Using region (not controller.Name) enables cross-cluster takeover.
-				"external-dns.alpha.kubernetes.io/controller": fmt.Sprintf("external-dns-%s", controller.Region),
-			},
-		},
-		Spec: externaldnsv1alpha1.DNSEndpointSpec{
-			Endpoints: endpoints,
-		},
+	labels := serviceRouteRecordLabels(types.NamespacedName{Name: serviceRoute.Name, Namespace: serviceRoute.Namespace})
+	labels["router.io/controller"] = controller.Name
+	labels["router.io/region"] = controller.Region
+
+	recordSet := &dnsbackend.RecordSet{
+		// Name uses controller.Name to ensure uniqueness when multiple controllers
+		// in the same region create DNSEndpoints for the same ServiceRoute
+		Name:        fmt.Sprintf("%s-%s", serviceRoute.Name, controller.Name),
+		Namespace:   targetNamespace,
+		Labels:      labels,
+		Annotations: controllerAnnotations(controller),
+		Records:     records,
 	}
 
 	// Set owner reference for automatic cleanup when ServiceRoute is deleted.
 	// Only works if DNSEndpoint is in the same namespace as the ServiceRoute.
 	if targetNamespace == serviceRoute.Namespace {
-		dnsEndpoint.OwnerReferences = []metav1.OwnerReference{
+		recordSet.OwnerReferences = []metav1.OwnerReference{
 			*metav1.NewControllerRef(serviceRoute, routingv1alpha1.GroupVersion.WithKind("ServiceRoute")),
 		}
 	}
 
-	return dnsEndpoint
+	return recordSet
 }
 
-// reconcileDNSEndpoints manages DNSEndpoint resources
-func (r *ServiceRouteReconciler) reconcileDNSEndpoints(
+// reconcileRecordSets publishes the desired record sets and withdraws stale ones
+func (r *ServiceRouteReconciler) reconcileRecordSets(
 	ctx context.Context,
 	serviceRoute *routingv1alpha1.ServiceRoute,
-	desired []*externaldnsv1alpha1.DNSEndpoint,
+	desired []*dnsbackend.RecordSet,
 ) error {
-	// Collect all namespaces where DNSEndpoints might exist
-	namespaces := make(map[string]bool)
-	for _, endpoint := range desired {
-		namespaces[endpoint.Namespace] = true
-	}
-
-	// List existing DNSEndpoints managed by this ServiceRoute across all relevant namespaces
-	var existing []*externaldnsv1alpha1.DNSEndpoint
-	for namespace := range namespaces {
-		var existingList externaldnsv1alpha1.DNSEndpointList
-
-		if err := r.List(ctx, &existingList,
-			client.InNamespace(namespace),
-			client.MatchingLabels{
-				"app.kubernetes.io/managed-by": "service-router-operator",
-				"router.io/serviceroute":       serviceRoute.Name,
-				"router.io/source-namespace":   serviceRoute.Namespace,
-			},
-		); err != nil {
-			return err
-		}
+	// Record sets are always created in the ServiceRoute namespace
+	existing, err := r.DNSBackend.List(ctx, serviceRoute.Namespace, serviceRouteRecordLabels(types.NamespacedName{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	}))
+	if err != nil {
+		return err
+	}
 
-		for i := range existingList.Items {
-			existing = append(existing, &existingList.Items[i])
+	result, err := dnsbackend.Sync(ctx, r.DNSBackend, existing, desired)
+	for _, change := range []struct {
+		reason string
+		verb   string
+		names  []string
+	}{
+		{consts.ReasonDNSEndpointsCreated, "Created", result.Created},
+		{consts.ReasonDNSEndpointsUpdated, "Updated", result.Updated},
+		{consts.ReasonDNSEndpointsDeleted, "Deleted", result.Deleted},
+	} {
+		if len(change.names) > 0 {
+			recorder.Eventf(r.Recorder, serviceRoute, corev1.EventTypeNormal, change.reason,
+				"PublishDNSEndpoints", "%s DNSEndpoints %s", change.verb, strings.Join(change.names, ", "))
 		}
 	}
+	return err
+}
 
-	// Build map of desired DNSEndpoints by namespace+name
-	desiredMap := make(map[string]*externaldnsv1alpha1.DNSEndpoint)
-	for _, endpoint := range desired {
-		key := endpoint.Namespace + "/" + endpoint.Name
-		desiredMap[key] = endpoint
-	}
-
-	// Build map of existing DNSEndpoints by namespace+name
-	existingMap := make(map[string]*externaldnsv1alpha1.DNSEndpoint)
-	for _, endpoint := range existing {
-		key := endpoint.Namespace + "/" + endpoint.Name
-		existingMap[key] = endpoint
-	}
-
-	// Create or update desired
-	for key, desiredEndpoint := range desiredMap {
-		if existingEndpoint, exists := existingMap[key]; exists {
-			// Update if different
-			if !reflect.DeepEqual(existingEndpoint.Spec, desiredEndpoint.Spec) {
-				patch := client.MergeFrom(existingEndpoint.DeepCopy())
-				existingEndpoint.Spec = desiredEndpoint.Spec
-				existingEndpoint.Labels = desiredEndpoint.Labels
-				existingEndpoint.Annotations = desiredEndpoint.Annotations
-				if err := r.Patch(ctx, existingEndpoint, patch); err != nil {
-					return err
-				}
-			}
-		} else {
-			// Create
-			if err := r.Create(ctx, desiredEndpoint); err != nil {
-				return err
-			}
-		}
+// observeRecordSets returns the processing state of the published record sets of a ServiceRoute
+// and records the time the DNS provider took to process them
+func (r *ServiceRouteReconciler) observeRecordSets(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	desired []*dnsbackend.RecordSet,
+) ([]metrics.RecordSetState, error) {
+	published, err := r.DNSBackend.List(ctx, serviceRoute.Namespace, serviceRouteRecordLabels(types.NamespacedName{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	states := recordSetStates(desired, published)
+	metrics.DNSReady.Observe("ServiceRoute", serviceRoute.Namespace+"/"+serviceRoute.Name, states)
+	return states, nil
+}
+
+// dnsEndpointStatus describes the DNS chain published by a generated record set.
+// The first CNAME is the source host, the remaining ones are its aliases.
+func dnsEndpointStatus(recordSet *dnsbackend.RecordSet) routingv1alpha1.ServiceRouteDNSEndpoint {
+	status := routingv1alpha1.ServiceRouteDNSEndpoint{
+		Name:       recordSet.Name,
+		Controller: recordSet.Labels["router.io/controller"],
+		Region:     recordSet.Labels["router.io/region"],
+		Migrating:  recordSet.Labels[migrationLabel] == "true",
 	}
 
-	// Delete stale
-	for key, existingEndpoint := range existingMap {
-		if _, desired := desiredMap[key]; !desired {
-			if err := r.Delete(ctx, existingEndpoint); err != nil {
-				return err
+	for _, record := range recordSet.Records {
+		if record.RecordType != "CNAME" {
+			continue
+		}
+		if status.SourceHost == "" {
+			status.SourceHost = record.DNSName
+			if len(record.Targets) > 0 {
+				status.TargetHost = record.Targets[0]
 			}
+			continue
 		}
+		status.Aliases = append(status.Aliases, record.DNSName)
 	}
 
-	return nil
+	return status
 }
 
-// updateStatusActive updates the ServiceRoute status to Active
+// clearDNSStatus removes the published DNS chain from the status once the DNSEndpoints are deleted.
+// Without records the ServiceRoute no longer claims its hostnames, so a takeover conflict is resolved.
+func clearDNSStatus(serviceRoute *routingv1alpha1.ServiceRoute) {
+	serviceRoute.Status.DNSEndpoint = ""
+	serviceRoute.Status.DNSEndpoints = nil
+	serviceRoute.Status.LoadBalancerIP = ""
+	meta.RemoveStatusCondition(&serviceRoute.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+	meta.RemoveStatusCondition(&serviceRoute.Status.Conditions, consts.ConditionTypeDNSReady)
+}
+
+// updateStatusActive updates the ServiceRoute status to Active.
+// The status lists every generated DNSEndpoint and the Gateway LoadBalancer IP,
+// so the full host -> target -> IP chain is visible on the ServiceRoute.
 func (r *ServiceRouteReconciler) updateStatusActive(
 	ctx context.Context,
 	serviceRoute *routingv1alpha1.ServiceRoute,
-	dnsEndpoints []*externaldnsv1alpha1.DNSEndpoint,
+	gateway *routingv1alpha1.Gateway,
+	dnsEndpoints []*dnsbackend.RecordSet,
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Store first DNSEndpoint name
+	serviceRoute.Status.DNSEndpoint = ""
 	if len(dnsEndpoints) > 0 {
 		serviceRoute.Status.DNSEndpoint = dnsEndpoints[0].Name
 	}
 
+	serviceRoute.Status.DNSEndpoints = make([]routingv1alpha1.ServiceRouteDNSEndpoint, 0, len(dnsEndpoints))
+	for _, dnsEndpoint := range dnsEndpoints {
+		serviceRoute.Status.DNSEndpoints = append(serviceRoute.Status.DNSEndpoints, dnsEndpointStatus(dnsEndpoint))
+	}
+	sort.Slice(serviceRoute.Status.DNSEndpoints, func(i, j int) bool {
+		return serviceRoute.Status.DNSEndpoints[i].Name < serviceRoute.Status.DNSEndpoints[j].Name
+	})
+
+	serviceRoute.Status.LoadBalancerIP = gateway.Status.LoadBalancerIP
+
+	previous := recorder.Ready(serviceRoute.Status.Conditions)
 	serviceRoute.Status.Phase = consts.PhaseActive
 	meta.SetStatusCondition(&serviceRoute.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
@@ -485,6 +758,7 @@ func (r *ServiceRouteReconciler) updateStatusActive(
 
 	if err := r.Status().Update(ctx, serviceRoute); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("ServiceRoute").Inc()
 			// Object has been modified, we can safely retry
 			logger.Info("ServiceRoute status update conflict (Active), will retry")
 			return ctrl.Result{Requeue: true}, nil
@@ -493,6 +767,8 @@ func (r *ServiceRouteReconciler) updateStatusActive(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, serviceRoute, corev1.EventTypeNormal, previous, serviceRoute.Status.Conditions)
+
 	logger.Info("ServiceRoute reconciled successfully", "endpointsCreated", len(dnsEndpoints))
 	return ctrl.Result{}, nil
 }
@@ -505,6 +781,7 @@ func (r *ServiceRouteReconciler) updateStatusPending(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(serviceRoute.Status.Conditions)
 	serviceRoute.Status.Phase = consts.PhasePending
 	meta.SetStatusCondition(&serviceRoute.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
@@ -516,6 +793,7 @@ func (r *ServiceRouteReconciler) updateStatusPending(
 
 	if err := r.Status().Update(ctx, serviceRoute); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("ServiceRoute").Inc()
 			logger.Info("ServiceRoute status update conflict (Pending), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -523,6 +801,8 @@ func (r *ServiceRouteReconciler) updateStatusPending(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, serviceRoute, corev1.EventTypeNormal, previous, serviceRoute.Status.Conditions)
+
 	logger.Info("ServiceRoute marked as Pending", "reason", reason, "message", message)
 	return ctrl.Result{}, nil
 }
@@ -535,6 +815,7 @@ func (r *ServiceRouteReconciler) updateStatusFailed(
 ) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	previous := recorder.Ready(serviceRoute.Status.Conditions)
 	serviceRoute.Status.Phase = consts.PhaseFailed
 	meta.SetStatusCondition(&serviceRoute.Status.Conditions, metav1.Condition{
 		Type:               consts.ConditionTypeReady,
@@ -546,6 +827,7 @@ func (r *ServiceRouteReconciler) updateStatusFailed(
 
 	if err := r.Status().Update(ctx, serviceRoute); err != nil {
 		if apierrors.IsConflict(err) {
+			metrics.StatusUpdateConflicts.WithLabelValues("ServiceRoute").Inc()
 			logger.Info("ServiceRoute status update conflict (Failed), will retry")
 			return ctrl.Result{Requeue: true}, nil
 		}
@@ -553,11 +835,57 @@ func (r *ServiceRouteReconciler) updateStatusFailed(
 		return ctrl.Result{}, err
 	}
 
+	recorder.ReadyTransition(r.Recorder, serviceRoute, corev1.EventTypeWarning, previous, serviceRoute.Status.Conditions)
+
 	logger.Info("ServiceRoute marked as Failed", "reason", reason, "message", message)
 	// Return nil to stop the reconciliation loop, as the status is correctly reported as Failed.
 	return ctrl.Result{}, nil
 }
 
+// policyStatus returns the status of a DNSPolicy or ClusterDNSPolicy, or nil for any other object
+func policyStatus(obj client.Object) *routingv1alpha1.DNSPolicyStatus {
+	switch policy := obj.(type) {
+	case *routingv1alpha1.DNSPolicy:
+		return &policy.Status
+	case *routingv1alpha1.ClusterDNSPolicy:
+		return &policy.Status
+	}
+	return nil
+}
+
+// policyRoutingChanged reports whether two policy statuses differ in what their ServiceRoutes publish:
+// the activation, the active controllers or the peer regions taken over. Probe times and counters are ignored.
+func policyRoutingChanged(oldStatus, newStatus *routingv1alpha1.DNSPolicyStatus) bool {
+	if oldStatus.Active != newStatus.Active || !slices.Equal(oldStatus.ActiveControllers, newStatus.ActiveControllers) {
+		return true
+	}
+
+	takenOver := func(status *routingv1alpha1.DNSPolicyStatus) map[string]bool {
+		regions := map[string]bool{}
+		for _, peer := range status.Peers {
+			if peer.TakenOver {
+				regions[peer.Region] = true
+			}
+		}
+		return regions
+	}
+	return !maps.Equal(takenOver(oldStatus), takenOver(newStatus))
+}
+
+// policyRoutingPredicate passes policy events that change the records of their ServiceRoutes:
+// spec changes and routing changes of the status. Status writes of the failover probes are ignored.
+func policyRoutingPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldStatus, newStatus := policyStatus(e.ObjectOld), policyStatus(e.ObjectNew)
+				return oldStatus == nil || newStatus == nil || policyRoutingChanged(oldStatus, newStatus)
+			},
+		},
+	)
+}
+
 // mapDNSPolicyToServiceRoutes returns ServiceRoutes for a DNSPolicy
 func (r *ServiceRouteReconciler) mapDNSPolicyToServiceRoutes(
 	ctx context.Context,
@@ -584,6 +912,75 @@ func (r *ServiceRouteReconciler) mapDNSPolicyToServiceRoutes(
 	return requests
 }
 
+// mapClusterDNSPolicyToServiceRoutes returns the ServiceRoutes in the namespaces a ClusterDNSPolicy selects,
+// and the ServiceRoutes it applied to before, so routes follow a selector change or the deletion of the policy
+func (r *ServiceRouteReconciler) mapClusterDNSPolicyToServiceRoutes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	policy := obj.(*routingv1alpha1.ClusterDNSPolicy)
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes); err != nil {
+		return nil
+	}
+
+	// A policy without a selector applies to every namespace, one with an invalid selector to none
+	selectAll := policy.Spec.NamespaceSelector == nil
+	selected := map[string]bool{}
+	if !selectAll {
+		if selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err == nil {
+			var namespaces corev1.NamespaceList
+			if err := r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil
+			}
+			for _, ns := range namespaces.Items {
+				selected[ns.Name] = true
+			}
+		}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range serviceRoutes.Items {
+		ref := route.Status.DNSPolicyRef
+		appliedBefore := ref != nil && ref.Kind == "ClusterDNSPolicy" && ref.Name == policy.Name
+		if !selectAll && !selected[route.Namespace] && !appliedBefore {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+// mapNamespaceToServiceRoutes returns the ServiceRoutes in a Namespace
+func (r *ServiceRouteReconciler) mapNamespaceToServiceRoutes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes, client.InNamespace(obj.GetName())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, route := range serviceRoutes.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 // mapGatewayToServiceRoutes returns ServiceRoutes for a Gateway
 func (r *ServiceRouteReconciler) mapGatewayToServiceRoutes(
 	ctx context.Context,
@@ -644,25 +1041,80 @@ func (r *ServiceRouteReconciler) mapClusterIdentityToServiceRoutes(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ServiceRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Register ExternalDNS types with the scheme
+	// Register ExternalDNS and Istio types with the scheme
 	if err := externaldnsv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
 		return err
 	}
+	if err := istioclientv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+
+	if r.DNSBackend == nil {
+		r.DNSBackend = dnsbackend.NewExternalDNS(mgr.GetClient())
+	}
+	if r.Identity == nil {
+		r.Identity = clusteridentity.NewProvider(mgr.GetCache())
+	}
+	if r.Config == nil {
+		r.Config = dnsconfiguration.NewProvider(mgr.GetCache())
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder("serviceroute-controller")
+	}
+
+	// EndpointSlices are mapped to the health gated ServiceRoutes through their backend Service
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &routingv1alpha1.ServiceRoute{},
+		backendServiceNameField, indexBackendServiceName); err != nil {
+		return err
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&routingv1alpha1.ServiceRoute{}).
 		Owns(&externaldnsv1alpha1.DNSEndpoint{}).
+		Owns(&istioclientv1beta1.VirtualService{})
+
+	// Only watch Gateway API resources when the CRDs are expected to be installed
+	if r.GatewayAPIEnabled {
+		controllerBuilder = controllerBuilder.Owns(newGatewayAPIObject(gatewayAPIHTTPRouteGVK))
+	}
+
+	return controllerBuilder.
+		// Re-evaluate hostname ownership of other routes when a route's hostnames or claim change
+		Watches(
+			&routingv1alpha1.ServiceRoute{},
+			handler.EnqueueRequestsFromMapFunc(r.mapServiceRouteToConflictingRoutes),
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			)),
+		).
 		Watches(
 			&routingv1alpha1.DNSPolicy{},
 			handler.EnqueueRequestsFromMapFunc(r.mapDNSPolicyToServiceRoutes),
+			builder.WithPredicates(policyRoutingPredicate()),
+		).
+		// A ClusterDNSPolicy re-evaluates the ServiceRoutes in the namespaces it selects
+		Watches(
+			&routingv1alpha1.ClusterDNSPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.mapClusterDNSPolicyToServiceRoutes),
+			builder.WithPredicates(policyRoutingPredicate()),
+		).
+		// Namespace labels decide which ClusterDNSPolicy applies
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToServiceRoutes),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
 		).
 		Watches(
 			&routingv1alpha1.Gateway{},
 			handler.EnqueueRequestsFromMapFunc(r.mapGatewayToServiceRoutes),
 		).
+		WatchesRawSource(r.Identity.Watch(handler.EnqueueRequestsFromMapFunc(r.mapClusterIdentityToServiceRoutes))).
+		// Health gated routes follow the readiness of their backend endpoints
 		Watches(
-			&clusterv1alpha1.ClusterIdentity{},
-			handler.EnqueueRequestsFromMapFunc(r.mapClusterIdentityToServiceRoutes),
+			&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(r.mapEndpointSliceToServiceRoutes),
+			builder.WithPredicates(endpointSliceReadinessChanged),
 		).
 		Complete(r)
 }
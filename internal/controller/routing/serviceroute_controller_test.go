@@ -23,15 +23,21 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 
 	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
 	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
 )
 
 var _ = Describe("ServiceRoute Controller", func() {
@@ -401,6 +407,449 @@ var _ = Describe("ServiceRoute Controller", func() {
 
 			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
 		})
+
+		It("should publish the full DNS chain in status", func() {
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-chain",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+					Aliases:          []string{"portal"},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			var sr routingv1alpha1.ServiceRoute
+			Eventually(func() int {
+				if err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      serviceRoute.Name,
+					Namespace: serviceRoute.Namespace,
+				}, &sr); err != nil {
+					return 0
+				}
+				return len(sr.Status.DNSEndpoints)
+			}, timeout, interval).Should(Equal(1))
+
+			chain := sr.Status.DNSEndpoints[0]
+			Expect(chain.Name).Should(Equal(sr.Status.DNSEndpoint))
+			Expect(chain.Controller).Should(Equal("external-dns-neu"))
+			Expect(chain.Region).Should(Equal("neu"))
+			Expect(chain.SourceHost).Should(Equal("my-service-ns-d-dev-myapp.example.com"))
+			Expect(chain.Aliases).Should(ConsistOf("portal.example.com"))
+			Expect(chain.TargetHost).Should(Equal("aks-neu-external.example.com"))
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+		})
+
+		It("should publish aliases as additional CNAME records", func() {
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-aliases",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+					Aliases:          []string{"portal.corp.com.", "portal"},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			Eventually(func() []string {
+				var dnsEndpoints externaldnsv1alpha1.DNSEndpointList
+				if err := k8sClient.List(ctx, &dnsEndpoints, client.InNamespace(testNamespace)); err != nil {
+					return nil
+				}
+				if len(dnsEndpoints.Items) == 0 {
+					return nil
+				}
+				var names []string
+				for _, ep := range dnsEndpoints.Items[0].Spec.Endpoints {
+					if ep.RecordType == "CNAME" && len(ep.Targets) > 0 && ep.Targets[0] == "aks-neu-external.example.com" {
+						names = append(names, ep.DNSName)
+					}
+				}
+				return names
+			}, timeout, interval).Should(ConsistOf(
+				"my-service-ns-d-dev-myapp.example.com",
+				"portal.corp.com",
+				"portal.example.com",
+			))
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+		})
+	})
+
+	Context("When a backend is configured", func() {
+		It("should generate a VirtualService bound to the Istio Gateway", func() {
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-backend",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+					Aliases:          []string{"portal"},
+					Backend: &routingv1alpha1.ServiceRouteBackend{
+						ServiceName:  "my-backend",
+						Port:         8080,
+						PathPrefixes: []string{"/api"},
+						Timeout:      &metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			var virtualService istioclientv1beta1.VirtualService
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{
+					Name:      serviceRoute.Name,
+					Namespace: testNamespace,
+				}, &virtualService)
+			}, timeout, interval).Should(Succeed())
+
+			Expect(virtualService.Spec.Hosts).Should(ConsistOf(
+				"my-service-ns-d-dev-myapp.example.com",
+				"portal.example.com",
+			))
+			Expect(virtualService.Spec.Gateways).Should(ConsistOf(gateway.Namespace + "/" + gateway.Name))
+			Expect(virtualService.Spec.Http).Should(HaveLen(1))
+			Expect(virtualService.Spec.Http[0].Route[0].Destination.Host).Should(Equal("my-backend." + testNamespace + ".svc.cluster.local"))
+			Expect(virtualService.Spec.Http[0].Route[0].Destination.Port.Number).Should(Equal(uint32(8080)))
+			Expect(virtualService.Spec.Http[0].Match[0].Uri.GetPrefix()).Should(Equal("/api"))
+			Expect(virtualService.OwnerReferences).Should(HaveLen(1))
+
+			By("correcting drift on the VirtualService")
+			patch := client.MergeFrom(virtualService.DeepCopy())
+			virtualService.Spec.Hosts = []string{"drifted.example.com"}
+			Expect(k8sClient.Patch(ctx, &virtualService, patch)).Should(Succeed())
+
+			Eventually(func() []string {
+				var vs istioclientv1beta1.VirtualService
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &vs); err != nil {
+					return nil
+				}
+				return vs.Spec.Hosts
+			}, timeout, interval).Should(ContainElement("my-service-ns-d-dev-myapp.example.com"))
+
+			By("removing the backend")
+			Eventually(func() error {
+				var sr routingv1alpha1.ServiceRoute
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &sr); err != nil {
+					return err
+				}
+				sr.Spec.Backend = nil
+				return k8sClient.Update(ctx, &sr)
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func() bool {
+				var vs istioclientv1beta1.VirtualService
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &vs)
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+		})
+
+		It("should generate an HTTPRoute when the Gateway uses the Gateway API", func() {
+			Eventually(func() error {
+				var gw routingv1alpha1.Gateway
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}, &gw); err != nil {
+					return err
+				}
+				gw.Spec.Implementation = "GatewayAPI"
+				gw.Spec.GatewayClassName = "istio"
+				return k8sClient.Update(ctx, &gw)
+			}, timeout, interval).Should(Succeed())
+
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-httproute",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+					Backend: &routingv1alpha1.ServiceRouteBackend{
+						ServiceName:  "my-backend",
+						Port:         8080,
+						PathPrefixes: []string{"/api"},
+						Timeout:      &metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			httpRoute := newGatewayAPIObject(gatewayAPIHTTPRouteGVK)
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, httpRoute)
+			}, timeout, interval).Should(Succeed())
+
+			hostnames, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+			Expect(hostnames).Should(ConsistOf("my-service-ns-d-dev-myapp.example.com"))
+
+			parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+			Expect(parentRefs).Should(HaveLen(1))
+			Expect(parentRefs[0].(map[string]interface{})["name"]).Should(Equal(gateway.Name))
+
+			rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+			Expect(rules).Should(HaveLen(1))
+			timeoutValue, _, _ := unstructured.NestedString(rules[0].(map[string]interface{}), "timeouts", "request")
+			Expect(timeoutValue).Should(Equal("30s"))
+
+			// No VirtualService is generated for Gateway API Gateways
+			Consistently(func() bool {
+				var vs istioclientv1beta1.VirtualService
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &vs)
+				return apierrors.IsNotFound(err)
+			}, time.Second*2, interval).Should(BeTrue())
+
+			Eventually(func() string {
+				var sr routingv1alpha1.ServiceRoute
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &sr); err != nil {
+					return ""
+				}
+				return sr.Status.HTTPRoute
+			}, timeout, interval).Should(Equal(serviceRoute.Name))
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+		})
+	})
+
+	Context("When publishing is health gated", func() {
+		It("should withhold DNSEndpoints until the backend has ready endpoints", func() {
+			By("giving the Gateway a LoadBalancer IP so it becomes DNSReady")
+			lbService := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-lb", gateway.Name),
+					Namespace: gateway.Namespace,
+					Labels:    map[string]string{"istio": gateway.Spec.Controller},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{{Port: 443}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, lbService)).Should(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, lbService) }()
+			lbService.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.50"}}
+			Expect(k8sClient.Status().Update(ctx, lbService)).Should(Succeed())
+
+			By("letting ExternalDNS process the A records of the Gateway")
+			Eventually(func() (int, error) {
+				return observeDNSEndpoints(ctx, gateway.Namespace, map[string]string{
+					"router.io/istio-controller": gateway.Spec.Controller,
+				})
+			}, timeout, interval).Should(BeNumerically(">", 0))
+
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-gated",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+					Backend: &routingv1alpha1.ServiceRouteBackend{
+						ServiceName: "my-backend",
+						Port:        8080,
+					},
+					HealthGated: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			backendReady := func() *metav1.Condition {
+				var sr routingv1alpha1.ServiceRoute
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace}, &sr); err != nil {
+					return nil
+				}
+				return meta.FindStatusCondition(sr.Status.Conditions, consts.ConditionTypeBackendReady)
+			}
+			dnsEndpointCount := func() int {
+				var dnsEndpoints externaldnsv1alpha1.DNSEndpointList
+				if err := k8sClient.List(ctx, &dnsEndpoints, client.InNamespace(testNamespace),
+					client.MatchingLabels{"router.io/serviceroute": serviceRoute.Name}); err != nil {
+					return -1
+				}
+				return len(dnsEndpoints.Items)
+			}
+
+			By("withholding DNS while the backend has no endpoints")
+			Eventually(backendReady, timeout, interval).Should(And(
+				Not(BeNil()),
+				HaveField("Reason", consts.ReasonNoReadyEndpoints),
+			))
+			Consistently(dnsEndpointCount, time.Second, interval).Should(BeZero())
+
+			// The route is generated anyway so traffic flows once DNS is published
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: serviceRoute.Name, Namespace: testNamespace},
+				&istioclientv1beta1.VirtualService{})).Should(Succeed())
+
+			By("publishing once an endpoint is ready")
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-backend-abc",
+					Namespace: testNamespace,
+					Labels:    map[string]string{discoveryv1.LabelServiceName: "my-backend"},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses:  []string{"10.1.0.10"},
+						Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, endpointSlice)).Should(Succeed())
+
+			Eventually(backendReady, timeout, interval).Should(And(
+				Not(BeNil()),
+				HaveField("Status", metav1.ConditionTrue),
+			))
+			Eventually(dnsEndpointCount, timeout, interval).Should(Equal(1))
+
+			By("withdrawing DNS when the last endpoint becomes unready")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: endpointSlice.Name, Namespace: testNamespace}, endpointSlice)).Should(Succeed())
+			endpointSlice.Endpoints[0].Conditions.Ready = ptr.To(false)
+			Expect(k8sClient.Update(ctx, endpointSlice)).Should(Succeed())
+
+			Eventually(dnsEndpointCount, timeout, interval).Should(BeZero())
+			Expect(backendReady()).Should(HaveField("Reason", consts.ReasonNoReadyEndpoints))
+
+			Expect(k8sClient.Delete(ctx, endpointSlice)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+		})
+	})
+
+	Context("When two ServiceRoutes render the same hostname", func() {
+		It("should fail the newer route and hand over when the owner is deleted", func() {
+			newRoute := func(name string) *routingv1alpha1.ServiceRoute {
+				return &routingv1alpha1.ServiceRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: testNamespace,
+					},
+					Spec: routingv1alpha1.ServiceRouteSpec{
+						ServiceName:      "shared",
+						GatewayName:      gateway.Name,
+						GatewayNamespace: gateway.Namespace,
+						Environment:      "dev",
+						Application:      "myapp",
+					},
+				}
+			}
+			phaseOf := func(name string) func() string {
+				return func() string {
+					var sr routingv1alpha1.ServiceRoute
+					if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: testNamespace}, &sr); err != nil {
+						return ""
+					}
+					return sr.Status.Phase
+				}
+			}
+			endpointsOf := func(name string) func() int {
+				return func() int {
+					var list externaldnsv1alpha1.DNSEndpointList
+					if err := k8sClient.List(ctx, &list, client.InNamespace(testNamespace),
+						client.MatchingLabels{"router.io/serviceroute": name}); err != nil {
+						return -1
+					}
+					return len(list.Items)
+				}
+			}
+
+			owner := newRoute("test-serviceroute-owner")
+			Expect(k8sClient.Create(ctx, owner)).Should(Succeed())
+			Eventually(phaseOf(owner.Name), timeout, interval).Should(Equal("Active"))
+
+			// creationTimestamp has second granularity
+			time.Sleep(time.Second)
+
+			contender := newRoute("test-serviceroute-contender")
+			Expect(k8sClient.Create(ctx, contender)).Should(Succeed())
+			Eventually(phaseOf(contender.Name), timeout, interval).Should(Equal("Failed"))
+
+			var sr routingv1alpha1.ServiceRoute
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: contender.Name, Namespace: testNamespace}, &sr)).Should(Succeed())
+			Expect(sr.Status.Conditions).ShouldNot(BeEmpty())
+			Expect(sr.Status.Conditions[0].Reason).Should(Equal("HostnameConflict"))
+			Consistently(endpointsOf(contender.Name), time.Second*2, interval).Should(Equal(0))
+
+			By("deleting the owner")
+			Expect(k8sClient.Delete(ctx, owner)).Should(Succeed())
+			Eventually(phaseOf(contender.Name), timeout, interval).Should(Equal("Active"))
+			Eventually(endpointsOf(contender.Name), timeout, interval).Should(BeNumerically(">", 0))
+
+			Expect(k8sClient.Delete(ctx, contender)).Should(Succeed())
+		})
+
+		It("should let an explicit claim win over an older route", func() {
+			older := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-older",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "claimed",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+				},
+			}
+			Expect(k8sClient.Create(ctx, older)).Should(Succeed())
+
+			claiming := older.DeepCopy()
+			claiming.ObjectMeta = metav1.ObjectMeta{
+				Name:        "test-serviceroute-claiming",
+				Namespace:   testNamespace,
+				Annotations: map[string]string{consts.AnnotationHostnameClaim: "true"},
+			}
+			Expect(k8sClient.Create(ctx, claiming)).Should(Succeed())
+
+			Eventually(func() string {
+				var sr routingv1alpha1.ServiceRoute
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: older.Name, Namespace: testNamespace}, &sr); err != nil {
+					return ""
+				}
+				return sr.Status.Phase
+			}, timeout, interval).Should(Equal("Failed"))
+
+			Eventually(func() string {
+				var sr routingv1alpha1.ServiceRoute
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: claiming.Name, Namespace: testNamespace}, &sr); err != nil {
+					return ""
+				}
+				return sr.Status.Phase
+			}, timeout, interval).Should(Equal("Active"))
+
+			Expect(k8sClient.Delete(ctx, claiming)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, older)).Should(Succeed())
+		})
 	})
 
 	Context("When validating ServiceRoute", func() {
@@ -529,6 +978,169 @@ var _ = Describe("ServiceRoute Controller", func() {
 			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, otherNs)).Should(Succeed())
 		})
+
+		It("should fall back to the ClusterDNSPolicy selecting the namespace", func() {
+			tenantNs := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   testNamespace + "-tenant",
+					Labels: map[string]string{"router.io/tenant": "true"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, tenantNs)).Should(Succeed())
+
+			clusterPolicy := &routingv1alpha1.ClusterDNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace + "-default",
+				},
+				Spec: routingv1alpha1.ClusterDNSPolicySpec{
+					DNSPolicySpec: routingv1alpha1.DNSPolicySpec{
+						Mode: "Active",
+					},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"router.io/tenant": "true"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterPolicy)).Should(Succeed())
+
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-cluster-policy",
+					Namespace: tenantNs.Name,
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "my-service",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			var sr routingv1alpha1.ServiceRoute
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceRoute), &sr); err != nil {
+					return ""
+				}
+				return sr.Status.Phase
+			}, timeout, interval).Should(Equal("Active"))
+			Expect(sr.Status.DNSPolicyRef).To(Equal(&routingv1alpha1.ServiceRouteDNSPolicyReference{
+				Kind: "ClusterDNSPolicy",
+				Name: clusterPolicy.Name,
+			}))
+			Expect(sr.Status.DNSEndpoints).To(HaveLen(1))
+
+			// A DNSPolicy in the namespace takes precedence over the ClusterDNSPolicy
+			namespacePolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tenant-dns",
+					Namespace: tenantNs.Name,
+				},
+				Spec: routingv1alpha1.DNSPolicySpec{
+					Mode: "RegionBound",
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespacePolicy)).Should(Succeed())
+
+			Eventually(func() *routingv1alpha1.ServiceRouteDNSPolicyReference {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceRoute), &sr); err != nil {
+					return nil
+				}
+				return sr.Status.DNSPolicyRef
+			}, timeout, interval).Should(Equal(&routingv1alpha1.ServiceRouteDNSPolicyReference{
+				Kind:      "DNSPolicy",
+				Name:      namespacePolicy.Name,
+				Namespace: tenantNs.Name,
+			}))
+			Eventually(func() int {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceRoute), &sr); err != nil {
+					return 0
+				}
+				return len(sr.Status.DNSEndpoints)
+			}, timeout, interval).Should(Equal(2))
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, namespacePolicy)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, clusterPolicy)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, tenantNs)).Should(Succeed())
+		})
+	})
+
+	Context("When a namespace holds several DNSPolicies", func() {
+		It("should follow the DNSPolicy whose routeSelector selects the route", func() {
+			criticalPolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "critical-dns",
+					Namespace: testNamespace,
+				},
+				Spec: routingv1alpha1.DNSPolicySpec{
+					Mode: "RegionBound",
+					RouteSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"tier": "critical"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, criticalPolicy)).Should(Succeed())
+
+			serviceRoute := &routingv1alpha1.ServiceRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-serviceroute-critical",
+					Namespace: testNamespace,
+					Labels:    map[string]string{"tier": "critical"},
+				},
+				Spec: routingv1alpha1.ServiceRouteSpec{
+					ServiceName:      "payments",
+					GatewayName:      gateway.Name,
+					GatewayNamespace: gateway.Namespace,
+					Environment:      "dev",
+					Application:      "myapp",
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceRoute)).Should(Succeed())
+
+			// RegionBound publishes through both controllers, the namespace default only through neu
+			var sr routingv1alpha1.ServiceRoute
+			Eventually(func() int {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceRoute), &sr); err != nil {
+					return 0
+				}
+				return len(sr.Status.DNSEndpoints)
+			}, timeout, interval).Should(Equal(2))
+			Expect(sr.Status.DNSPolicyRef.Name).To(Equal(criticalPolicy.Name))
+
+			// A second policy selecting the same route is reported as a conflict on both
+			overlappingPolicy := &routingv1alpha1.DNSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "critical-dns-copy",
+					Namespace: testNamespace,
+				},
+				Spec: criticalPolicy.Spec,
+			}
+			Expect(k8sClient.Create(ctx, overlappingPolicy)).Should(Succeed())
+
+			for _, policy := range []*routingv1alpha1.DNSPolicy{criticalPolicy, overlappingPolicy} {
+				Eventually(func() bool {
+					var dp routingv1alpha1.DNSPolicy
+					if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(policy), &dp); err != nil {
+						return false
+					}
+					return meta.IsStatusConditionTrue(dp.Status.Conditions, consts.ConditionTypeRouteSelectorConflict)
+				}, timeout, interval).Should(BeTrue())
+			}
+
+			Expect(k8sClient.Delete(ctx, overlappingPolicy)).Should(Succeed())
+			Eventually(func() *metav1.Condition {
+				var dp routingv1alpha1.DNSPolicy
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(criticalPolicy), &dp); err != nil {
+					return nil
+				}
+				return meta.FindStatusCondition(dp.Status.Conditions, consts.ConditionTypeRouteSelectorConflict)
+			}, timeout, interval).Should(BeNil())
+
+			Expect(k8sClient.Delete(ctx, serviceRoute)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, criticalPolicy)).Should(Succeed())
+		})
 	})
 
 	Context("When updating ServiceRoute", func() {
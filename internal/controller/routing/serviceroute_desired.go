@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// routeBlock explains why a ServiceRoute publishes no records,
+// with the phase and condition reason the ServiceRoute reports for it
+type routeBlock struct {
+	phase   string
+	reason  string
+	message string
+}
+
+// desiredRoute is the outcome of desiredRecordSets
+type desiredRoute struct {
+	// dnsPolicy, gateway and the identities are set once the corresponding step passed
+	dnsPolicy          *routingv1alpha1.DNSPolicy
+	gateway            *routingv1alpha1.Gateway
+	clusterIdentity    *clusteridentity.ClusterIdentity
+	previousIdentities []*clusteridentity.ClusterIdentity
+	// backendChecked is true once the backend health step was reached,
+	// health is nil when the ServiceRoute is not health gated
+	backendChecked bool
+	health         *backendHealth
+	// claims holds the record sets to publish and the hostnames claimed by other clusters
+	claims *claimResult
+	// blocked is set when a step stops the ServiceRoute from publishing
+	blocked *routeBlock
+}
+
+// desiredRecordSets computes the record sets a ServiceRoute publishes. It walks the dependency chain
+// DNSPolicy, DNSConfiguration, Gateway, ClusterIdentity, hostnames, hostname conflicts, backend health,
+// record generation and ownership claims, and stops at the first step that blocks the route.
+// The reconciler, the record export and the diagnosis all use it, so they agree on every record.
+// Only failures to read the cluster state are returned as errors.
+func (r *ServiceRouteReconciler) desiredRecordSets(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) (*desiredRoute, error) {
+	logger := log.FromContext(ctx)
+	desired := &desiredRoute{}
+	block := func(phase, reason, message string) (*desiredRoute, error) {
+		desired.blocked = &routeBlock{phase: phase, reason: reason, message: message}
+		return desired, nil
+	}
+
+	// Validate to ensure we have a complete specification before attempting generation.
+	if err := validation.ServiceRoute(serviceRoute); err != nil {
+		return block(consts.PhaseFailed, consts.ReasonValidationFailed, err.Error())
+	}
+
+	// Fetch the DNSPolicy for the route to determine the routing strategy.
+	// Without one, a ClusterDNSPolicy selecting the namespace is used.
+	dnsPolicy, err := r.getDNSPolicyForServiceRoute(ctx, serviceRoute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNSPolicy: %w", err)
+	}
+	if dnsPolicy == nil {
+		return block(consts.PhasePending, consts.ReasonDNSPolicyNotFound,
+			"Waiting for DNSPolicy to be configured in namespace")
+	}
+	desired.dnsPolicy = dnsPolicy
+
+	if !dnsPolicy.Status.Active {
+		return block(consts.PhasePending, consts.ReasonDNSPolicyInactive,
+			"DNSPolicy is not active for this cluster (sourceRegion/sourceCluster mismatch).")
+	}
+
+	// Need DNSConfiguration to map controller names to regions.
+	// We use the cached configuration with CRD fallback to ensure availability.
+	dnsConfig, err := r.Config.Config(ctx)
+	if err != nil {
+		logger.Error(err, "failed to get DNSConfiguration")
+	}
+	if err != nil || dnsConfig == nil {
+		return block(consts.PhasePending, consts.ReasonDNSConfigurationNotAvailable,
+			"Waiting for DNSConfiguration to be configured")
+	}
+
+	// Fetch the Gateway to determine the target host and postfix.
+	gatewayNamespace := serviceRoute.Spec.GatewayNamespace
+	if gatewayNamespace == "" {
+		gatewayNamespace = r.DefaultRouterGatewayNamespace
+	}
+	var gateway routingv1alpha1.Gateway
+	if err := r.Get(ctx, client.ObjectKey{
+		Name:      serviceRoute.Spec.GatewayName,
+		Namespace: gatewayNamespace,
+	}, &gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			return block(consts.PhasePending, consts.ReasonGatewayNotFound,
+				fmt.Sprintf("Gateway %s not found in namespace %s", serviceRoute.Spec.GatewayName, gatewayNamespace))
+		}
+		return nil, fmt.Errorf("failed to fetch Gateway: %w", err)
+	}
+	desired.gateway = &gateway
+
+	// ClusterIdentity provides the domain and region information for hostname generation.
+	clusterIdentity, err := r.Identity.Identity(ctx)
+	if err != nil {
+		logger.Error(err, "failed to get ClusterIdentity")
+	}
+	if err != nil || clusterIdentity == nil {
+		return block(consts.PhasePending, consts.ReasonClusterIdentityNotAvailable,
+			"Waiting for ClusterIdentity to be configured")
+	}
+	desired.clusterIdentity = clusterIdentity
+
+	// During a hostname migration the hostnames of the previous identities stay published
+	previousIdentities, err := r.Identity.PreviousIdentities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous cluster identities: %w", err)
+	}
+	desired.previousIdentities = previousIdentities
+
+	// Hostnames that exceed the DNS limits would be rejected by every DNS provider.
+	if err := validation.ServiceRouteHostnames(serviceRoute, clusterIdentity); err != nil {
+		return block(consts.PhaseFailed, consts.ReasonValidationFailed, err.Error())
+	}
+
+	// Two ServiceRoutes may render the same hostname. Only the owning route publishes it,
+	// the other one withdraws its records so they never compete in DNS.
+	conflict, err := r.findHostnameConflict(ctx, serviceRoute, clusterIdentity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for hostname conflicts: %w", err)
+	}
+	if conflict != nil {
+		return block(consts.PhaseFailed, consts.ReasonHostnameConflict,
+			fmt.Sprintf("Hostname %s is already owned by ServiceRoute %s", conflict.host, conflict.owner))
+	}
+
+	// Health gated routes only publish while the backend can serve the hostnames.
+	desired.backendChecked = true
+	if serviceRoute.Spec.HealthGated {
+		health, err := r.checkBackendHealth(ctx, serviceRoute, &gateway)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check backend health: %w", err)
+		}
+		desired.health = &health
+		if !health.ready {
+			return block(consts.PhasePending, consts.ReasonBackendNotReady, health.message)
+		}
+	}
+
+	// Generate the desired record sets based on the active controllers and route spec.
+	recordSets, err := r.generateRecordSets(serviceRoute, dnsPolicy, &gateway, clusterIdentity, previousIdentities, dnsConfig)
+	if err != nil {
+		return block(consts.PhaseFailed, consts.ReasonDNSEndpointGenerationFailed, err.Error())
+	}
+
+	// Hostnames published in the zone of another region are claimed through their ownership records.
+	// Record sets claimed by another cluster are not published, or withdrawn when that claim is newer.
+	claims, err := r.claimRecordSets(ctx, serviceRoute, dnsPolicy, clusterIdentity, recordSets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim hostnames: %w", err)
+	}
+	desired.claims = claims
+	return desired, nil
+}
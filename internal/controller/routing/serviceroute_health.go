@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// backendServiceNameField is the field index of health gated ServiceRoutes on their backend Service
+const backendServiceNameField = "spec.backend.serviceName"
+
+// backendHealth is the outcome of the health gate of a ServiceRoute
+type backendHealth struct {
+	ready   bool
+	reason  string
+	message string
+}
+
+// checkBackendHealth reports whether a health gated ServiceRoute may publish its records:
+// the backend Service needs at least one ready endpoint and the Gateway must be DNSReady.
+func (r *ServiceRouteReconciler) checkBackendHealth(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	gateway *routingv1alpha1.Gateway,
+) (backendHealth, error) {
+	backend := serviceRoute.Spec.Backend
+
+	readyEndpoints, err := r.countReadyEndpoints(ctx, serviceRoute.Namespace, backend.ServiceName)
+	if err != nil {
+		return backendHealth{}, err
+	}
+	if readyEndpoints == 0 {
+		return backendHealth{
+			reason:  consts.ReasonNoReadyEndpoints,
+			message: fmt.Sprintf("Service %s has no ready endpoints", backend.ServiceName),
+		}, nil
+	}
+
+	if !meta.IsStatusConditionTrue(gateway.Status.Conditions, consts.ConditionTypeDNSReady) {
+		return backendHealth{
+			reason:  consts.ReasonGatewayDNSNotReady,
+			message: fmt.Sprintf("Gateway %s/%s is not DNSReady", gateway.Namespace, gateway.Name),
+		}, nil
+	}
+
+	return backendHealth{
+		ready:   true,
+		reason:  consts.ReasonEndpointsReady,
+		message: fmt.Sprintf("Service %s has %d ready endpoints", backend.ServiceName, readyEndpoints),
+	}, nil
+}
+
+// countReadyEndpoints counts the ready endpoints in the EndpointSlices of a Service.
+func (r *ServiceRouteReconciler) countReadyEndpoints(ctx context.Context, namespace, serviceName string) (int, error) {
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &endpointSlices,
+		client.InNamespace(namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: serviceName},
+	); err != nil {
+		return 0, err
+	}
+
+	ready := 0
+	for i := range endpointSlices.Items {
+		ready += readyEndpoints(&endpointSlices.Items[i])
+	}
+
+	return ready, nil
+}
+
+// readyEndpoints counts the ready endpoints of an EndpointSlice.
+// An endpoint without a ready condition is ready, as defined by the EndpointSlice API.
+func readyEndpoints(slice *discoveryv1.EndpointSlice) int {
+	ready := 0
+	for _, endpoint := range slice.Endpoints {
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			ready++
+		}
+	}
+	return ready
+}
+
+// setBackendReadyCondition reports the health gate in the BackendReady condition.
+// ServiceRoutes that are not health gated carry no BackendReady condition.
+func setBackendReadyCondition(serviceRoute *routingv1alpha1.ServiceRoute, health *backendHealth) {
+	if health == nil {
+		meta.RemoveStatusCondition(&serviceRoute.Status.Conditions, consts.ConditionTypeBackendReady)
+		return
+	}
+
+	status := metav1.ConditionFalse
+	if health.ready {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&serviceRoute.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeBackendReady,
+		Status:             status,
+		ObservedGeneration: serviceRoute.Generation,
+		Reason:             health.reason,
+		Message:            health.message,
+	})
+}
+
+// indexBackendServiceName indexes health gated ServiceRoutes by the name of their backend Service
+func indexBackendServiceName(obj client.Object) []string {
+	serviceRoute, ok := obj.(*routingv1alpha1.ServiceRoute)
+	if !ok || !serviceRoute.Spec.HealthGated || serviceRoute.Spec.Backend == nil {
+		return nil
+	}
+	return []string{serviceRoute.Spec.Backend.ServiceName}
+}
+
+// healthGatedRoutes lists the health gated ServiceRoutes routing to the Service of an EndpointSlice
+func (r *ServiceRouteReconciler) healthGatedRoutes(
+	ctx context.Context,
+	obj client.Object,
+) ([]routingv1alpha1.ServiceRoute, error) {
+	serviceName := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if serviceName == "" {
+		return nil, nil
+	}
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := r.List(ctx, &serviceRoutes,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{backendServiceNameField: serviceName},
+	); err != nil {
+		return nil, err
+	}
+	return serviceRoutes.Items, nil
+}
+
+// endpointSliceReadinessChanged passes EndpointSlices of Services and only updates that change the
+// number of ready endpoints. Whether a health gated ServiceRoute routes to the Service is left to
+// mapEndpointSliceToServiceRoutes, which can list ServiceRoutes with the context of the event.
+var endpointSliceReadinessChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return isServiceEndpointSlice(e.Object)
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSlice, oldOK := e.ObjectOld.(*discoveryv1.EndpointSlice)
+		newSlice, newOK := e.ObjectNew.(*discoveryv1.EndpointSlice)
+		if oldOK && newOK && readyEndpoints(oldSlice) == readyEndpoints(newSlice) {
+			return false
+		}
+		return isServiceEndpointSlice(e.ObjectNew)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return isServiceEndpointSlice(e.Object)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return isServiceEndpointSlice(e.Object)
+	},
+}
+
+// isServiceEndpointSlice reports whether an EndpointSlice belongs to a Service
+func isServiceEndpointSlice(obj client.Object) bool {
+	return obj.GetLabels()[discoveryv1.LabelServiceName] != ""
+}
+
+// mapEndpointSliceToServiceRoutes returns the health gated ServiceRoutes routing to the Service of an EndpointSlice
+func (r *ServiceRouteReconciler) mapEndpointSliceToServiceRoutes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	serviceRoutes, err := r.healthGatedRoutes(ctx, obj)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list the health gated ServiceRoutes of an EndpointSlice",
+			"endpointSlice", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, route := range serviceRoutes {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      route.Name,
+				Namespace: route.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+// EndpointSliceCache limits the cached EndpointSlices to those of Services and keeps only what the
+// health gate reads: the Service label and the ready condition of the endpoints.
+func EndpointSliceCache() cache.ByObject {
+	hasService, err := labels.NewRequirement(discoveryv1.LabelServiceName, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+	return cache.ByObject{
+		Label:     labels.NewSelector().Add(*hasService),
+		Transform: trimEndpointSlice,
+	}
+}
+
+// trimEndpointSlice drops the fields of a cached EndpointSlice the health gate does not read
+func trimEndpointSlice(obj interface{}) (interface{}, error) {
+	slice, ok := obj.(*discoveryv1.EndpointSlice)
+	if !ok {
+		return obj, nil
+	}
+	slice.ManagedFields = nil
+	slice.Annotations = nil
+	slice.Ports = nil
+	for i := range slice.Endpoints {
+		slice.Endpoints[i] = discoveryv1.Endpoint{
+			Conditions: discoveryv1.EndpointConditions{Ready: slice.Endpoints[i].Conditions.Ready},
+		}
+	}
+	return slice, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+)
+
+var _ = Describe("EndpointSlice watch", func() {
+	var reconciler *ServiceRouteReconciler
+	watch := endpointSliceReadinessChanged
+
+	newRoute := func(name, backend string, healthGated bool) *routingv1alpha1.ServiceRoute {
+		return &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: name,
+				GatewayName: "default-gateway",
+				Environment: "dev",
+				Application: "shop",
+				Backend:     &routingv1alpha1.ServiceRouteBackend{ServiceName: backend, Port: 8080},
+				HealthGated: healthGated,
+			},
+		}
+	}
+	newSlice := func(service string, ready ...bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      service + "-abc",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		}
+		for _, r := range ready {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{"10.1.0.10"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(r)},
+			})
+		}
+		return slice
+	}
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		reconciler = &ServiceRouteReconciler{
+			Client: fake.NewClientBuilder().WithScheme(testScheme).
+				WithIndex(&routingv1alpha1.ServiceRoute{}, backendServiceNameField, indexBackendServiceName).
+				WithObjects(
+					newRoute("gated", "gated-backend", true),
+					newRoute("ungated", "ungated-backend", false),
+				).Build(),
+		}
+	})
+
+	It("should only pass EndpointSlices of Services", func() {
+		unlabeled := newSlice("unrelated", true)
+		unlabeled.Labels = nil
+		Expect(watch.Create(event.CreateEvent{Object: newSlice("gated-backend", true)})).To(BeTrue())
+		Expect(watch.Create(event.CreateEvent{Object: unlabeled})).To(BeFalse())
+		Expect(watch.Delete(event.DeleteEvent{Object: newSlice("gated-backend", true)})).To(BeTrue())
+		Expect(watch.Delete(event.DeleteEvent{Object: unlabeled})).To(BeFalse())
+	})
+
+	It("should ignore updates that do not change readiness", func() {
+		Expect(watch.Update(event.UpdateEvent{
+			ObjectOld: newSlice("gated-backend", true, false),
+			ObjectNew: newSlice("gated-backend", false, true),
+		})).To(BeFalse())
+		Expect(watch.Update(event.UpdateEvent{
+			ObjectOld: newSlice("gated-backend", true),
+			ObjectNew: newSlice("gated-backend", false),
+		})).To(BeTrue())
+	})
+
+	It("should map an EndpointSlice to the health gated ServiceRoutes of its Service", func() {
+		Expect(reconciler.mapEndpointSliceToServiceRoutes(context.Background(), newSlice("gated-backend", true))).To(
+			ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "gated", Namespace: "default"}}))
+		Expect(reconciler.mapEndpointSliceToServiceRoutes(context.Background(), newSlice("ungated-backend", true))).To(BeEmpty())
+		Expect(reconciler.mapEndpointSliceToServiceRoutes(context.Background(), newSlice("unrelated", true))).To(BeEmpty())
+	})
+
+	It("should cache only the ready condition of the endpoints", func() {
+		slice := newSlice("gated-backend", true, false)
+		slice.Annotations = map[string]string{"endpoints.kubernetes.io/last-change-trigger-time": "2025-01-01T00:00:00Z"}
+		slice.Ports = []discoveryv1.EndpointPort{{Port: ptr.To[int32](8080)}}
+
+		trimmed, err := trimEndpointSlice(slice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trimmed).To(Equal(&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gated-backend-abc",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "gated-backend"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}},
+				{Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)}},
+			},
+		}))
+		Expect(readyEndpoints(trimmed.(*discoveryv1.EndpointSlice))).To(Equal(1))
+	})
+})
@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// ownershipRecheckInterval is how often claimed hostnames are checked for claims of other clusters
+const ownershipRecheckInterval = 2 * time.Minute
+
+// ownerRecord returns the ownership record this cluster publishes under a DNSPolicy.
+// The generation is set when the record set is claimed, see claimRecordSets.
+func ownerRecord(clusterIdentity *clusteridentity.ClusterIdentity, dnsPolicy *routingv1alpha1.DNSPolicy) ownership.Record {
+	return ownership.Record{
+		Cluster:    clusterIdentity.Cluster,
+		Region:     clusterIdentity.Region,
+		Mode:       dnsPolicy.Spec.Mode,
+		Generation: 1,
+	}
+}
+
+// ownershipRecords returns the ownership TXT record of every CNAME in records
+func ownershipRecords(records []dnsbackend.Record, owner ownership.Record) []dnsbackend.Record {
+	var txtRecords []dnsbackend.Record
+	for _, record := range records {
+		if record.RecordType != "CNAME" {
+			continue
+		}
+		txtRecords = append(txtRecords, dnsbackend.Record{
+			DNSName:    ownership.RecordName(record.DNSName),
+			RecordType: "TXT",
+			Targets:    []string{owner.String()},
+		})
+	}
+	return txtRecords
+}
+
+// recordSetGeneration returns the generation of the ownership records in a record set, 0 without any
+func recordSetGeneration(recordSet *dnsbackend.RecordSet) int64 {
+	var generation int64
+	for _, record := range recordSet.Records {
+		if record.RecordType != "TXT" {
+			continue
+		}
+		for _, owner := range ownership.ParseAll(record.Targets) {
+			generation = max(generation, owner.Generation)
+		}
+	}
+	return generation
+}
+
+// setRecordSetGeneration sets the generation of the ownership records in a record set
+func setRecordSetGeneration(recordSet *dnsbackend.RecordSet, generation int64) {
+	for i := range recordSet.Records {
+		record := &recordSet.Records[i]
+		if record.RecordType != "TXT" || len(record.Targets) != 1 {
+			continue
+		}
+		owner, ok := ownership.Parse(record.Targets[0])
+		if !ok {
+			continue
+		}
+		owner.Generation = generation
+		record.Targets = []string{owner.String()}
+	}
+}
+
+// takeoverConflict describes a hostname claimed by another cluster
+type takeoverConflict struct {
+	host       string
+	controller string
+	holder     ownership.Record
+	// published is true when the hostname stays published in the zone of the cluster's own region
+	published bool
+}
+
+// claimResult is the outcome of claimRecordSets
+type claimResult struct {
+	// recordSets are the record sets this cluster may publish
+	recordSets []*dnsbackend.RecordSet
+	// conflicts are the hostnames withheld because another cluster claims them
+	conflicts []takeoverConflict
+	// contested are the hostnames of the own region that another cluster claims with a newer generation.
+	// They stay published, ExternalDNS owner IDs arbitrate the zone of the own region.
+	contested []takeoverConflict
+	// checked is true when ownership records were looked up in DNS
+	checked bool
+}
+
+// claimRecordSets claims the hostnames of the record sets and returns the record sets to publish.
+//
+// Record sets for the controller of the cluster's own region are published as usual, a newer claim
+// of another cluster on one of their hostnames is only reported as contested. A record set for the
+// controller of another region (RegionBound mode, adopted regions or a failover takeover) publishes
+// hostnames that clusters of other regions may publish as well, so its hostnames are claimed first:
+// the ownership records found in DNS decide, see ownership.Decide. In Failover mode the hostnames of
+// a peer region this cluster has taken over are claimed with the next generation. A record set is
+// withheld, or withdrawn once published, when any of its hostnames is claimed by another cluster.
+// Without an Ownership resolver no claim is looked up and every record set is published.
+func (r *ServiceRouteReconciler) claimRecordSets(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	dnsPolicy *routingv1alpha1.DNSPolicy,
+	clusterIdentity *clusteridentity.ClusterIdentity,
+	recordSets []*dnsbackend.RecordSet,
+) (*claimResult, error) {
+	logger := log.FromContext(ctx)
+
+	existing, err := r.DNSBackend.List(ctx, serviceRoute.Namespace, serviceRouteRecordLabels(types.NamespacedName{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[string]int64, len(existing))
+	for _, set := range existing {
+		published[set.Key()] = recordSetGeneration(set)
+	}
+
+	takeover := func(holder ownership.Record) bool {
+		return peerTakenOver(dnsPolicy, holder.Region)
+	}
+
+	result := &claimResult{}
+	for _, set := range recordSets {
+		self := ownerRecord(clusterIdentity, dnsPolicy)
+		self.Generation = published[set.Key()]
+
+		if r.Ownership == nil {
+			setRecordSetGeneration(set, max(self.Generation, 1))
+			result.recordSets = append(result.recordSets, set)
+			continue
+		}
+
+		result.checked = true
+		if set.Labels["router.io/region"] == clusterIdentity.Region {
+			self.Generation = max(self.Generation, 1)
+			contested, err := r.contestedHosts(ctx, set, self)
+			if err != nil {
+				return nil, err
+			}
+			if len(contested) > 0 {
+				logger.Info("Hostnames of the own region are claimed by another cluster with a newer generation",
+					"recordSet", set.Name, "host", contested[0].host, "holder", contested[0].holder.Owner())
+				result.contested = append(result.contested, contested...)
+			}
+			setRecordSetGeneration(set, self.Generation)
+			result.recordSets = append(result.recordSets, set)
+			continue
+		}
+
+		generation := self.Generation
+		var conflicts []takeoverConflict
+		for _, record := range set.Records {
+			if record.RecordType != "CNAME" {
+				continue
+			}
+			observed, err := r.Ownership.Lookup(ctx, record.DNSName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up the ownership of %s: %w", record.DNSName, err)
+			}
+			claim := ownership.Decide(self, observed, takeover)
+			if !claim.Allowed {
+				conflicts = append(conflicts, takeoverConflict{
+					host:       record.DNSName,
+					controller: set.Labels["router.io/controller"],
+					holder:     *claim.Holder,
+				})
+				continue
+			}
+			generation = max(generation, claim.Generation)
+		}
+
+		if len(conflicts) > 0 {
+			logger.Info("Hostnames are claimed by another cluster, withholding record set",
+				"recordSet", set.Name, "host", conflicts[0].host, "holder", conflicts[0].holder.Owner())
+			result.conflicts = append(result.conflicts, conflicts...)
+			continue
+		}
+		if self.Generation == 0 && generation > 1 {
+			logger.Info("Hostnames taken over from another cluster", "recordSet", set.Name, "generation", generation)
+		}
+		setRecordSetGeneration(set, generation)
+		result.recordSets = append(result.recordSets, set)
+	}
+
+	return result, nil
+}
+
+// contestedHosts returns the hostnames of a record set that another cluster claims with a newer generation than self
+func (r *ServiceRouteReconciler) contestedHosts(
+	ctx context.Context,
+	recordSet *dnsbackend.RecordSet,
+	self ownership.Record,
+) ([]takeoverConflict, error) {
+	var contested []takeoverConflict
+	for _, record := range recordSet.Records {
+		if record.RecordType != "CNAME" {
+			continue
+		}
+		observed, err := r.Ownership.Lookup(ctx, record.DNSName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up the ownership of %s: %w", record.DNSName, err)
+		}
+		for _, holder := range observed {
+			if holder.SameOwner(self) || holder.Generation <= self.Generation {
+				continue
+			}
+			contested = append(contested, takeoverConflict{
+				host:       record.DNSName,
+				controller: recordSet.Labels["router.io/controller"],
+				holder:     holder,
+				published:  true,
+			})
+			break
+		}
+	}
+	return contested, nil
+}
+
+// peerTakenOver reports whether a Failover mode policy currently takes over a peer region
+func peerTakenOver(dnsPolicy *routingv1alpha1.DNSPolicy, region string) bool {
+	if dnsPolicy.Spec.Mode != "Failover" {
+		return false
+	}
+	for _, peer := range dnsPolicy.Status.Peers {
+		if peer.Region == region {
+			return peer.TakenOver
+		}
+	}
+	return false
+}
+
+// takeoverConflictMessage describes the hostnames claimed by other clusters
+func takeoverConflictMessage(conflicts []takeoverConflict) string {
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		if conflict.published {
+			descriptions = append(descriptions, fmt.Sprintf("%s (controller %s) is published but claimed by %s",
+				conflict.host, conflict.controller, conflict.holder.Owner()))
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (controller %s) is claimed by %s",
+			conflict.host, conflict.controller, conflict.holder.Owner()))
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// setServiceRouteTakeoverConflict reports the hostnames withheld because another cluster claims them,
+// and the hostnames of the own region another cluster claims with a newer generation.
+// The condition is only present while there is a conflict.
+func setServiceRouteTakeoverConflict(serviceRoute *routingv1alpha1.ServiceRoute, claims *claimResult) {
+	conflicts := append(append([]takeoverConflict(nil), claims.conflicts...), claims.contested...)
+	if len(conflicts) == 0 {
+		meta.RemoveStatusCondition(&serviceRoute.Status.Conditions, consts.ConditionTypeTakeoverConflict)
+		return
+	}
+
+	meta.SetStatusCondition(&serviceRoute.Status.Conditions, metav1.Condition{
+		Type:               consts.ConditionTypeTakeoverConflict,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceRoute.Generation,
+		Reason:             consts.ReasonClaimedByOtherCluster,
+		Message:            takeoverConflictMessage(conflicts),
+	})
+}
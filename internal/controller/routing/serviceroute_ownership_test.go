@@ -0,0 +1,302 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsexport"
+	"github.com/AshwinSarimin/service-router-operator/internal/ownership"
+)
+
+// fakeOwnershipResolver returns the ownership records registered per hostname
+type fakeOwnershipResolver map[string][]ownership.Record
+
+func (f fakeOwnershipResolver) Lookup(_ context.Context, host string) ([]ownership.Record, error) {
+	return f[host], nil
+}
+
+var _ = Describe("Hostname ownership", func() {
+	const sourceHost = "api-ns-d-dev-shop.example.com"
+
+	var (
+		ctx          context.Context
+		reconciler   *ServiceRouteReconciler
+		resolver     fakeOwnershipResolver
+		identity     *clusteridentity.ClusterIdentity
+		config       *dnsconfiguration.DNSConfiguration
+		serviceRoute *routingv1alpha1.ServiceRoute
+		dnsPolicy    *routingv1alpha1.DNSPolicy
+		gateway      *routingv1alpha1.Gateway
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testScheme := runtime.NewScheme()
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+		resolver = fakeOwnershipResolver{}
+		reconciler = &ServiceRouteReconciler{
+			Client:     c,
+			DNSBackend: dnsbackend.NewExternalDNS(c),
+			Ownership:  resolver,
+		}
+
+		identity = &clusteridentity.ClusterIdentity{
+			Region:            "weu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		}
+		config = &dnsconfiguration.DNSConfiguration{
+			ExternalDNSControllers: []dnsconfiguration.ExternalDNSController{
+				{Name: "external-dns-weu", Region: "weu"},
+				{Name: "external-dns-neu", Region: "neu"},
+			},
+		}
+		serviceRoute = &routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: routingv1alpha1.ServiceRouteSpec{
+				ServiceName: "api",
+				Environment: "dev",
+				Application: "shop",
+			},
+		}
+		dnsPolicy = &routingv1alpha1.DNSPolicy{
+			Spec: routingv1alpha1.DNSPolicySpec{Mode: "RegionBound"},
+			Status: routingv1alpha1.DNSPolicyStatus{
+				ActiveControllers: []string{"external-dns-weu", "external-dns-neu"},
+			},
+		}
+		gateway = &routingv1alpha1.Gateway{Spec: routingv1alpha1.GatewaySpec{TargetPostfix: "internal"}}
+	})
+
+	claim := func() *claimResult {
+		recordSets, err := reconciler.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		claims, err := reconciler.claimRecordSets(ctx, serviceRoute, dnsPolicy, identity, recordSets)
+		Expect(err).NotTo(HaveOccurred())
+		return claims
+	}
+
+	ownerValue := func(recordSet *dnsbackend.RecordSet) string {
+		for _, record := range recordSet.Records {
+			if record.RecordType == "TXT" {
+				return record.Targets[0]
+			}
+		}
+		return ""
+	}
+
+	It("should publish an ownership record next to every CNAME", func() {
+		serviceRoute.Spec.Aliases = []string{"shop"}
+
+		recordSets, err := reconciler.generateRecordSets(serviceRoute, dnsPolicy, gateway, identity, nil, config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recordSets[0].Records).To(HaveLen(4))
+		Expect(recordSets[0].Records[2]).To(Equal(dnsbackend.Record{
+			DNSName:    "_router-owner." + sourceHost,
+			RecordType: "TXT",
+			Targets:    []string{"heritage=service-router,cluster=aks,region=weu,mode=RegionBound,generation=1"},
+		}))
+		Expect(recordSets[0].Records[3].DNSName).To(Equal("_router-owner.shop.example.com"))
+
+		status := dnsEndpointStatus(recordSets[0])
+		Expect(status.SourceHost).To(Equal(sourceHost))
+		Expect(status.Aliases).To(Equal([]string{"shop.example.com"}))
+	})
+
+	It("should claim unclaimed hostnames in the zone of another region", func() {
+		claims := claim()
+		Expect(claims.checked).To(BeTrue())
+		Expect(claims.conflicts).To(BeEmpty())
+		Expect(claims.recordSets).To(HaveLen(2))
+	})
+
+	It("should withhold hostnames claimed by a cluster of another region", func() {
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks", Region: "neu", Mode: "Active", Generation: 1}}
+
+		claims := claim()
+		Expect(claims.recordSets).To(HaveLen(1))
+		Expect(claims.recordSets[0].Name).To(Equal("api-external-dns-weu"))
+		Expect(claims.conflicts).To(HaveLen(1))
+
+		setServiceRouteTakeoverConflict(serviceRoute, claims)
+		condition := meta.FindStatusCondition(serviceRoute.Status.Conditions, "TakeoverConflict")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("ClaimedByOtherCluster"))
+		Expect(condition.Message).To(Equal(sourceHost +
+			" (controller external-dns-neu) is claimed by cluster aks in region neu (generation 1)"))
+	})
+
+	It("should take over the hostnames of a failed peer region with the next generation", func() {
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks", Region: "neu", Mode: "Failover", Generation: 1}}
+		dnsPolicy.Spec.Mode = "Failover"
+		dnsPolicy.Status.Peers = []routingv1alpha1.DNSPolicyPeerStatus{{Region: "neu", TakenOver: true}}
+
+		claims := claim()
+		Expect(claims.conflicts).To(BeEmpty())
+		Expect(claims.recordSets).To(HaveLen(2))
+		Expect(ownerValue(claims.recordSets[1])).To(Equal(
+			"heritage=service-router,cluster=aks,region=weu,mode=Failover,generation=2"))
+	})
+
+	It("should yield a published hostname to a newer claim", func() {
+		claims := claim()
+		Expect(reconciler.reconcileRecordSets(ctx, serviceRoute, claims.recordSets)).To(Succeed())
+
+		// Our own claim keeps its generation while no one else claims the hostname
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks", Region: "weu", Mode: "RegionBound", Generation: 1}}
+		Expect(claim().recordSets).To(HaveLen(2))
+
+		resolver[sourceHost] = append(resolver[sourceHost],
+			ownership.Record{Cluster: "aks02", Region: "weu", Mode: "RegionBound", Generation: 2})
+		claims = claim()
+		Expect(claims.recordSets).To(HaveLen(1))
+		Expect(claims.conflicts).To(HaveLen(1))
+		Expect(claims.conflicts[0].holder.Cluster).To(Equal("aks02"))
+
+		// The record set of the own region stays published, the newer claim is reported
+		Expect(claims.recordSets[0].Name).To(Equal("api-external-dns-weu"))
+		Expect(claims.contested).To(HaveLen(1))
+		Expect(claims.contested[0].controller).To(Equal("external-dns-weu"))
+		setServiceRouteTakeoverConflict(serviceRoute, claims)
+		condition := meta.FindStatusCondition(serviceRoute.Status.Conditions, "TakeoverConflict")
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Message).To(ContainSubstring(sourceHost +
+			" (controller external-dns-weu) is published but claimed by cluster aks02 in region weu (generation 2)"))
+	})
+
+	It("should not report an older claim on a hostname of the own region", func() {
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks02", Region: "weu", Mode: "RegionBound", Generation: 1}}
+
+		claims := claim()
+		Expect(claims.contested).To(BeEmpty())
+	})
+
+	It("should publish without checking claims when no resolver is configured", func() {
+		reconciler.Ownership = nil
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks", Region: "neu", Mode: "Active", Generation: 1}}
+
+		claims := claim()
+		Expect(claims.checked).To(BeFalse())
+		Expect(claims.recordSets).To(HaveLen(2))
+	})
+
+	It("should report the takeover conflicts of the policy's ServiceRoutes on the DNSPolicy", func() {
+		policy := &routingv1alpha1.DNSPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+		conflicting := metav1.Condition{
+			Type:    "TakeoverConflict",
+			Status:  metav1.ConditionTrue,
+			Reason:  "ClaimedByOtherCluster",
+			Message: sourceHost + " (controller external-dns-neu) is claimed by cluster aks in region neu (generation 1)",
+		}
+		serviceRoutes := []routingv1alpha1.ServiceRoute{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Status: routingv1alpha1.ServiceRouteStatus{
+					DNSPolicyRef: dnsPolicyReference(policy),
+					Conditions:   []metav1.Condition{conflicting},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Status:     routingv1alpha1.ServiceRouteStatus{DNSPolicyRef: dnsPolicyReference(policy)},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Status: routingv1alpha1.ServiceRouteStatus{
+					DNSPolicyRef: &routingv1alpha1.ServiceRouteDNSPolicyReference{Kind: "ClusterDNSPolicy", Name: "global"},
+					Conditions:   []metav1.Condition{conflicting},
+				},
+			},
+		}
+
+		Expect(takeoverConflicts(policy, serviceRoutes)).To(Equal([]string{
+			"ServiceRoute default/api: " + conflicting.Message,
+		}))
+	})
+
+	It("should leave hostnames claimed by another cluster out of the record export", func() {
+		resolver[sourceHost] = []ownership.Record{{Cluster: "aks", Region: "neu", Mode: "Active", Generation: 1}}
+
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(clusterv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(routingv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		Expect(externaldnsv1alpha1.AddToScheme(testScheme)).To(Succeed())
+		serviceRoute.Spec.GatewayName = "default-gateway"
+		dnsPolicy.ObjectMeta = metav1.ObjectMeta{Name: "policy", Namespace: "default"}
+		dnsPolicy.Status.Active = true
+		gateway.ObjectMeta = metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"}
+		gateway.Spec.Controller = "aks-istio-ingressgateway-internal"
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			serviceRoute, dnsPolicy, gateway,
+			&clusterv1alpha1.ClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+				Spec: clusterv1alpha1.ClusterIdentitySpec{
+					Region: "weu", Cluster: "aks", Domain: "example.com", EnvironmentLetter: "d",
+				},
+			},
+			&clusterv1alpha1.DNSConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "dns-config"},
+				Spec: clusterv1alpha1.DNSConfigurationSpec{
+					ExternalDNSControllers: []clusterv1alpha1.ExternalDNSController{
+						{Name: "external-dns-weu", Region: "weu"},
+						{Name: "external-dns-neu", Region: "neu"},
+					},
+				},
+			},
+		).Build()
+
+		export, err := (&RecordExporter{
+			Client:                        c,
+			DefaultRouterGatewayNamespace: "istio-system",
+			Identity:                      clusteridentity.NewReaderProvider(c),
+			Config:                        dnsconfiguration.NewReaderProvider(c),
+			Ownership:                     resolver,
+		}).Export(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		controllers := map[string]bool{}
+		for _, record := range export.Records {
+			if record.Name == sourceHost {
+				controllers[record.Controller] = true
+			}
+		}
+		Expect(controllers).To(Equal(map[string]bool{"external-dns-weu": true}))
+		Expect(export.Skipped).To(ContainElement(dnsexport.Skipped{
+			Source: "ServiceRoute default/api",
+			Reason: sourceHost + " (controller external-dns-neu) is claimed by cluster aks in region neu (generation 1)",
+		}))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	networkingv1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+)
+
+// generateVirtualService builds the Istio VirtualService that binds the ServiceRoute
+// hostnames to its backend Service through the Istio Gateway generated for the Gateway.
+//
+// The VirtualService lives in the ServiceRoute's namespace and is owned by the ServiceRoute,
+// so it is garbage collected together with the DNSEndpoints.
+func (r *ServiceRouteReconciler) generateVirtualService(
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	gateway *routingv1alpha1.Gateway,
+	hosts []string,
+) *istioclientv1beta1.VirtualService {
+	backend := serviceRoute.Spec.Backend
+
+	httpRoute := &networkingv1beta1.HTTPRoute{
+		Route: []*networkingv1beta1.HTTPRouteDestination{
+			{
+				Destination: &networkingv1beta1.Destination{
+					Host: fmt.Sprintf("%s.%s.svc.cluster.local", backend.ServiceName, serviceRoute.Namespace),
+					Port: &networkingv1beta1.PortSelector{
+						Number: uint32(backend.Port),
+					},
+				},
+			},
+		},
+	}
+
+	for _, prefix := range backend.PathPrefixes {
+		httpRoute.Match = append(httpRoute.Match, &networkingv1beta1.HTTPMatchRequest{
+			Uri: &networkingv1beta1.StringMatch{
+				MatchType: &networkingv1beta1.StringMatch_Prefix{Prefix: prefix},
+			},
+		})
+	}
+
+	if backend.Timeout != nil {
+		httpRoute.Timeout = durationpb.New(backend.Timeout.Duration)
+	}
+
+	if backend.Retries != nil {
+		httpRoute.Retries = &networkingv1beta1.HTTPRetry{
+			Attempts: backend.Retries.Attempts,
+			RetryOn:  backend.Retries.RetryOn,
+		}
+		if backend.Retries.PerTryTimeout != nil {
+			httpRoute.Retries.PerTryTimeout = durationpb.New(backend.Retries.PerTryTimeout.Duration)
+		}
+	}
+
+	return &istioclientv1beta1.VirtualService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1beta1",
+			Kind:       "VirtualService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceRoute.Name,
+			Namespace: serviceRoute.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "service-router-operator",
+				"router.io/serviceroute":       serviceRoute.Name,
+				"router.io/gateway":            gateway.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(serviceRoute, routingv1alpha1.GroupVersion.WithKind("ServiceRoute")),
+			},
+		},
+		Spec: networkingv1beta1.VirtualService{
+			Hosts: hosts,
+			// The Istio Gateway shares the name and namespace of the Gateway resource
+			Gateways: []string{fmt.Sprintf("%s/%s", gateway.Namespace, gateway.Name)},
+			Http:     []*networkingv1beta1.HTTPRoute{httpRoute},
+		},
+	}
+}
+
+// reconcileVirtualService manages the Istio VirtualService resource.
+// An existing VirtualService of the same name that the ServiceRoute does not control is left
+// untouched and reported as a notControlledError.
+func (r *ServiceRouteReconciler) reconcileVirtualService(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	desired *istioclientv1beta1.VirtualService,
+) error {
+	var existing istioclientv1beta1.VirtualService
+	err := r.Get(ctx, client.ObjectKey{
+		Name:      desired.Name,
+		Namespace: desired.Namespace,
+	}, &existing)
+
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Create
+			return r.Create(ctx, desired)
+		}
+		return err
+	}
+
+	if !metav1.IsControlledBy(&existing, serviceRoute) {
+		return &notControlledError{kind: "VirtualService", key: client.ObjectKeyFromObject(&existing)}
+	}
+
+	// Update if needed
+	if r.virtualServiceNeedsUpdate(&existing, desired) {
+		patch := client.MergeFrom(existing.DeepCopy())
+		desired.Spec.DeepCopyInto(&existing.Spec)
+		existing.Labels = desired.Labels
+		return r.Patch(ctx, &existing, patch)
+	}
+
+	return nil
+}
+
+// deleteVirtualService deletes the VirtualService generated for a ServiceRoute if it exists
+func (r *ServiceRouteReconciler) deleteVirtualService(
+	ctx context.Context,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+) error {
+	var existing istioclientv1beta1.VirtualService
+	err := r.Get(ctx, client.ObjectKey{
+		Name:      serviceRoute.Name,
+		Namespace: serviceRoute.Namespace,
+	}, &existing)
+
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Never delete a VirtualService the operator does not own
+	if !metav1.IsControlledBy(&existing, serviceRoute) {
+		return nil
+	}
+
+	return r.Delete(ctx, &existing)
+}
+
+// virtualServiceNeedsUpdate checks if the VirtualService needs updating
+func (r *ServiceRouteReconciler) virtualServiceNeedsUpdate(existing, desired *istioclientv1beta1.VirtualService) bool {
+	if !proto.Equal(&existing.Spec, &desired.Spec) {
+		return true
+	}
+
+	// Compare labels
+	for k, v := range desired.Labels {
+		if existing.Labels[k] != v {
+			return true
+		}
+	}
+
+	return false
+}
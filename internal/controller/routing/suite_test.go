@@ -124,6 +124,7 @@ var _ = BeforeSuite(func() {
 		Client:                        k8sManager.GetClient(),
 		Scheme:                        k8sManager.GetScheme(),
 		DefaultRouterGatewayNamespace: "istio-system",
+		GatewayAPIEnabled:             true,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -133,10 +134,17 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&ClusterDNSPolicyReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&ServiceRouteReconciler{
 		Client:                        k8sManager.GetClient(),
 		Scheme:                        k8sManager.GetScheme(),
 		DefaultRouterGatewayNamespace: "istio-system",
+		GatewayAPIEnabled:             true,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
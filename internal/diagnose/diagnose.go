@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnose explains why a ServiceRoute is or is not serving. A Diagnosis lists every
+// resource the ServiceRoute reconciler depends on, in the order the reconciler reads them,
+// with the first one blocking the route. It is rendered by the kubectl-router plugin.
+package diagnose
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// StatusOK means the hop does not block the ServiceRoute
+	StatusOK = "OK"
+
+	// StatusBlocked means the hop keeps the ServiceRoute from serving
+	StatusBlocked = "Blocked"
+
+	// StatusWaiting means the hop cannot be checked until an earlier hop is unblocked
+	StatusWaiting = "Waiting"
+
+	// none is printed for empty table cells
+	none = "-"
+)
+
+// Hop is one resource in the dependency chain of a ServiceRoute
+type Hop struct {
+	// Name is the step of the chain, e.g. DNSPolicy or LoadBalancer
+	Name string `json:"name"`
+
+	// Resource is the resource checked, e.g. DNSPolicy default/policy, empty when none was found
+	Resource string `json:"resource,omitempty"`
+
+	// Status is OK, Blocked or Waiting
+	Status string `json:"status"`
+
+	// Reason is the condition reason of a blocked hop, as reported by the reconcilers
+	Reason string `json:"reason,omitempty"`
+
+	// Message describes the state of the resource
+	Message string `json:"message"`
+}
+
+// Diagnosis is the dependency chain of a ServiceRoute
+type Diagnosis struct {
+	// ServiceRoute is the namespace/name of the ServiceRoute
+	ServiceRoute string `json:"serviceRoute"`
+
+	// Phase is the phase the ServiceRoute reports
+	Phase string `json:"phase,omitempty"`
+
+	Hops []Hop `json:"hops"`
+}
+
+// AddOK adds a hop that does not block the ServiceRoute
+func (d *Diagnosis) AddOK(name, resource, message string) {
+	d.Hops = append(d.Hops, Hop{Name: name, Resource: resource, Status: StatusOK, Message: message})
+}
+
+// AddBlocked adds a hop that keeps the ServiceRoute from serving
+func (d *Diagnosis) AddBlocked(name, resource, reason, message string) {
+	d.Hops = append(d.Hops, Hop{Name: name, Resource: resource, Status: StatusBlocked, Reason: reason, Message: message})
+}
+
+// AddWaiting adds a hop that depends on an earlier blocked hop
+func (d *Diagnosis) AddWaiting(name, resource, message string) {
+	d.Hops = append(d.Hops, Hop{Name: name, Resource: resource, Status: StatusWaiting, Message: message})
+}
+
+// Blocking returns the first blocked hop, or nil when the ServiceRoute is serving
+func (d *Diagnosis) Blocking() *Hop {
+	for i := range d.Hops {
+		if d.Hops[i].Status == StatusBlocked {
+			return &d.Hops[i]
+		}
+	}
+	return nil
+}
+
+// Write renders the diagnosis as a table of hops followed by the first blocking reason
+func Write(w io.Writer, d *Diagnosis) error {
+	phase := d.Phase
+	if phase == "" {
+		phase = "no phase reported"
+	}
+	if _, err := fmt.Fprintf(w, "ServiceRoute %s (%s)\n\n", d.ServiceRoute, phase); err != nil {
+		return err
+	}
+
+	table := &Table{Headers: []string{"HOP", "RESOURCE", "STATUS", "REASON", "MESSAGE"}}
+	for _, hop := range d.Hops {
+		table.Rows = append(table.Rows, []string{hop.Name, hop.Resource, hop.Status, hop.Reason, hop.Message})
+	}
+	if err := WriteTable(w, table); err != nil {
+		return err
+	}
+
+	blocking := d.Blocking()
+	if blocking == nil {
+		_, err := fmt.Fprintln(w, "\nNothing blocks the ServiceRoute, every hop is OK.")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nBlocked at %s: %s: %s\n", blocking.Name, blocking.Reason, blocking.Message)
+	return err
+}
+
+// Table is a list of rows printed in aligned columns, like kubectl get
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// WriteTable renders a table with aligned columns. Empty cells are printed as "-",
+// line breaks in a cell as spaces so every row stays on one line.
+func WriteTable(w io.Writer, t *Table) error {
+	if len(t.Rows) == 0 {
+		_, err := fmt.Fprintln(w, "No resources found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, strings.Join(t.Headers, "\t")); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(cell, "\n", " ")
+			if cell == "" {
+				cells[i] = none
+			}
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
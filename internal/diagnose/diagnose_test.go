@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+)
+
+func TestWriteReportsFirstBlockingHop(t *testing.T) {
+	diagnosis := &Diagnosis{ServiceRoute: "default/api", Phase: "Pending"}
+	diagnosis.AddOK("DNSPolicy", "DNSPolicy default/policy", "Mode RegionBound")
+	diagnosis.AddBlocked("Active", "DNSPolicy default/policy", "DNSPolicyInactive", "DNSPolicy is not active for this cluster")
+	diagnosis.AddBlocked("Gateway", "Gateway istio-system/default", "GatewayNotFound", "Gateway default not found")
+	diagnosis.AddWaiting("LoadBalancer", "", "Requires the Gateway")
+
+	if blocking := diagnosis.Blocking(); blocking == nil || blocking.Name != "Active" {
+		t.Fatalf("got blocking hop %+v, want Active", blocking)
+	}
+
+	var b strings.Builder
+	if err := Write(&b, diagnosis); err != nil {
+		t.Fatal(err)
+	}
+	want := `ServiceRoute default/api (Pending)
+
+HOP            RESOURCE                       STATUS    REASON              MESSAGE
+DNSPolicy      DNSPolicy default/policy       OK        -                   Mode RegionBound
+Active         DNSPolicy default/policy       Blocked   DNSPolicyInactive   DNSPolicy is not active for this cluster
+Gateway        Gateway istio-system/default   Blocked   GatewayNotFound     Gateway default not found
+LoadBalancer   -                              Waiting   -                   Requires the Gateway
+
+Blocked at Active: DNSPolicyInactive: DNSPolicy is not active for this cluster
+`
+	if b.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteServingRoute(t *testing.T) {
+	diagnosis := &Diagnosis{ServiceRoute: "default/api", Phase: "Active"}
+	diagnosis.AddOK("DNSPolicy", "DNSPolicy default/policy", "Mode Active")
+
+	var b strings.Builder
+	if err := Write(&b, diagnosis); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(b.String(), "\nNothing blocks the ServiceRoute, every hop is OK.\n") {
+		t.Errorf("unexpected output:\n%s", b.String())
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTable(&b, &Table{Headers: []string{"NAME"}}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "No resources found.\n" {
+		t.Errorf("unexpected output for an empty table %q", b.String())
+	}
+
+	b.Reset()
+	if err := WriteTable(&b, &Table{Headers: []string{"NAME", "MESSAGE"}, Rows: [][]string{{"a", "line\nbreak"}, {"b", ""}}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "NAME   MESSAGE\na      line break\nb      -\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestHostsTable(t *testing.T) {
+	serviceRoutes := []routingv1alpha1.ServiceRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Status: routingv1alpha1.ServiceRouteStatus{
+				Phase: "Pending",
+				Conditions: []metav1.Condition{
+					{Type: "Ready", Status: metav1.ConditionFalse, Reason: "DNSPolicyInactive"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Status: routingv1alpha1.ServiceRouteStatus{
+				Phase: "Active",
+				DNSEndpoints: []routingv1alpha1.ServiceRouteDNSEndpoint{{
+					Name:       "api-external-dns-weu",
+					Controller: "external-dns-weu",
+					SourceHost: "api-ns-d-dev-shop.example.com",
+					Aliases:    []string{"shop.example.com"},
+					TargetHost: "aks-weu-internal.example.com",
+				}},
+			},
+		},
+	}
+
+	table := HostsTable(serviceRoutes)
+	want := [][]string{
+		{"shop", "api", "api-ns-d-dev-shop.example.com", "aks-weu-internal.example.com", "external-dns-weu", "Active", ""},
+		{"shop", "api", "shop.example.com", "aks-weu-internal.example.com", "external-dns-weu", "Active", ""},
+		{"shop", "web", "", "", "", "Pending", "DNSPolicyInactive"},
+	}
+	if len(table.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(table.Rows), len(want), table.Rows)
+	}
+	for i := range want {
+		if strings.Join(table.Rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d: got %v, want %v", i, table.Rows[i], want[i])
+		}
+	}
+}
+
+func TestPoliciesTable(t *testing.T) {
+	table := PoliciesTable(
+		[]routingv1alpha1.DNSPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "shop"},
+			Spec:       routingv1alpha1.DNSPolicySpec{Mode: "RegionBound"},
+			Status: routingv1alpha1.DNSPolicyStatus{
+				Phase:             "Active",
+				Active:            true,
+				ActiveControllers: []string{"external-dns-weu", "external-dns-neu"},
+			},
+		}},
+		[]routingv1alpha1.ClusterDNSPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			Spec:       routingv1alpha1.ClusterDNSPolicySpec{DNSPolicySpec: routingv1alpha1.DNSPolicySpec{Mode: "Active"}},
+			Status:     routingv1alpha1.DNSPolicyStatus{Phase: "Inactive"},
+		}},
+	)
+
+	want := []string{
+		"DNSPolicy|shop|policy|RegionBound|Active|true|external-dns-weu,external-dns-neu|",
+		"ClusterDNSPolicy||global|Active|Inactive|false||",
+	}
+	if len(table.Rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(table.Rows), len(want))
+	}
+	for i, row := range table.Rows {
+		if got := strings.Join(row, "|"); got != want[i] {
+			t.Errorf("row %d: got %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// HostsTable lists every hostname the ServiceRoutes publish, one row per DNSEndpoint.
+// A ServiceRoute publishing nothing is listed once, with the reason of its Ready condition.
+func HostsTable(serviceRoutes []routingv1alpha1.ServiceRoute) *Table {
+	table := &Table{Headers: []string{"NAMESPACE", "SERVICEROUTE", "HOST", "TARGET", "CONTROLLER", "PHASE", "REASON"}}
+	for _, route := range sortedByName(serviceRoutes) {
+		reason := readyReason(route.Status.Conditions)
+		if len(route.Status.DNSEndpoints) == 0 {
+			table.Rows = append(table.Rows, []string{route.Namespace, route.Name, "", "", "", route.Status.Phase, reason})
+			continue
+		}
+		for _, endpoint := range route.Status.DNSEndpoints {
+			hosts := append([]string{endpoint.SourceHost}, endpoint.Aliases...)
+			for _, host := range hosts {
+				table.Rows = append(table.Rows, []string{
+					route.Namespace, route.Name, host, endpoint.TargetHost, endpoint.Controller, route.Status.Phase, reason,
+				})
+			}
+		}
+	}
+	return table
+}
+
+// GatewaysTable lists the Gateways with their implementation and LoadBalancer IP
+func GatewaysTable(gateways []routingv1alpha1.Gateway) *Table {
+	table := &Table{Headers: []string{
+		"NAMESPACE", "NAME", "IMPLEMENTATION", "CONTROLLER", "TARGET POSTFIX", "PHASE", "LOADBALANCER IP", "REASON",
+	}}
+	for _, gateway := range sortedByName(gateways) {
+		implementation := gateway.Spec.Implementation
+		if implementation == "" {
+			implementation = consts.ImplementationIstio
+		}
+		table.Rows = append(table.Rows, []string{
+			gateway.Namespace,
+			gateway.Name,
+			implementation,
+			gateway.Spec.Controller,
+			gateway.Spec.TargetPostfix,
+			gateway.Status.Phase,
+			gateway.Status.LoadBalancerIP,
+			readyReason(gateway.Status.Conditions),
+		})
+	}
+	return table
+}
+
+// PoliciesTable lists the DNSPolicies followed by the ClusterDNSPolicies, with the controllers they publish to
+func PoliciesTable(dnsPolicies []routingv1alpha1.DNSPolicy, clusterPolicies []routingv1alpha1.ClusterDNSPolicy) *Table {
+	table := &Table{Headers: []string{"KIND", "NAMESPACE", "NAME", "MODE", "PHASE", "ACTIVE", "CONTROLLERS", "REASON"}}
+	policyRow := func(kind string, object metav1.ObjectMeta, spec routingv1alpha1.DNSPolicySpec, status routingv1alpha1.DNSPolicyStatus) []string {
+		return []string{
+			kind,
+			object.Namespace,
+			object.Name,
+			spec.Mode,
+			status.Phase,
+			strconv.FormatBool(status.Active),
+			strings.Join(status.ActiveControllers, ","),
+			readyReason(status.Conditions),
+		}
+	}
+
+	for _, policy := range sortedByName(dnsPolicies) {
+		table.Rows = append(table.Rows, policyRow("DNSPolicy", policy.ObjectMeta, policy.Spec, policy.Status))
+	}
+	for _, policy := range sortedByName(clusterPolicies) {
+		table.Rows = append(table.Rows, policyRow("ClusterDNSPolicy", policy.ObjectMeta, policy.Spec.DNSPolicySpec, policy.Status))
+	}
+	return table
+}
+
+// readyReason returns the reason of the Ready condition, empty without one
+func readyReason(conditions []metav1.Condition) string {
+	if condition := meta.FindStatusCondition(conditions, consts.ConditionTypeReady); condition != nil {
+		return condition.Reason
+	}
+	return ""
+}
+
+// sortedByName returns the items ordered by namespace and name
+func sortedByName[T any, PT interface {
+	*T
+	metav1.Object
+}](items []T) []T {
+	sorted := append([]T(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := PT(&sorted[i]), PT(&sorted[j])
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return sorted
+}
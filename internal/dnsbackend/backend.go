@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsbackend publishes the DNS records generated by the reconcilers.
+// The reconcilers describe the records they want as RecordSets and a DNSBackend
+// makes them visible to DNS, either through ExternalDNS DNSEndpoint resources
+// or directly on an authoritative server with RFC2136 dynamic updates.
+package dnsbackend
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BackendExternalDNS publishes records as ExternalDNS DNSEndpoint resources
+	BackendExternalDNS = "externaldns"
+
+	// BackendRFC2136 publishes records with RFC2136 dynamic updates
+	BackendRFC2136 = "rfc2136"
+)
+
+// Record is a DNS record set of one name and type
+type Record struct {
+	// DNSName is the fully qualified name of the record
+	DNSName string `json:"dnsName"`
+
+	// RecordType is the record type (A, AAAA, CNAME or TXT)
+	RecordType string `json:"recordType"`
+
+	// Targets are the record values
+	Targets []string `json:"targets"`
+
+	// TTL is the record TTL in seconds, 0 leaves the choice to the backend
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// RecordSet is a group of records published and withdrawn together.
+// The ExternalDNS backend stores a RecordSet as a single DNSEndpoint,
+// so Name, Namespace, Labels and Annotations follow Kubernetes object rules.
+type RecordSet struct {
+	Name            string
+	Namespace       string
+	Labels          map[string]string
+	Annotations     map[string]string
+	OwnerReferences []metav1.OwnerReference
+	Records         []Record
+
+	// Generation is the revision of the published RecordSet, set by List
+	Generation int64
+
+	// ObservedGeneration is the revision the DNS provider has processed, set by List.
+	// Backends that write to DNS directly leave both generations 0.
+	ObservedGeneration int64
+}
+
+// Key returns the namespace/name of the RecordSet
+func (s *RecordSet) Key() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// Synced reports whether the DNS provider has processed the current revision of a listed RecordSet
+func (s *RecordSet) Synced() bool {
+	return s.ObservedGeneration >= s.Generation
+}
+
+// DNSBackend publishes RecordSets to DNS
+type DNSBackend interface {
+	// List returns the published RecordSets in namespace that carry all of the given labels.
+	// An empty namespace lists RecordSets in all namespaces.
+	List(ctx context.Context, namespace string, labels map[string]string) ([]*RecordSet, error)
+
+	// Create publishes a new RecordSet
+	Create(ctx context.Context, desired *RecordSet) error
+
+	// Update replaces the published existing RecordSet with desired
+	Update(ctx context.Context, existing, desired *RecordSet) error
+
+	// Delete withdraws a published RecordSet
+	Delete(ctx context.Context, existing *RecordSet) error
+}
+
+// SyncResult lists the names of the RecordSets changed by Sync
+type SyncResult struct {
+	Created []string
+	Updated []string
+	Deleted []string
+}
+
+// Changed reports whether Sync changed any RecordSet
+func (r *SyncResult) Changed() bool {
+	return len(r.Created)+len(r.Updated)+len(r.Deleted) > 0
+}
+
+// Sync makes the published RecordSets match desired.
+// existing holds the RecordSets currently published for the same owner, as returned by List:
+// RecordSets only in desired are created, changed ones are updated and the rest is deleted.
+// The result lists the changes made before an error, if any.
+func Sync(ctx context.Context, backend DNSBackend, existing, desired []*RecordSet) (*SyncResult, error) {
+	result := &SyncResult{}
+
+	existingMap := make(map[string]*RecordSet, len(existing))
+	for _, set := range existing {
+		existingMap[set.Key()] = set
+	}
+
+	desiredMap := make(map[string]*RecordSet, len(desired))
+	for _, set := range desired {
+		desiredMap[set.Key()] = set
+
+		current, exists := existingMap[set.Key()]
+		if !exists {
+			if err := backend.Create(ctx, set); err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, set.Name)
+			continue
+		}
+		if !Equal(current, set) {
+			if err := backend.Update(ctx, current, set); err != nil {
+				return result, err
+			}
+			result.Updated = append(result.Updated, set.Name)
+		}
+	}
+
+	for key, set := range existingMap {
+		if _, desired := desiredMap[key]; !desired {
+			if err := backend.Delete(ctx, set); err != nil {
+				return result, err
+			}
+			result.Deleted = append(result.Deleted, set.Name)
+		}
+	}
+	sort.Strings(result.Deleted)
+
+	return result, nil
+}
+
+// Equal reports whether two RecordSets publish the same records with the same metadata.
+// The generations are not compared.
+func Equal(a, b *RecordSet) bool {
+	return reflect.DeepEqual(normalizeRecords(a.Records), normalizeRecords(b.Records)) &&
+		mapsEqual(a.Labels, b.Labels) &&
+		mapsEqual(a.Annotations, b.Annotations)
+}
+
+// normalizeRecords orders records by name and type and treats nil and empty target lists the same
+func normalizeRecords(records []Record) []Record {
+	normalized := make([]Record, 0, len(records))
+	for _, record := range records {
+		if len(record.Targets) == 0 {
+			record.Targets = nil
+		}
+		normalized = append(normalized, record)
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		if normalized[i].DNSName != normalized[j].DNSName {
+			return normalized[i].DNSName < normalized[j].DNSName
+		}
+		return normalized[i].RecordType < normalized[j].RecordType
+	})
+	return normalized
+}
+
+// mapsEqual compares two string maps, treating nil and empty maps the same
+func mapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Config selects and configures the DNS backend of the operator
+type Config struct {
+	// Backend is externaldns (default) or rfc2136
+	Backend string
+
+	// RFC2136Server is the host:port of the authoritative server
+	RFC2136Server string
+
+	// RFC2136Zone is the zone the records are published in
+	RFC2136Zone string
+
+	// RFC2136Net is the transport, udp or tcp
+	RFC2136Net string
+
+	// TSIGKeyName is the name of the TSIG key, empty sends unsigned updates
+	TSIGKeyName string
+
+	// TSIGAlgorithm is hmac-sha1, hmac-sha256 or hmac-sha512
+	TSIGAlgorithm string
+
+	// TSIGSecretFile is the path of a file holding the base64 encoded TSIG secret
+	TSIGSecretFile string
+}
+
+// BindFlags registers the flags selecting and configuring the DNS backend.
+// The manager and the commands reading its records share them.
+func (c *Config) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Backend, "dns-backend", BackendExternalDNS,
+		"How DNS records are published: externaldns (DNSEndpoint resources) or rfc2136 (dynamic updates).")
+	fs.StringVar(&c.RFC2136Server, "rfc2136-server", "",
+		"The host:port of the authoritative DNS server receiving dynamic updates.")
+	fs.StringVar(&c.RFC2136Zone, "rfc2136-zone", "", "The zone records are published in with dynamic updates.")
+	fs.StringVar(&c.RFC2136Net, "rfc2136-net", "udp", "The transport for dynamic updates, udp or tcp.")
+	fs.StringVar(&c.TSIGKeyName, "rfc2136-tsig-key-name", "",
+		"The TSIG key name used to sign dynamic updates. Updates are unsigned when empty.")
+	fs.StringVar(&c.TSIGAlgorithm, "rfc2136-tsig-algorithm", "hmac-sha256",
+		"The TSIG algorithm: hmac-sha1, hmac-sha256 or hmac-sha512.")
+	fs.StringVar(&c.TSIGSecretFile, "rfc2136-tsig-secret-file", "",
+		"The file holding the base64 encoded TSIG secret.")
+}
+
+// New returns the DNS backend selected by cfg
+func New(c client.Client, cfg Config) (DNSBackend, error) {
+	switch cfg.Backend {
+	case "", BackendExternalDNS:
+		return NewExternalDNS(c), nil
+	case BackendRFC2136:
+		return newRFC2136FromConfig(c, cfg)
+	}
+	return nil, fmt.Errorf("unknown DNS backend %q, must be %s or %s", cfg.Backend, BackendExternalDNS, BackendRFC2136)
+}
+
+// newRFC2136FromConfig validates the RFC2136 settings and loads the TSIG secret
+func newRFC2136FromConfig(c client.Client, cfg Config) (*RFC2136, error) {
+	if cfg.RFC2136Server == "" {
+		return nil, fmt.Errorf("the rfc2136 DNS backend requires a server")
+	}
+	if cfg.RFC2136Zone == "" {
+		return nil, fmt.Errorf("the rfc2136 DNS backend requires a zone")
+	}
+	if cfg.RFC2136Net != "" && cfg.RFC2136Net != "udp" && cfg.RFC2136Net != "tcp" {
+		return nil, fmt.Errorf("invalid rfc2136 transport %q, must be udp or tcp", cfg.RFC2136Net)
+	}
+
+	backend := &RFC2136{
+		Client: c,
+		Server: cfg.RFC2136Server,
+		Zone:   cfg.RFC2136Zone,
+		Net:    cfg.RFC2136Net,
+	}
+
+	if cfg.TSIGKeyName == "" {
+		return backend, nil
+	}
+
+	if cfg.TSIGSecretFile == "" {
+		return nil, fmt.Errorf("TSIG key %s requires a secret file", cfg.TSIGKeyName)
+	}
+	raw, err := os.ReadFile(cfg.TSIGSecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TSIG secret: %w", err)
+	}
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("TSIG secret is not valid base64: %w", err)
+	}
+
+	backend.TSIG = &TSIG{
+		KeyName:   cfg.TSIGKeyName,
+		Algorithm: cfg.TSIGAlgorithm,
+		Secret:    secret,
+	}
+	if _, err := backend.TSIG.algorithm(); err != nil {
+		return nil, err
+	}
+
+	return backend, nil
+}
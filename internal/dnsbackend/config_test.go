@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+func TestNew(t *testing.T) {
+	c := testutil.NewFakeClient(t, testSchemes)
+
+	backend, err := New(c, Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := backend.(*ExternalDNS); !ok {
+		t.Errorf("expected the ExternalDNS backend by default, got %T", backend)
+	}
+
+	if _, err := New(c, Config{Backend: "route53"}); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+	if _, err := New(c, Config{Backend: BackendRFC2136, RFC2136Zone: "example.com"}); err == nil {
+		t.Error("expected error for missing server")
+	}
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("c2VjcmV0\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+	backend, err = New(c, Config{
+		Backend:        BackendRFC2136,
+		RFC2136Server:  "127.0.0.1:53",
+		RFC2136Zone:    "example.com",
+		TSIGKeyName:    "operator-key",
+		TSIGAlgorithm:  "hmac-sha512",
+		TSIGSecretFile: secretFile,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rfc2136, ok := backend.(*RFC2136)
+	if !ok || string(rfc2136.TSIG.Secret) != "secret" {
+		t.Errorf("expected an RFC2136 backend with the decoded secret, got %T", backend)
+	}
+
+	if _, err := New(c, Config{
+		Backend:        BackendRFC2136,
+		RFC2136Server:  "127.0.0.1:53",
+		RFC2136Zone:    "example.com",
+		TSIGKeyName:    "operator-key",
+		TSIGAlgorithm:  "hmac-md5",
+		TSIGSecretFile: secretFile,
+	}); err == nil {
+		t.Error("expected error for unsupported TSIG algorithm")
+	}
+}
+
+func TestConfigBindFlags(t *testing.T) {
+	var cfg Config
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.BindFlags(fs)
+	if err := fs.Parse([]string{"--dns-backend=rfc2136", "--rfc2136-server=127.0.0.1:53"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Config{
+		Backend:       BackendRFC2136,
+		RFC2136Server: "127.0.0.1:53",
+		RFC2136Net:    "udp",
+		TSIGAlgorithm: "hmac-sha256",
+	}
+	if cfg != expected {
+		t.Errorf("expected %+v, got %+v", expected, cfg)
+	}
+}
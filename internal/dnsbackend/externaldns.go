@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+	externaldnsendpoint "sigs.k8s.io/external-dns/endpoint"
+)
+
+// ExternalDNS publishes RecordSets as ExternalDNS DNSEndpoint resources.
+// ExternalDNS picks them up and writes the records to the DNS provider.
+type ExternalDNS struct {
+	Client client.Client
+}
+
+// NewExternalDNS returns a DNSBackend that writes DNSEndpoint resources
+func NewExternalDNS(c client.Client) *ExternalDNS {
+	return &ExternalDNS{Client: c}
+}
+
+// List returns the DNSEndpoints matching labels as RecordSets
+func (b *ExternalDNS) List(ctx context.Context, namespace string, labels map[string]string) ([]*RecordSet, error) {
+	opts := []client.ListOption{client.MatchingLabels(labels)}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	var list externaldnsv1alpha1.DNSEndpointList
+	if err := b.Client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+
+	sets := make([]*RecordSet, 0, len(list.Items))
+	for i := range list.Items {
+		sets = append(sets, recordSetFromDNSEndpoint(&list.Items[i]))
+	}
+	return sets, nil
+}
+
+// Create creates the DNSEndpoint for the RecordSet
+func (b *ExternalDNS) Create(ctx context.Context, desired *RecordSet) error {
+	return b.Client.Create(ctx, dnsEndpointFromRecordSet(desired))
+}
+
+// Update patches the DNSEndpoint of the RecordSet to the desired records and metadata
+func (b *ExternalDNS) Update(ctx context.Context, existing, desired *RecordSet) error {
+	var dnsEndpoint externaldnsv1alpha1.DNSEndpoint
+	if err := b.Client.Get(ctx, types.NamespacedName{Name: existing.Name, Namespace: existing.Namespace}, &dnsEndpoint); err != nil {
+		if apierrors.IsNotFound(err) {
+			return b.Create(ctx, desired)
+		}
+		return err
+	}
+
+	want := dnsEndpointFromRecordSet(desired)
+	patch := client.MergeFrom(dnsEndpoint.DeepCopy())
+	dnsEndpoint.Spec = want.Spec
+	dnsEndpoint.Labels = want.Labels
+	dnsEndpoint.Annotations = want.Annotations
+	return b.Client.Patch(ctx, &dnsEndpoint, patch)
+}
+
+// Delete deletes the DNSEndpoint of the RecordSet
+func (b *ExternalDNS) Delete(ctx context.Context, existing *RecordSet) error {
+	return client.IgnoreNotFound(b.Client.Delete(ctx, &externaldnsv1alpha1.DNSEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      existing.Name,
+			Namespace: existing.Namespace,
+		},
+	}))
+}
+
+// dnsEndpointFromRecordSet builds the DNSEndpoint that publishes a RecordSet
+func dnsEndpointFromRecordSet(set *RecordSet) *externaldnsv1alpha1.DNSEndpoint {
+	endpoints := make([]*externaldnsendpoint.Endpoint, 0, len(set.Records))
+	for _, record := range set.Records {
+		endpoints = append(endpoints, &externaldnsendpoint.Endpoint{
+			DNSName:    record.DNSName,
+			RecordType: record.RecordType,
+			Targets:    externaldnsendpoint.Targets(record.Targets),
+			RecordTTL:  externaldnsendpoint.TTL(record.TTL),
+		})
+	}
+
+	return &externaldnsv1alpha1.DNSEndpoint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "externaldns.k8s.io/v1alpha1",
+			Kind:       "DNSEndpoint",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            set.Name,
+			Namespace:       set.Namespace,
+			Labels:          set.Labels,
+			Annotations:     set.Annotations,
+			OwnerReferences: set.OwnerReferences,
+		},
+		Spec: externaldnsv1alpha1.DNSEndpointSpec{
+			Endpoints: endpoints,
+		},
+	}
+}
+
+// recordSetFromDNSEndpoint converts a DNSEndpoint back into the RecordSet it publishes
+func recordSetFromDNSEndpoint(dnsEndpoint *externaldnsv1alpha1.DNSEndpoint) *RecordSet {
+	records := make([]Record, 0, len(dnsEndpoint.Spec.Endpoints))
+	for _, endpoint := range dnsEndpoint.Spec.Endpoints {
+		if endpoint == nil {
+			continue
+		}
+		records = append(records, Record{
+			DNSName:    endpoint.DNSName,
+			RecordType: endpoint.RecordType,
+			Targets:    []string(endpoint.Targets),
+			TTL:        int64(endpoint.RecordTTL),
+		})
+	}
+
+	return &RecordSet{
+		Name:            dnsEndpoint.Name,
+		Namespace:       dnsEndpoint.Namespace,
+		Labels:          dnsEndpoint.Labels,
+		Annotations:     dnsEndpoint.Annotations,
+		OwnerReferences: dnsEndpoint.OwnerReferences,
+		Records:         records,
+
+		Generation:         dnsEndpoint.Generation,
+		ObservedGeneration: dnsEndpoint.Status.ObservedGeneration,
+	}
+}
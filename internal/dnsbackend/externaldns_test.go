@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+// testSchemes are the API groups of the fake clients of the tests
+var testSchemes = runtime.SchemeBuilder{corev1.AddToScheme, externaldnsv1alpha1.AddToScheme}
+
+func TestExternalDNSSync(t *testing.T) {
+	backend := NewExternalDNS(testutil.NewFakeClient(t, testSchemes))
+	ctx := context.Background()
+	labels := map[string]string{"router.io/serviceroute": "api"}
+
+	neu := &RecordSet{
+		Name:        "api-external-dns-neu",
+		Namespace:   "default",
+		Labels:      labels,
+		Annotations: map[string]string{"external-dns.alpha.kubernetes.io/controller": "external-dns-neu"},
+		Records: []Record{
+			{DNSName: "api.example.com", RecordType: "CNAME", Targets: []string{"aks-neu-internal.example.com"}},
+		},
+	}
+	weu := &RecordSet{
+		Name:      "api-external-dns-weu",
+		Namespace: "default",
+		Labels:    labels,
+		Records:   neu.Records,
+	}
+
+	result, err := Sync(ctx, backend, nil, []*RecordSet{neu, weu})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Created, []string{neu.Name, weu.Name}) || len(result.Updated)+len(result.Deleted) > 0 {
+		t.Errorf("unexpected sync result %+v", result)
+	}
+
+	existing, err := backend.List(ctx, "default", labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(existing) != 2 {
+		t.Fatalf("expected 2 DNSEndpoints, got %d", len(existing))
+	}
+
+	// Retarget the first RecordSet and drop the second one
+	changed := *neu
+	changed.Records = []Record{
+		{DNSName: "api.example.com", RecordType: "CNAME", Targets: []string{"aks-weu-internal.example.com"}},
+	}
+	result, err = Sync(ctx, backend, existing, []*RecordSet{&changed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Updated, []string{neu.Name}) || !reflect.DeepEqual(result.Deleted, []string{weu.Name}) {
+		t.Errorf("unexpected sync result %+v", result)
+	}
+
+	// A second sync has nothing to change
+	existing, err = backend.List(ctx, "default", labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result, err = Sync(ctx, backend, existing, []*RecordSet{&changed}); err != nil || result.Changed() {
+		t.Errorf("expected no changes, got %+v, %v", result, err)
+	}
+
+	var dnsEndpoint externaldnsv1alpha1.DNSEndpoint
+	if err := backend.Client.Get(ctx, types.NamespacedName{Name: neu.Name, Namespace: "default"}, &dnsEndpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := dnsEndpoint.Spec.Endpoints[0].Targets[0]; got != "aks-weu-internal.example.com" {
+		t.Errorf("DNSEndpoint was not updated: target %s", got)
+	}
+	if dnsEndpoint.Annotations["external-dns.alpha.kubernetes.io/controller"] != "external-dns-neu" {
+		t.Errorf("DNSEndpoint annotations were not kept: %v", dnsEndpoint.Annotations)
+	}
+
+	remaining, err := backend.List(ctx, "default", labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != neu.Name {
+		t.Errorf("expected only %s to remain, got %d DNSEndpoints", neu.Name, len(remaining))
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := &RecordSet{
+		Records: []Record{
+			{DNSName: "b.example.com", RecordType: "CNAME", Targets: []string{"t.example.com"}},
+			{DNSName: "a.example.com", RecordType: "CNAME", Targets: []string{"t.example.com"}},
+		},
+	}
+	b := &RecordSet{
+		Labels: map[string]string{},
+		Records: []Record{
+			{DNSName: "a.example.com", RecordType: "CNAME", Targets: []string{"t.example.com"}},
+			{DNSName: "b.example.com", RecordType: "CNAME", Targets: []string{"t.example.com"}},
+		},
+	}
+	if !Equal(a, b) {
+		t.Error("expected RecordSets with reordered records to be equal")
+	}
+
+	b.Labels["router.io/region"] = "neu"
+	if Equal(a, b) {
+		t.Error("expected RecordSets with different labels to differ")
+	}
+}
+
+func TestExternalDNSListGenerations(t *testing.T) {
+	backend := NewExternalDNS(testutil.NewFakeClient(t, testSchemes))
+	ctx := context.Background()
+	labels := map[string]string{"router.io/serviceroute": "api"}
+
+	dnsEndpoint := &externaldnsv1alpha1.DNSEndpoint{}
+	dnsEndpoint.Name = "api-external-dns-weu"
+	dnsEndpoint.Namespace = "default"
+	dnsEndpoint.Labels = labels
+	dnsEndpoint.Generation = 2
+	dnsEndpoint.Status.ObservedGeneration = 1
+	if err := backend.Client.Create(ctx, dnsEndpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sets, err := backend.List(ctx, "default", labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 1 {
+		t.Fatalf("expected 1 DNSEndpoint, got %d", len(sets))
+	}
+	if sets[0].Synced() {
+		t.Errorf("expected generation %d observed at %d not to be synced", sets[0].Generation, sets[0].ObservedGeneration)
+	}
+
+	sets[0].ObservedGeneration = sets[0].Generation
+	if !sets[0].Synced() {
+		t.Error("expected the observed generation to be synced")
+	}
+}
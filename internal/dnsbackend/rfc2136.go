@@ -0,0 +1,467 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BackendLabel marks the ConfigMaps that record RecordSets published by the RFC2136 backend
+	BackendLabel = "router.io/dns-backend"
+
+	// recordsKey is the ConfigMap key holding the published records
+	recordsKey = "records"
+
+	// configMapPrefix keeps the ConfigMaps recording RecordSets apart from the user's own ConfigMaps
+	configMapPrefix = "rfc2136-"
+
+	// defaultRFC2136TTL is used for records without a TTL
+	defaultRFC2136TTL = 300
+
+	// defaultRFC2136Timeout bounds a single update exchange with the server
+	defaultRFC2136Timeout = 10 * time.Second
+
+	// tsigFudge is the permitted clock skew in seconds
+	tsigFudge = 300
+)
+
+// recordTypes maps the supported record types to their DNS types
+var recordTypes = map[string]uint16{
+	"A":     dns.TypeA,
+	"AAAA":  dns.TypeAAAA,
+	"CNAME": dns.TypeCNAME,
+	"TXT":   dns.TypeTXT,
+}
+
+// tsigAlgorithms maps the supported TSIG algorithm names to their DNS names
+var tsigAlgorithms = map[string]string{
+	"hmac-sha1":   dns.HmacSHA1,
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// TSIG holds the key used to sign dynamic updates
+type TSIG struct {
+	// KeyName is the name of the key as configured on the server
+	KeyName string
+
+	// Algorithm is hmac-sha1, hmac-sha256 or hmac-sha512
+	Algorithm string
+
+	// Secret is the raw (base64 decoded) key material
+	Secret []byte
+}
+
+// algorithm returns the DNS name of the TSIG algorithm, hmac-sha256 when none is set
+func (t *TSIG) algorithm() (string, error) {
+	name := strings.TrimSuffix(strings.ToLower(t.Algorithm), ".")
+	if name == "" {
+		name = "hmac-sha256"
+	}
+	algorithm, ok := tsigAlgorithms[name]
+	if !ok {
+		return "", fmt.Errorf("unsupported TSIG algorithm %q", t.Algorithm)
+	}
+	return algorithm, nil
+}
+
+// RFC2136 publishes RecordSets directly on an authoritative DNS server with
+// RFC2136 dynamic updates, optionally signed with TSIG. It is meant for clusters
+// that cannot run ExternalDNS.
+//
+// The server cannot be asked which records the operator published, so every
+// published RecordSet is also recorded in a ConfigMap named after the RecordSet
+// with an rfc2136- prefix. A ConfigMap without the backend label is never changed.
+// The ConfigMaps carry no owner references: they must outlive their owner until
+// the operator has withdrawn the records from the server.
+type RFC2136 struct {
+	Client client.Client
+
+	// Server is the host:port of the authoritative server
+	Server string
+
+	// Zone is the zone the records are published in
+	Zone string
+
+	// TSIG signs the updates, nil sends unsigned updates
+	TSIG *TSIG
+
+	// Net is the transport, udp (default) or tcp
+	Net string
+
+	// Timeout bounds a single update exchange, defaults to 10s
+	Timeout time.Duration
+}
+
+// List returns the RecordSets recorded in ConfigMaps matching labels
+func (b *RFC2136) List(ctx context.Context, namespace string, labels map[string]string) ([]*RecordSet, error) {
+	selector := client.MatchingLabels{BackendLabel: BackendRFC2136}
+	for k, v := range labels {
+		selector[k] = v
+	}
+
+	opts := []client.ListOption{selector}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	var list corev1.ConfigMapList
+	if err := b.Client.List(ctx, &list, opts...); err != nil {
+		return nil, err
+	}
+
+	sets := make([]*RecordSet, 0, len(list.Items))
+	for i := range list.Items {
+		set, err := recordSetFromConfigMap(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// Create publishes the records on the server and records the RecordSet
+func (b *RFC2136) Create(ctx context.Context, desired *RecordSet) error {
+	// Refuse before publishing, records without a ConfigMap could never be withdrawn
+	if _, err := b.getConfigMap(ctx, desired); err != nil {
+		return err
+	}
+	if err := b.update(ctx, desired.Key(), nil, desired.Records); err != nil {
+		return err
+	}
+	return b.saveConfigMap(ctx, desired)
+}
+
+// Update replaces the published records of existing with the desired ones
+func (b *RFC2136) Update(ctx context.Context, existing, desired *RecordSet) error {
+	if _, err := b.getConfigMap(ctx, desired); err != nil {
+		return err
+	}
+	if err := b.update(ctx, desired.Key(), existing.Records, desired.Records); err != nil {
+		return err
+	}
+	return b.saveConfigMap(ctx, desired)
+}
+
+// Delete withdraws the records from the server and removes the RecordSet record
+func (b *RFC2136) Delete(ctx context.Context, existing *RecordSet) error {
+	configMap, err := b.getConfigMap(ctx, existing)
+	if err != nil {
+		return err
+	}
+	if err := b.update(ctx, existing.Key(), existing.Records, nil); err != nil {
+		return err
+	}
+	if configMap == nil {
+		return nil
+	}
+	return client.IgnoreNotFound(b.Client.Delete(ctx, configMap, client.Preconditions{UID: &configMap.UID}))
+}
+
+// getConfigMap returns the ConfigMap recording a RecordSet, or nil if there is none.
+// It fails if a ConfigMap with the same name exists that the backend did not create.
+func (b *RFC2136) getConfigMap(ctx context.Context, set *RecordSet) (*corev1.ConfigMap, error) {
+	var configMap corev1.ConfigMap
+	key := client.ObjectKey{Name: configMapName(set.Name), Namespace: set.Namespace}
+	if err := b.Client.Get(ctx, key, &configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if configMap.Labels[BackendLabel] != BackendRFC2136 {
+		return nil, fmt.Errorf("ConfigMap %s/%s exists but is not managed by the %s DNS backend",
+			configMap.Namespace, configMap.Name, BackendRFC2136)
+	}
+	return &configMap, nil
+}
+
+// saveConfigMap writes the desired RecordSet to its ConfigMap
+func (b *RFC2136) saveConfigMap(ctx context.Context, desired *RecordSet) error {
+	want, err := configMapFromRecordSet(desired)
+	if err != nil {
+		return err
+	}
+
+	configMap, err := b.getConfigMap(ctx, desired)
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		return b.Client.Create(ctx, want)
+	}
+
+	patch := client.MergeFromWithOptions(configMap.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	configMap.Labels = want.Labels
+	configMap.Annotations = want.Annotations
+	configMap.Data = want.Data
+	return b.Client.Patch(ctx, configMap, patch)
+}
+
+// rrsetKey identifies a DNS RRset
+type rrsetKey struct {
+	name  string
+	rtype string
+}
+
+// update sends one UPDATE that removes the RRsets only in previous and replaces the RRsets in desired.
+// RRsets still published by another RecordSet, identified by its key, are never removed.
+func (b *RFC2136) update(ctx context.Context, key string, previous, desired []Record) error {
+	msg := new(dns.Msg)
+	msg.SetUpdate(dns.Fqdn(b.Zone))
+
+	wanted := make(map[rrsetKey]bool, len(desired))
+	for _, record := range desired {
+		wanted[rrsetKey{name: dns.Fqdn(strings.ToLower(record.DNSName)), rtype: record.RecordType}] = true
+	}
+
+	var withdrawn []rrsetKey
+	for _, record := range previous {
+		k := rrsetKey{name: dns.Fqdn(strings.ToLower(record.DNSName)), rtype: record.RecordType}
+		if !wanted[k] {
+			withdrawn = append(withdrawn, k)
+		}
+	}
+
+	if len(withdrawn) > 0 {
+		shared, err := b.sharedRRsets(ctx, key)
+		if err != nil {
+			return err
+		}
+		for _, k := range withdrawn {
+			if shared[k] {
+				continue
+			}
+			if err := b.addDelete(msg, k.name, k.rtype); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, record := range desired {
+		name := dns.Fqdn(strings.ToLower(record.DNSName))
+		if err := b.addDelete(msg, name, record.RecordType); err != nil {
+			return err
+		}
+
+		ttl := record.TTL
+		if ttl <= 0 {
+			ttl = defaultRFC2136TTL
+		}
+		rrs := make([]dns.RR, 0, len(record.Targets))
+		for _, target := range record.Targets {
+			rr, err := newRR(name, record.RecordType, uint32(ttl), target)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", record.DNSName, record.RecordType, err)
+			}
+			rrs = append(rrs, rr)
+		}
+		msg.Insert(rrs)
+	}
+
+	if len(msg.Ns) == 0 {
+		return nil
+	}
+	return b.send(ctx, msg)
+}
+
+// addDelete adds the removal of an RRset after checking it belongs to the zone
+func (b *RFC2136) addDelete(msg *dns.Msg, name, recordType string) error {
+	rtype, ok := recordTypes[recordType]
+	if !ok {
+		return fmt.Errorf("%s: unsupported record type %q", name, recordType)
+	}
+	zone := dns.Fqdn(strings.ToLower(b.Zone))
+	if !dns.IsSubDomain(zone, name) {
+		return fmt.Errorf("%s is not in zone %s", name, zone)
+	}
+	msg.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: name, Rrtype: rtype, Class: dns.ClassINET}}})
+	return nil
+}
+
+// sharedRRsets returns the RRsets published by RecordSets other than the one with the given key
+func (b *RFC2136) sharedRRsets(ctx context.Context, key string) (map[rrsetKey]bool, error) {
+	sets, err := b.List(ctx, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make(map[rrsetKey]bool)
+	for _, set := range sets {
+		if set.Key() == key {
+			continue
+		}
+		for _, record := range set.Records {
+			shared[rrsetKey{name: dns.Fqdn(strings.ToLower(record.DNSName)), rtype: record.RecordType}] = true
+		}
+	}
+	return shared, nil
+}
+
+// send signs the UPDATE, exchanges it with the server and checks the response.
+// A truncated UDP response is retried over TCP.
+func (b *RFC2136) send(ctx context.Context, msg *dns.Msg) error {
+	c := &dns.Client{Net: b.Net, Timeout: b.Timeout}
+	if c.Net == "" {
+		c.Net = "udp"
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultRFC2136Timeout
+	}
+
+	var keyName, algorithm string
+	if b.TSIG != nil {
+		var err error
+		if algorithm, err = b.TSIG.algorithm(); err != nil {
+			return err
+		}
+		keyName = dns.Fqdn(strings.ToLower(b.TSIG.KeyName))
+		c.TsigSecret = map[string]string{keyName: base64.StdEncoding.EncodeToString(b.TSIG.Secret)}
+	}
+
+	exchange := func() (*dns.Msg, error) {
+		// Signing removes the TSIG record from the message, every exchange signs it again
+		if b.TSIG != nil {
+			msg.SetTsig(keyName, algorithm, tsigFudge, time.Now().Unix())
+		}
+		resp, _, err := c.ExchangeContext(ctx, msg, b.Server)
+		return resp, err
+	}
+
+	resp, err := exchange()
+	if err == nil && resp.Truncated && c.Net == "udp" {
+		c.Net = "tcp"
+		resp, err = exchange()
+	}
+
+	// A rejected signature is reported in the TSIG record of a NOTAUTH response,
+	// which is not signed and fails verification, so the response code is checked first
+	if resp != nil && resp.Rcode != dns.RcodeSuccess {
+		if tsig := resp.IsTsig(); tsig != nil && tsig.Error != dns.RcodeSuccess {
+			return fmt.Errorf("dynamic update to %s rejected: %s (%s)",
+				b.Server, dns.RcodeToString[resp.Rcode], dns.RcodeToString[int(tsig.Error)])
+		}
+		return fmt.Errorf("dynamic update to %s rejected: %s", b.Server, dns.RcodeToString[resp.Rcode])
+	}
+	if err != nil {
+		return fmt.Errorf("dynamic update to %s failed: %w", b.Server, err)
+	}
+	return nil
+}
+
+// newRR builds a resource record of a supported type from its value
+func newRR(name, recordType string, ttl uint32, value string) (dns.RR, error) {
+	rtype, ok := recordTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+	header := dns.RR_Header{Name: name, Rrtype: rtype, Class: dns.ClassINET, Ttl: ttl}
+
+	switch rtype {
+	case dns.TypeA:
+		ip := net.ParseIP(value).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid A record value %q", value)
+		}
+		return &dns.A{Hdr: header, A: ip}, nil
+	case dns.TypeAAAA:
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid AAAA record value %q", value)
+		}
+		return &dns.AAAA{Hdr: header, AAAA: ip}, nil
+	case dns.TypeCNAME:
+		if _, ok := dns.IsDomainName(value); !ok {
+			return nil, fmt.Errorf("invalid CNAME record value %q", value)
+		}
+		return &dns.CNAME{Hdr: header, Target: dns.Fqdn(value)}, nil
+	default:
+		// Long values are split into character strings of at most 255 octets,
+		// backslashes are escaped as the strings are in presentation format
+		var txt []string
+		for len(value) > 255 {
+			txt = append(txt, strings.ReplaceAll(value[:255], `\`, `\\`))
+			value = value[255:]
+		}
+		return &dns.TXT{Hdr: header, Txt: append(txt, strings.ReplaceAll(value, `\`, `\\`))}, nil
+	}
+}
+
+// configMapName returns the name of the ConfigMap recording the RecordSet with the given name
+func configMapName(recordSetName string) string {
+	return configMapPrefix + recordSetName
+}
+
+// configMapFromRecordSet builds the ConfigMap recording a published RecordSet
+func configMapFromRecordSet(set *RecordSet) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(set.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{BackendLabel: BackendRFC2136}
+	for k, v := range set.Labels {
+		labels[k] = v
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        configMapName(set.Name),
+			Namespace:   set.Namespace,
+			Labels:      labels,
+			Annotations: set.Annotations,
+		},
+		Data: map[string]string{recordsKey: string(data)},
+	}, nil
+}
+
+// recordSetFromConfigMap reads a published RecordSet back from its ConfigMap
+func recordSetFromConfigMap(configMap *corev1.ConfigMap) (*RecordSet, error) {
+	var records []Record
+	if err := json.Unmarshal([]byte(configMap.Data[recordsKey]), &records); err != nil {
+		return nil, fmt.Errorf("invalid records in ConfigMap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+	}
+
+	labels := make(map[string]string, len(configMap.Labels))
+	for k, v := range configMap.Labels {
+		if k != BackendLabel {
+			labels[k] = v
+		}
+	}
+
+	return &RecordSet{
+		Name:        strings.TrimPrefix(configMap.Name, configMapPrefix),
+		Namespace:   configMap.Namespace,
+		Labels:      labels,
+		Annotations: configMap.Annotations,
+		Records:     records,
+	}, nil
+}
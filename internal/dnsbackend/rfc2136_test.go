@@ -0,0 +1,292 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsbackend
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+// testServer is a minimal authoritative server accepting TSIG signed updates,
+// standing in for BIND or CoreDNS
+type testServer struct {
+	server  *dns.Server
+	keyName string
+
+	mu      sync.Mutex
+	updates [][]dns.RR
+}
+
+func newTestServer(t *testing.T, keyName string, secret []byte) *testServer {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &testServer{keyName: keyName}
+	started := make(chan struct{})
+	s.server = &dns.Server{
+		PacketConn:        conn,
+		Handler:           dns.HandlerFunc(s.handle),
+		TsigSecret:        map[string]string{keyName: base64.StdEncoding.EncodeToString(secret)},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accepts queries and notifies only
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction {
+			if int(dh.Bits>>11)&0xF == dns.OpcodeUpdate {
+				return dns.MsgAccept
+			}
+			return dns.DefaultMsgAcceptFunc(dh)
+		},
+	}
+	go func() { _ = s.server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = s.server.Shutdown() })
+	return s
+}
+
+func (s *testServer) addr() string {
+	return s.server.PacketConn.LocalAddr().String()
+}
+
+func (s *testServer) received() [][]dns.RR {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]dns.RR(nil), s.updates...)
+}
+
+// handle verifies the request signature and records the update section
+func (s *testServer) handle(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+
+	tsig := req.IsTsig()
+	if tsig == nil || w.TsigStatus() != nil {
+		resp.SetRcode(req, dns.RcodeNotAuth)
+		resp.SetTsig(s.keyName, dns.HmacSHA256, 300, time.Now().Unix())
+		resp.Extra[0].(*dns.TSIG).Error = dns.RcodeBadSig
+		_ = w.WriteMsg(resp)
+		return
+	}
+
+	s.mu.Lock()
+	s.updates = append(s.updates, req.Ns)
+	s.mu.Unlock()
+
+	resp.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+	_ = w.WriteMsg(resp)
+}
+
+func newTestRFC2136(t *testing.T, server *testServer, secret []byte) *RFC2136 {
+	t.Helper()
+	return &RFC2136{
+		Client: testutil.NewFakeClient(t, testSchemes),
+		Server: server.addr(),
+		Zone:   "example.com",
+		TSIG: &TSIG{
+			KeyName:   "operator-key.",
+			Algorithm: "hmac-sha256",
+			Secret:    secret,
+		},
+		Timeout: 2 * time.Second,
+	}
+}
+
+func testRecordSet(name string) *RecordSet {
+	return &RecordSet{
+		Name:      name,
+		Namespace: "istio-system",
+		Labels:    map[string]string{"router.io/resource-type": "gateway-service"},
+		Records: []Record{
+			{DNSName: "aks-neu-internal.example.com", RecordType: "A", Targets: []string{"10.0.0.10"}, TTL: 300},
+		},
+	}
+}
+
+func TestRFC2136Create(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	server := newTestServer(t, "operator-key.", secret)
+	backend := newTestRFC2136(t, server, secret)
+	ctx := context.Background()
+
+	set := testRecordSet("gateway-neu")
+	if err := backend.Create(ctx, set); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received := server.received()
+	if len(received) != 1 || len(received[0]) != 2 {
+		t.Fatalf("expected one update with two entries, got %v", received)
+	}
+	deletion, addition := received[0][0].Header(), received[0][1]
+	if deletion.Name != "aks-neu-internal.example.com." || deletion.Rrtype != dns.TypeA || deletion.Class != dns.ClassANY {
+		t.Errorf("unexpected RRset deletion: %v", deletion)
+	}
+	if a, ok := addition.(*dns.A); !ok || a.Hdr.Class != dns.ClassINET || a.A.String() != "10.0.0.10" {
+		t.Errorf("unexpected record addition: %v", addition)
+	}
+
+	var configMap corev1.ConfigMap
+	if err := backend.Client.Get(ctx, types.NamespacedName{Name: "rfc2136-" + set.Name, Namespace: set.Namespace}, &configMap); err != nil {
+		t.Fatalf("RecordSet was not recorded: %v", err)
+	}
+	if configMap.Labels[BackendLabel] != BackendRFC2136 {
+		t.Errorf("ConfigMap is missing the backend label: %v", configMap.Labels)
+	}
+
+	sets, err := backend.List(ctx, "istio-system", map[string]string{"router.io/resource-type": "gateway-service"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 1 || !Equal(sets[0], set) {
+		t.Errorf("listed RecordSets do not match the published one: %+v", sets)
+	}
+}
+
+func TestRFC2136KeepsForeignConfigMap(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	server := newTestServer(t, "operator-key.", secret)
+	backend := newTestRFC2136(t, server, secret)
+	ctx := context.Background()
+
+	set := testRecordSet("gateway-neu")
+	foreign := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "rfc2136-" + set.Name, Namespace: set.Namespace},
+		Data:       map[string]string{"config": "user data"},
+	}
+	if err := backend.Client.Create(ctx, foreign); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := backend.Create(ctx, set)
+	if err == nil || !strings.Contains(err.Error(), "not managed by the rfc2136 DNS backend") {
+		t.Fatalf("expected the foreign ConfigMap to be refused, got %v", err)
+	}
+	if received := server.received(); len(received) != 0 {
+		t.Errorf("expected no update, got %v", received)
+	}
+
+	var configMap corev1.ConfigMap
+	if err := backend.Client.Get(ctx, client.ObjectKeyFromObject(foreign), &configMap); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if configMap.Data["config"] != "user data" || configMap.Labels[BackendLabel] != "" {
+		t.Errorf("foreign ConfigMap was changed: %+v", configMap)
+	}
+}
+
+func TestRFC2136DeleteKeepsSharedRRsets(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	server := newTestServer(t, "operator-key.", secret)
+	backend := newTestRFC2136(t, server, secret)
+	ctx := context.Background()
+
+	first := testRecordSet("gateway-neu")
+	second := testRecordSet("gateway-weu")
+	if err := backend.Create(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := backend.Create(ctx, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The RRset is still published by the second RecordSet, nothing is sent
+	if err := backend.Delete(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received := server.received(); len(received) != 2 {
+		t.Errorf("expected no update for a shared RRset, got %d updates", len(received))
+	}
+
+	if err := backend.Delete(ctx, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received := server.received()
+	if len(received) != 3 || len(received[2]) != 1 || received[2][0].Header().Class != dns.ClassANY {
+		t.Errorf("expected the RRset to be deleted, got %v", received)
+	}
+
+	sets, err := backend.List(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 0 {
+		t.Errorf("expected no recorded RecordSets, got %d", len(sets))
+	}
+}
+
+func TestRFC2136RejectedSignature(t *testing.T) {
+	server := newTestServer(t, "operator-key.", []byte("0123456789abcdef0123456789abcdef"))
+	backend := newTestRFC2136(t, server, []byte("wrong-secret"))
+
+	err := backend.Create(context.Background(), testRecordSet("gateway-neu"))
+	if err == nil || !strings.Contains(err.Error(), "BADSIG") {
+		t.Fatalf("expected BADSIG error, got %v", err)
+	}
+}
+
+func TestRFC2136RecordOutsideZone(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	server := newTestServer(t, "operator-key.", secret)
+	backend := newTestRFC2136(t, server, secret)
+
+	set := testRecordSet("gateway-neu")
+	set.Records[0].DNSName = "aks-neu-internal.example.org"
+	if err := backend.Create(context.Background(), set); err == nil {
+		t.Fatal("expected error for a record outside the zone")
+	}
+	if received := server.received(); len(received) != 0 {
+		t.Errorf("expected no update, got %v", received)
+	}
+}
+
+func TestNewRR(t *testing.T) {
+	txt, err := newRR("_router-owner.example.com.", "TXT", 300, strings.Repeat("a", 300))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings := txt.(*dns.TXT).Txt; len(strings) != 2 || len(strings[0]) != 255 || len(strings[1]) != 45 {
+		t.Errorf("TXT value was not split into character strings")
+	}
+
+	cname, err := newRR("api.example.com.", "CNAME", 300, "aks-neu-internal.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target := cname.(*dns.CNAME).Target; target != "aks-neu-internal.example.com." {
+		t.Errorf("unexpected CNAME target %s", target)
+	}
+
+	if _, err := newRR("api.example.com.", "A", 300, "not-an-ip"); err == nil {
+		t.Error("expected error for invalid A record value")
+	}
+	if _, err := newRR("api.example.com.", "AAAA", 300, "10.0.0.10"); err == nil {
+		t.Error("expected error for an IPv4 AAAA record value")
+	}
+}
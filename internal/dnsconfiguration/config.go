@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsconfiguration
+
+import (
+	"strings"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+// ExternalDNSController defines an ExternalDNS controller configuration
+type ExternalDNSController struct {
+	Name   string
+	Region string
+	// Annotation overrides the controller annotation value, defaults to external-dns-<region>
+	Annotation string
+	// DomainFilters and RecordTypes restrict the records published to the controller, empty accepts all
+	DomainFilters []string
+	RecordTypes   []string
+	// TTL is the TTL of the records published to the controller in seconds, 0 keeps the record TTL
+	TTL int64
+}
+
+// AnnotationValue returns the external-dns.alpha.kubernetes.io/controller annotation value
+// the ExternalDNS deployment of this controller filters on
+func (c ExternalDNSController) AnnotationValue() string {
+	if c.Annotation != "" {
+		return c.Annotation
+	}
+	return "external-dns-" + c.Region
+}
+
+// AcceptsRecord reports whether a record of the given name and type is published to this controller.
+// A name is within a domain filter when it is the domain itself or one of its subdomains.
+func (c ExternalDNSController) AcceptsRecord(dnsName, recordType string) bool {
+	if len(c.RecordTypes) > 0 {
+		accepted := false
+		for _, t := range c.RecordTypes {
+			if strings.EqualFold(t, recordType) {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return false
+		}
+	}
+
+	if len(c.DomainFilters) == 0 {
+		return true
+	}
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	for _, filter := range c.DomainFilters {
+		domain := strings.ToLower(strings.Trim(filter, "."))
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// DefaultGatewayTTL is the TTL of gateway A records when no TTL is configured
+	DefaultGatewayTTL = 300
+
+	// DefaultPreFailoverTTL caps the record TTLs in pre-failover mode when no TTL is configured
+	DefaultPreFailoverTTL = 30
+)
+
+// DNSConfiguration holds the cluster's DNS configuration
+type DNSConfiguration struct {
+	ExternalDNSControllers []ExternalDNSController
+	// ServiceTTL and GatewayTTL are the default TTLs of service CNAME and gateway A records
+	// in seconds, 0 when not configured
+	ServiceTTL int64
+	GatewayTTL int64
+	// PreFailoverTTL caps the TTL of every record in seconds, 0 outside pre-failover mode
+	PreFailoverTTL int64
+}
+
+// ServiceRecordTTL returns the TTL of the service records published to a controller: the
+// ServiceRoute or DNSPolicy override when not 0, else the controller TTL, else the service default.
+// 0 leaves the TTL to the DNS provider.
+func (c *DNSConfiguration) ServiceRecordTTL(controller ExternalDNSController, override int64) int64 {
+	return c.capTTL(firstTTL(override, controller.TTL, c.ServiceTTL))
+}
+
+// GatewayRecordTTL returns the TTL of the gateway records published to a controller:
+// the controller TTL, else the gateway default, else DefaultGatewayTTL
+func (c *DNSConfiguration) GatewayRecordTTL(controller ExternalDNSController) int64 {
+	return c.capTTL(firstTTL(controller.TTL, c.GatewayTTL, DefaultGatewayTTL))
+}
+
+// capTTL lowers a TTL to the pre-failover TTL. A record without TTL gets the pre-failover TTL
+// because the provider default is unknown and may well be longer.
+func (c *DNSConfiguration) capTTL(ttl int64) int64 {
+	if c.PreFailoverTTL > 0 && (ttl == 0 || ttl > c.PreFailoverTTL) {
+		return c.PreFailoverTTL
+	}
+	return ttl
+}
+
+// firstTTL returns the first TTL that is set
+func firstTTL(ttls ...int64) int64 {
+	for _, ttl := range ttls {
+		if ttl > 0 {
+			return ttl
+		}
+	}
+	return 0
+}
+
+// fromSpec converts a DNSConfiguration spec, copying every slice so the result may be modified
+func fromSpec(spec *clusterv1alpha1.DNSConfigurationSpec) *DNSConfiguration {
+	config := &DNSConfiguration{
+		ExternalDNSControllers: make([]ExternalDNSController, len(spec.ExternalDNSControllers)),
+	}
+	for i, c := range spec.ExternalDNSControllers {
+		config.ExternalDNSControllers[i] = ExternalDNSController{
+			Name:          c.Name,
+			Region:        c.Region,
+			Annotation:    c.Annotation,
+			DomainFilters: append([]string(nil), c.DomainFilters...),
+			RecordTypes:   append([]string(nil), c.RecordTypes...),
+		}
+		if c.TTL != nil {
+			config.ExternalDNSControllers[i].TTL = *c.TTL
+		}
+	}
+	if spec.ServiceTTL != nil {
+		config.ServiceTTL = *spec.ServiceTTL
+	}
+	if spec.GatewayTTL != nil {
+		config.GatewayTTL = *spec.GatewayTTL
+	}
+	if spec.PreFailover != nil {
+		config.PreFailoverTTL = spec.PreFailover.TTL
+		if config.PreFailoverTTL <= 0 {
+			config.PreFailoverTTL = DefaultPreFailoverTTL
+		}
+	}
+	return config
+}
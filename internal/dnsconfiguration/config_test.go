@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsconfiguration
+
+import (
+	"testing"
+)
+
+func TestAnnotationValue(t *testing.T) {
+	controller := ExternalDNSController{Name: "external-dns-public", Region: "neu"}
+	if got := controller.AnnotationValue(); got != "external-dns-neu" {
+		t.Errorf("expected the region based default, got %s", got)
+	}
+
+	controller.Annotation = "public"
+	if got := controller.AnnotationValue(); got != "public" {
+		t.Errorf("expected the configured annotation, got %s", got)
+	}
+}
+
+func TestAcceptsRecord(t *testing.T) {
+	controller := ExternalDNSController{
+		Name:          "external-dns-private",
+		Region:        "neu",
+		DomainFilters: []string{"internal.example.com"},
+		RecordTypes:   []string{"A", "CNAME"},
+	}
+
+	tests := []struct {
+		dnsName    string
+		recordType string
+		want       bool
+	}{
+		{"api.internal.example.com", "CNAME", true},
+		{"internal.example.com", "A", true},
+		{"API.Internal.Example.com.", "cname", true},
+		{"api.example.com", "CNAME", false},
+		{"api.notinternal.example.com", "CNAME", false},
+		{"api.internal.example.com", "TXT", false},
+	}
+	for _, tt := range tests {
+		if got := controller.AcceptsRecord(tt.dnsName, tt.recordType); got != tt.want {
+			t.Errorf("AcceptsRecord(%s, %s) = %v, want %v", tt.dnsName, tt.recordType, got, tt.want)
+		}
+	}
+
+	if !(ExternalDNSController{Name: "external-dns-neu"}).AcceptsRecord("api.example.com", "TXT") {
+		t.Error("expected a controller without filters to accept every record")
+	}
+}
+
+func TestRecordTTL(t *testing.T) {
+	controller := ExternalDNSController{Name: "external-dns-neu", Region: "neu"}
+	withTTL := ExternalDNSController{Name: "external-dns-weu", Region: "weu", TTL: 120}
+
+	config := &DNSConfiguration{}
+	if got := config.ServiceRecordTTL(controller, 0); got != 0 {
+		t.Errorf("expected the provider default for service records, got %d", got)
+	}
+	if got := config.GatewayRecordTTL(controller); got != DefaultGatewayTTL {
+		t.Errorf("expected the default gateway TTL, got %d", got)
+	}
+
+	config = &DNSConfiguration{ServiceTTL: 600, GatewayTTL: 900}
+	tests := []struct {
+		name       string
+		controller ExternalDNSController
+		override   int64
+		want       int64
+	}{
+		{"configuration default", controller, 0, 600},
+		{"controller over the configuration default", withTTL, 0, 120},
+		{"override over the controller", withTTL, 60, 60},
+	}
+	for _, tt := range tests {
+		if got := config.ServiceRecordTTL(tt.controller, tt.override); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	if got := config.GatewayRecordTTL(controller); got != 900 {
+		t.Errorf("expected the configured gateway TTL, got %d", got)
+	}
+	if got := config.GatewayRecordTTL(withTTL); got != 120 {
+		t.Errorf("expected the controller TTL, got %d", got)
+	}
+}
+
+func TestRecordTTLPreFailover(t *testing.T) {
+	controller := ExternalDNSController{Name: "external-dns-neu", Region: "neu"}
+	config := &DNSConfiguration{GatewayTTL: 900, PreFailoverTTL: 30}
+
+	if got := config.ServiceRecordTTL(controller, 0); got != 30 {
+		t.Errorf("expected a record without TTL to get the pre-failover TTL, got %d", got)
+	}
+	if got := config.ServiceRecordTTL(controller, 10); got != 10 {
+		t.Errorf("expected a lower TTL to be kept, got %d", got)
+	}
+	if got := config.GatewayRecordTTL(controller); got != 30 {
+		t.Errorf("expected the gateway TTL to be capped, got %d", got)
+	}
+}
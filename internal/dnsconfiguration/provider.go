@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsconfiguration
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+)
+
+// ConfigProvider gives reconcilers and webhooks the DNS configuration of the cluster
+type ConfigProvider interface {
+	// Config returns the DNS configuration, or nil when no DNSConfiguration exists.
+	// The returned value is a copy the caller may modify.
+	Config(ctx context.Context) (*DNSConfiguration, error)
+
+	// Watch returns a controller source passing every DNSConfiguration creation,
+	// update and deletion to the handler
+	Watch(h handler.EventHandler) source.Source
+}
+
+// provider reads the DNSConfiguration through a client.Reader
+type provider struct {
+	reader client.Reader
+	cache  cache.Cache
+}
+
+// NewProvider returns a ConfigProvider backed by the informer cache of a manager.
+// Every manager has its own cache, so managers in one process do not share configurations.
+func NewProvider(c cache.Cache) ConfigProvider {
+	return &provider{reader: c, cache: c}
+}
+
+// NewReaderProvider returns a ConfigProvider reading the DNSConfiguration with a plain reader,
+// for callers without a manager such as the export-dns command and tests. Its Watch source never fires.
+func NewReaderProvider(reader client.Reader) ConfigProvider {
+	return &provider{reader: reader}
+}
+
+// Config implements ConfigProvider.
+// More than one DNSConfiguration is an error, the configuration is then ambiguous.
+func (p *provider) Config(ctx context.Context) (*DNSConfiguration, error) {
+	var dnsConfigs clusterv1alpha1.DNSConfigurationList
+	if err := p.reader.List(ctx, &dnsConfigs); err != nil {
+		return nil, err
+	}
+
+	var current []clusterv1alpha1.DNSConfiguration
+	for _, item := range dnsConfigs.Items {
+		if item.DeletionTimestamp == nil {
+			current = append(current, item)
+		}
+	}
+
+	switch len(current) {
+	case 0:
+		return nil, nil
+	case 1:
+		return fromSpec(&current[0].Spec), nil
+	}
+	return nil, fmt.Errorf("found %d DNSConfigurations, only one is allowed per cluster", len(current))
+}
+
+// Watch implements ConfigProvider
+func (p *provider) Watch(h handler.EventHandler) source.Source {
+	if p.cache == nil {
+		return source.Func(func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			return nil
+		})
+	}
+	return source.Kind[client.Object](p.cache, &clusterv1alpha1.DNSConfiguration{}, h)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsconfiguration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+)
+
+// testSchemes are the API groups of the fake clients of the tests
+var testSchemes = runtime.SchemeBuilder{clusterv1alpha1.AddToScheme}
+
+func newDNSConfiguration(name string) *clusterv1alpha1.DNSConfiguration {
+	ttl := int64(60)
+	return &clusterv1alpha1.DNSConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clusterv1alpha1.DNSConfigurationSpec{
+			ExternalDNSControllers: []clusterv1alpha1.ExternalDNSController{
+				{Name: "external-dns-neu", Region: "neu"},
+				{
+					Name:          "external-dns-private",
+					Region:        "neu",
+					Annotation:    "private",
+					DomainFilters: []string{"internal.example.com"},
+					RecordTypes:   []string{"CNAME"},
+					TTL:           &ttl,
+				},
+			},
+		},
+	}
+}
+
+func TestProviderConfig(t *testing.T) {
+	ctx := context.Background()
+
+	config, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes)).Config(ctx)
+	if err != nil || config != nil {
+		t.Fatalf("expected no configuration without a DNSConfiguration, got %+v, %v", config, err)
+	}
+
+	p := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, newDNSConfiguration("default")))
+	config, err = p.Config(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.ExternalDNSControllers) != 2 {
+		t.Fatalf("expected 2 controllers, got %+v", config.ExternalDNSControllers)
+	}
+	private := config.ExternalDNSControllers[1]
+	if private.Annotation != "private" || private.TTL != 60 ||
+		len(private.DomainFilters) != 1 || len(private.RecordTypes) != 1 {
+		t.Errorf("unexpected controller: %+v", private)
+	}
+	if config.ExternalDNSControllers[0].TTL != 0 {
+		t.Errorf("expected no TTL for a controller without one, got %d", config.ExternalDNSControllers[0].TTL)
+	}
+
+	// The returned configuration is a copy, changing it does not affect the next caller
+	config.ExternalDNSControllers[1].DomainFilters[0] = "example.org"
+	config, err = p.Config(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.ExternalDNSControllers[1].DomainFilters[0] != "internal.example.com" {
+		t.Errorf("expected an unmodified configuration, got %+v", config.ExternalDNSControllers[1])
+	}
+}
+
+func TestProviderConfigTTLs(t *testing.T) {
+	serviceTTL, gatewayTTL := int64(600), int64(900)
+	dnsConfig := newDNSConfiguration("default")
+	dnsConfig.Spec.ServiceTTL = &serviceTTL
+	dnsConfig.Spec.GatewayTTL = &gatewayTTL
+	dnsConfig.Spec.PreFailover = &clusterv1alpha1.PreFailover{}
+
+	config, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, dnsConfig)).Config(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.ServiceTTL != 600 || config.GatewayTTL != 900 {
+		t.Errorf("unexpected TTLs: %+v", config)
+	}
+	if config.PreFailoverTTL != DefaultPreFailoverTTL {
+		t.Errorf("expected the default pre-failover TTL, got %d", config.PreFailoverTTL)
+	}
+}
+
+func TestProviderConfigAmbiguous(t *testing.T) {
+	objs := []client.Object{newDNSConfiguration("first"), newDNSConfiguration("second")}
+
+	_, err := NewReaderProvider(testutil.NewFakeClient(t, testSchemes, objs...)).Config(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "found 2 DNSConfigurations") {
+		t.Errorf("expected an error for two DNSConfigurations, got %v", err)
+	}
+}
+
+func TestReaderProviderWatch(t *testing.T) {
+	src := NewReaderProvider(testutil.NewFakeClient(t, testSchemes)).Watch(nil)
+	if err := src.Start(context.Background(), nil); err != nil {
+		t.Errorf("expected the reader provider source to start without error, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsexport renders the complete set of DNS records the operator publishes,
+// as a BIND zone file or as JSON. It is used for compliance reviews and to diff the
+// desired records against what the DNS provider actually serves.
+package dnsexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+)
+
+const (
+	// FormatZone renders the records as a BIND zone file
+	FormatZone = "zone"
+
+	// FormatJSON renders the records as JSON
+	FormatJSON = "json"
+
+	// DefaultTTL is the $TTL of the zone file, used by records without an explicit TTL
+	DefaultTTL = 300
+)
+
+// Record is a desired DNS record and where it comes from
+type Record struct {
+	// Name is the fully qualified record name
+	Name string `json:"name"`
+
+	// Type is the record type (A, AAAA, CNAME or TXT)
+	Type string `json:"type"`
+
+	// TTL is the record TTL in seconds, 0 when the provider default applies
+	TTL int64 `json:"ttl,omitempty"`
+
+	// Targets are the record values
+	Targets []string `json:"targets"`
+
+	// Controller is the ExternalDNS controller publishing the record
+	Controller string `json:"controller,omitempty"`
+
+	// Region is the region of the ExternalDNS controller
+	Region string `json:"region,omitempty"`
+
+	// RecordSet is the namespace/name of the record set (DNSEndpoint) holding the record
+	RecordSet string `json:"recordSet"`
+
+	// Source is the resource the record is generated for, e.g. ServiceRoute default/api
+	Source string `json:"source"`
+}
+
+// Skipped is a resource that currently publishes no records
+type Skipped struct {
+	// Source is the resource, e.g. ServiceRoute default/api
+	Source string `json:"source"`
+
+	// Reason explains why nothing is published
+	Reason string `json:"reason"`
+}
+
+// Export is the complete desired record set of the operator
+type Export struct {
+	Records []Record  `json:"records"`
+	Skipped []Skipped `json:"skipped,omitempty"`
+}
+
+// Source computes the desired records from the current cluster state
+type Source interface {
+	Export(ctx context.Context) (*Export, error)
+}
+
+// AddRecordSet adds the records of a generated record set, attributed to source.
+// The ExternalDNS controller and region are taken from the record set labels.
+func (e *Export) AddRecordSet(source string, set *dnsbackend.RecordSet) {
+	for _, record := range set.Records {
+		e.Records = append(e.Records, Record{
+			Name:       record.DNSName,
+			Type:       record.RecordType,
+			TTL:        record.TTL,
+			Targets:    append([]string(nil), record.Targets...),
+			Controller: set.Labels["router.io/controller"],
+			Region:     set.Labels["router.io/region"],
+			RecordSet:  set.Key(),
+			Source:     source,
+		})
+	}
+}
+
+// AddSkipped records a resource that publishes no records
+func (e *Export) AddSkipped(source, reason string) {
+	e.Skipped = append(e.Skipped, Skipped{Source: source, Reason: reason})
+}
+
+// Sort orders the records by controller, name and type, so exports can be diffed
+func (e *Export) Sort() {
+	sort.SliceStable(e.Records, func(i, j int) bool {
+		a, b := e.Records[i], e.Records[j]
+		if a.Controller != b.Controller {
+			return a.Controller < b.Controller
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.RecordSet < b.RecordSet
+	})
+	sort.SliceStable(e.Skipped, func(i, j int) bool {
+		return e.Skipped[i].Source < e.Skipped[j].Source
+	})
+}
+
+// FilterController returns the export reduced to the records of one ExternalDNS controller
+func (e *Export) FilterController(controller string) *Export {
+	filtered := &Export{Records: []Record{}, Skipped: e.Skipped}
+	for _, record := range e.Records {
+		if record.Controller == controller {
+			filtered.Records = append(filtered.Records, record)
+		}
+	}
+	return filtered
+}
+
+// Write renders the export in the given format
+func Write(w io.Writer, e *Export, format string) error {
+	switch format {
+	case "", FormatZone:
+		return WriteZone(w, e)
+	case FormatJSON:
+		return WriteJSON(w, e)
+	}
+	return fmt.Errorf("unknown export format %q, must be %s or %s", format, FormatZone, FormatJSON)
+}
+
+// WriteJSON renders the export as indented JSON
+func WriteJSON(w io.Writer, e *Export) error {
+	if e.Records == nil {
+		e = &Export{Records: []Record{}, Skipped: e.Skipped}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// WriteZone renders the export as a BIND zone file.
+// Every ExternalDNS controller publishes to its own zone, so the records are grouped
+// per controller; a single group is a valid zone body for that controller.
+// The source of every record is kept as a trailing comment.
+func WriteZone(w io.Writer, e *Export) error {
+	var b strings.Builder
+
+	b.WriteString("; DNS records managed by service-router-operator\n")
+	fmt.Fprintf(&b, "$TTL %d\n", DefaultTTL)
+
+	controller := ""
+	for i, record := range e.Records {
+		if i == 0 || record.Controller != controller {
+			controller = record.Controller
+			b.WriteString("\n")
+			if controller != "" {
+				fmt.Fprintf(&b, "; ExternalDNS controller %s (region %s)\n", controller, record.Region)
+			} else {
+				b.WriteString("; No ExternalDNS controller\n")
+			}
+		}
+
+		ttl := ""
+		if record.TTL > 0 {
+			ttl = fmt.Sprintf("%d", record.TTL)
+		}
+		for _, target := range record.Targets {
+			fmt.Fprintf(&b, "%s\t%s\tIN\t%s\t%s\t; %s, %s\n",
+				fqdn(record.Name), ttl, record.Type, zoneValue(record.Type, target), record.Source, record.RecordSet)
+		}
+	}
+
+	if len(e.Skipped) > 0 {
+		b.WriteString("\n; Resources publishing no records\n")
+		for _, skipped := range e.Skipped {
+			fmt.Fprintf(&b, "; %s: %s\n", skipped.Source, strings.ReplaceAll(skipped.Reason, "\n", " "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fqdn returns name as an absolute domain name
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// zoneValue renders a record value in zone file syntax
+func zoneValue(recordType, value string) string {
+	switch recordType {
+	case "CNAME":
+		return fqdn(value)
+	case "TXT":
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+)
+
+func testExport() *Export {
+	export := &Export{}
+	for _, controller := range []struct{ name, region string }{
+		{"external-dns-weu", "weu"},
+		{"external-dns-neu", "neu"},
+	} {
+		export.AddRecordSet("ServiceRoute default/api", &dnsbackend.RecordSet{
+			Name:      "api-" + controller.name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"router.io/controller": controller.name,
+				"router.io/region":     controller.region,
+			},
+			Records: []dnsbackend.Record{
+				{DNSName: "api-ns-d-dev-app.example.com", RecordType: "CNAME", Targets: []string{"aks-neu-internal.example.com"}},
+			},
+		})
+		export.AddRecordSet("Gateway controller aks-istio-ingress (internal)", &dnsbackend.RecordSet{
+			Name:      "gateway-controller-aks-istio-ingress-internal-" + controller.name,
+			Namespace: "istio-system",
+			Labels: map[string]string{
+				"router.io/controller": controller.name,
+				"router.io/region":     controller.region,
+			},
+			Records: []dnsbackend.Record{
+				{DNSName: "aks-neu-internal.example.com", RecordType: "A", Targets: []string{"10.0.0.10"}, TTL: 300},
+			},
+		})
+	}
+	export.AddSkipped("ServiceRoute default/web", "DNSPolicy not found in namespace")
+	export.Sort()
+	return export
+}
+
+func TestWriteZone(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, testExport(), FormatZone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zone := out.String()
+
+	for _, expected := range []string{
+		"$TTL 300\n",
+		"; ExternalDNS controller external-dns-neu (region neu)\n" +
+			"aks-neu-internal.example.com.\t300\tIN\tA\t10.0.0.10\t; Gateway controller aks-istio-ingress (internal), " +
+			"istio-system/gateway-controller-aks-istio-ingress-internal-external-dns-neu\n" +
+			"api-ns-d-dev-app.example.com.\t\tIN\tCNAME\taks-neu-internal.example.com.\t; ServiceRoute default/api, " +
+			"default/api-external-dns-neu\n",
+		"; ExternalDNS controller external-dns-weu (region weu)\n",
+		"; ServiceRoute default/web: DNSPolicy not found in namespace\n",
+	} {
+		if !strings.Contains(zone, expected) {
+			t.Errorf("zone file does not contain %q:\n%s", expected, zone)
+		}
+	}
+
+	// The neu group is rendered before the weu group
+	if strings.Index(zone, "external-dns-neu") > strings.Index(zone, "external-dns-weu") {
+		t.Errorf("records are not grouped in controller order:\n%s", zone)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := Write(&out, testExport().FilterController("external-dns-weu"), FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var export Export
+	if err := json.Unmarshal(out.Bytes(), &export); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(export.Records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(export.Records))
+	}
+	for _, record := range export.Records {
+		if record.Controller != "external-dns-weu" || record.Region != "weu" {
+			t.Errorf("record of another controller exported: %+v", record)
+		}
+	}
+	if len(export.Skipped) != 1 {
+		t.Errorf("expected skipped resources to be kept, got %v", export.Skipped)
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, testExport(), "yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+type fakeSource struct {
+	export *Export
+	err    error
+}
+
+func (s *fakeSource) Export(context.Context) (*Export, error) {
+	return s.export, s.err
+}
+
+func TestHandler(t *testing.T) {
+	handler := &Handler{Source: &fakeSource{export: testExport()}}
+
+	tests := []struct {
+		name        string
+		url         string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"zone by default", Path, http.StatusOK, "text/dns; charset=utf-8", "$TTL 300"},
+		{"json", Path + "?format=json", http.StatusOK, "application/json", `"recordSet": "default/api-external-dns-neu"`},
+		{"controller filter", Path + "?controller=external-dns-weu", http.StatusOK, "text/dns; charset=utf-8", "external-dns-weu"},
+		{"unknown format", Path + "?format=yaml", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.contentType != "" && rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("expected content type %s, got %s", tt.contentType, rec.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("response does not contain %q:\n%s", tt.contains, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?controller=external-dns-weu", nil))
+	if strings.Contains(rec.Body.String(), "external-dns-neu") {
+		t.Errorf("controller filter kept records of another controller:\n%s", rec.Body.String())
+	}
+
+	failing := &Handler{Source: &fakeSource{err: errors.New("boom")}}
+	rec = httptest.NewRecorder()
+	failing.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500 when the export fails, got %d", rec.Code)
+	}
+}
+
+func TestServer(t *testing.T) {
+	// Reserve a free port for the server
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := &Server{BindAddress: addr, Handler: &Handler{Source: &fakeSource{export: testExport()}}}
+	stopped := make(chan error, 1)
+	go func() { stopped <- server.Start(ctx) }()
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		if resp, err = http.Get("http://" + addr + Path); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("export not served: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if resp, err = http.Get("http://" + addr + "/metrics"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected only the export to be served, got status %d for /metrics", resp.StatusCode)
+	}
+
+	cancel()
+	if err := <-stopped; err != nil {
+		t.Errorf("unexpected error stopping the server: %v", err)
+	}
+	if server.NeedLeaderElection() {
+		t.Error("every replica should serve the export")
+	}
+}
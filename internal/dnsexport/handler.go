@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Path is the path the export is served on by the manager
+const Path = "/dns/records"
+
+// shutdownTimeout bounds the time in-flight exports get when the manager stops
+const shutdownTimeout = 10 * time.Second
+
+// Handler serves the export over HTTP.
+// The format query parameter selects zone (default) or json,
+// the controller query parameter limits the export to one ExternalDNS controller.
+type Handler struct {
+	Source Source
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+	if format == "" {
+		format = FormatZone
+	}
+	if format != FormatZone && format != FormatJSON {
+		http.Error(w, "format must be zone or json", http.StatusBadRequest)
+		return
+	}
+
+	export, err := h.Source.Export(req.Context())
+	if err != nil {
+		log.FromContext(req.Context()).Error(err, "failed to export DNS records")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if controller := req.URL.Query().Get("controller"); controller != "" {
+		export = export.FilterController(controller)
+	}
+
+	// Render first, so a failure still results in an error status
+	var body bytes.Buffer
+	if err := Write(&body, export, format); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/dns; charset=utf-8")
+	}
+	_, _ = w.Write(body.Bytes())
+}
+
+// Server serves the export on its own listener. The export lists every record and backend
+// of the cluster, so it is kept off the metrics endpoint, which serves without authentication.
+type Server struct {
+	// BindAddress is the address the export listens on
+	BindAddress string
+	Handler     http.Handler
+}
+
+// Start implements manager.Runnable and serves the export until ctx is done
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.BindAddress)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, s.Handler)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: shutdownTimeout}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica serves the export
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostname renders the DNS names published by the operator.
+// Both the DNS records and the Istio Gateway hosts are built through this package
+// so they can never drift apart.
+package hostname
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+)
+
+const (
+	// MaxLabelLength is the maximum length of a single DNS label (RFC 1035)
+	MaxLabelLength = 63
+
+	// MaxNameLength is the maximum length of a DNS name (RFC 1035)
+	MaxNameLength = 253
+
+	// DefaultSourceTemplate is the pattern used for service hostnames when ClusterIdentity sets none
+	DefaultSourceTemplate = "{{.Service}}-ns-{{.EnvironmentLetter}}-{{.Environment}}-{{.Application}}.{{.Domain}}"
+
+	// DefaultTargetTemplate is the pattern used for gateway target hostnames when ClusterIdentity sets none
+	DefaultTargetTemplate = "{{.Cluster}}-{{.Region}}-{{.Postfix}}.{{.Domain}}"
+)
+
+// Values holds the variables available to hostname templates
+type Values struct {
+	Service           string
+	EnvironmentLetter string
+	Environment       string
+	Application       string
+	Region            string
+	Cluster           string
+	Postfix           string
+	Domain            string
+}
+
+// source returns the variables available to source host templates
+func (v Values) source() map[string]string {
+	return map[string]string{
+		"Service":           v.Service,
+		"EnvironmentLetter": v.EnvironmentLetter,
+		"Environment":       v.Environment,
+		"Application":       v.Application,
+		"Region":            v.Region,
+		"Cluster":           v.Cluster,
+		"Domain":            v.Domain,
+	}
+}
+
+// target returns the variables available to target host templates
+func (v Values) target() map[string]string {
+	return map[string]string{
+		"EnvironmentLetter": v.EnvironmentLetter,
+		"Region":            v.Region,
+		"Cluster":           v.Cluster,
+		"Postfix":           v.Postfix,
+		"Domain":            v.Domain,
+	}
+}
+
+// placeholders are the values templates are validated with
+var placeholders = Values{
+	Service:           "service",
+	EnvironmentLetter: "d",
+	Environment:       "env",
+	Application:       "app",
+	Region:            "region",
+	Cluster:           "cluster",
+	Postfix:           "postfix",
+	Domain:            "example.com",
+}
+
+// render executes the given template against variables and returns the lowercased hostname.
+// Variables are passed as a map so a template referencing a variable it has no access to fails.
+func render(tmpl string, variables map[string]string) (string, error) {
+	t, err := template.New("hostname").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname template %q: %w", tmpl, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, variables); err != nil {
+		return "", fmt.Errorf("failed to render hostname template %q: %w", tmpl, err)
+	}
+
+	host := strings.ToLower(strings.TrimSpace(buf.String()))
+	if host == "" {
+		return "", fmt.Errorf("hostname template %q rendered an empty hostname", tmpl)
+	}
+
+	return host, nil
+}
+
+// ValidateSource checks that a source host template parses and renders with placeholder values
+func ValidateSource(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	_, err := render(tmpl, placeholders.source())
+	return err
+}
+
+// ValidateTarget checks that a target host template parses and renders with placeholder values
+func ValidateTarget(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	_, err := render(tmpl, placeholders.target())
+	return err
+}
+
+// CheckLength returns an error if the hostname or any of its labels exceeds the DNS limits
+func CheckLength(host string) error {
+	if len(host) > MaxNameLength {
+		return fmt.Errorf("hostname %q is %d characters long, the maximum is %d", host, len(host), MaxNameLength)
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) > MaxLabelLength {
+			return fmt.Errorf("label %q of hostname %q is %d characters long, the maximum is %d",
+				label, host, len(label), MaxLabelLength)
+		}
+	}
+	return nil
+}
+
+// SourceHost renders the hostname clients use to reach a ServiceRoute
+func SourceHost(identity *clusteridentity.ClusterIdentity, service, environment, application string) (string, error) {
+	tmpl := identity.SourceHostTemplate
+	if tmpl == "" {
+		tmpl = DefaultSourceTemplate
+	}
+
+	return render(tmpl, Values{
+		Service:           service,
+		EnvironmentLetter: identity.EnvironmentLetter,
+		Environment:       environment,
+		Application:       application,
+		Region:            identity.Region,
+		Cluster:           identity.Cluster,
+		Domain:            identity.Domain,
+	}.source())
+}
+
+// TargetHost renders the hostname of the gateway that service CNAMEs point to
+func TargetHost(identity *clusteridentity.ClusterIdentity, postfix string) (string, error) {
+	tmpl := identity.TargetHostTemplate
+	if tmpl == "" {
+		tmpl = DefaultTargetTemplate
+	}
+
+	return render(tmpl, Values{
+		EnvironmentLetter: identity.EnvironmentLetter,
+		Region:            identity.Region,
+		Cluster:           identity.Cluster,
+		Postfix:           postfix,
+		Domain:            identity.Domain,
+	}.target())
+}
+
+// Alias resolves a ServiceRoute alias to a fully-qualified hostname, following the DNS convention:
+// names with a trailing dot are fully-qualified and returned without it,
+// all other names are relative to the ClusterIdentity domain (e.g., "api.v2" is "api.v2.<domain>").
+func Alias(identity *clusteridentity.ClusterIdentity, alias string) string {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if fqdn, ok := strings.CutSuffix(alias, "."); ok {
+		return fqdn
+	}
+	return alias + "." + identity.Domain
+}
+
+// Aliases resolves all aliases, dropping empty and duplicate entries as well as
+// any alias that equals the primary hostname.
+func Aliases(identity *clusteridentity.ClusterIdentity, primary string, aliases []string) []string {
+	seen := map[string]bool{primary: true}
+	var hosts []string
+	for _, a := range aliases {
+		if strings.TrimSpace(a) == "" {
+			continue
+		}
+		host := Alias(identity, a)
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
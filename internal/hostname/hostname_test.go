@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostname
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+)
+
+func testIdentity() *clusteridentity.ClusterIdentity {
+	return &clusteridentity.ClusterIdentity{
+		Region:            "neu",
+		Cluster:           "aks",
+		Domain:            "example.com",
+		EnvironmentLetter: "d",
+	}
+}
+
+func TestSourceHostDefaultTemplate(t *testing.T) {
+	host, err := SourceHost(testIdentity(), "api", "dev", "shop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host != "api-ns-d-dev-shop.example.com" {
+		t.Errorf("host mismatch: got %s", host)
+	}
+}
+
+func TestTargetHostDefaultTemplate(t *testing.T) {
+	host, err := TargetHost(testIdentity(), "internal")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host != "aks-neu-internal.example.com" {
+		t.Errorf("host mismatch: got %s", host)
+	}
+}
+
+func TestCustomTemplates(t *testing.T) {
+	identity := testIdentity()
+	identity.SourceHostTemplate = "{{.Application}}-{{.Service}}.{{.Environment}}.{{.Domain}}"
+	identity.TargetHostTemplate = "gw-{{.Postfix}}.{{.Region}}.{{.Domain}}"
+
+	source, err := SourceHost(identity, "api", "dev", "shop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source != "shop-api.dev.example.com" {
+		t.Errorf("source host mismatch: got %s", source)
+	}
+
+	target, err := TargetHost(identity, "external")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target != "gw-external.neu.example.com" {
+		t.Errorf("target host mismatch: got %s", target)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := ValidateSource(""); err != nil {
+		t.Errorf("empty template should be valid, got %v", err)
+	}
+	if err := ValidateSource(DefaultSourceTemplate); err != nil {
+		t.Errorf("default source template should be valid, got %v", err)
+	}
+	if err := ValidateTarget(DefaultTargetTemplate); err != nil {
+		t.Errorf("default target template should be valid, got %v", err)
+	}
+	if err := ValidateSource("{{.Service"); err == nil {
+		t.Error("expected parse error for unterminated action")
+	}
+	if err := ValidateSource("{{.Unknown}}.example.com"); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+	if err := ValidateSource("{{.Service}}-{{.Postfix}}.{{.Domain}}"); err == nil {
+		t.Error("expected error for Postfix in a source template")
+	}
+	if err := ValidateTarget("{{.Service}}-{{.Postfix}}.{{.Domain}}"); err == nil {
+		t.Error("expected error for Service in a target template")
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	if err := CheckLength("api-ns-d-dev-shop.example.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckLength(strings.Repeat("a", 63) + ".example.com"); err != nil {
+		t.Errorf("63 character label should be valid, got %v", err)
+	}
+	if err := CheckLength(strings.Repeat("a", 64) + ".example.com"); err == nil {
+		t.Error("expected error for 64 character label")
+	}
+	if err := CheckLength(strings.Repeat(strings.Repeat("a", 60)+".", 5) + "com"); err == nil {
+		t.Error("expected error for hostname longer than 253 characters")
+	}
+}
+
+func TestAliases(t *testing.T) {
+	identity := testIdentity()
+	primary := "api-ns-d-dev-shop.example.com"
+
+	hosts := Aliases(identity, primary, []string{
+		"portal.corp.com.",
+		"portal",
+		"Portal.example.com.",
+		"api.v2",
+		"",
+		"api-ns-d-dev-shop",
+		"api-ns-d-dev-shop.example.com.",
+	})
+
+	expected := []string{"portal.corp.com", "portal.example.com", "api.v2.example.com"}
+	if len(hosts) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, hosts)
+	}
+	for i := range expected {
+		if hosts[i] != expected[i] {
+			t.Errorf("alias %d mismatch: got %s, want %s", i, hosts[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RecordSetState is the processing state of a published record set
+type RecordSetState struct {
+	// Key identifies the record set, see dnsbackend.RecordSet.Key
+	Key string
+	// Controller is the ExternalDNS controller publishing the record set
+	Controller string
+	// Synced is true once the DNS provider has processed the current revision
+	Synced bool
+}
+
+// DNSReadyTracker measures how long the DNS provider takes to process record sets.
+// The clock of a record set starts when it is first seen pending and stops when it is synced.
+type DNSReadyTracker struct {
+	observer prometheus.ObserverVec
+	now      func() time.Time
+
+	mu sync.Mutex
+	// pending holds the start time of the pending record sets per owner
+	pending map[string]map[string]time.Time
+}
+
+// NewDNSReadyTracker returns a tracker observing into an observer with kind and controller labels
+func NewDNSReadyTracker(observer prometheus.ObserverVec) *DNSReadyTracker {
+	return &DNSReadyTracker{
+		observer: observer,
+		now:      time.Now,
+		pending:  map[string]map[string]time.Time{},
+	}
+}
+
+// Observe records the state of all record sets published for an owner of the given kind.
+// Record sets that became synced are observed, pending record sets no longer published
+// are forgotten. Observing no record sets forgets the owner.
+func (t *DNSReadyTracker) Observe(kind, owner string, states []RecordSetState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ownerKey := kind + "/" + owner
+	previous := t.pending[ownerKey]
+	current := map[string]time.Time{}
+	for _, state := range states {
+		started, wasPending := previous[state.Key]
+		switch {
+		case !state.Synced && wasPending:
+			current[state.Key] = started
+		case !state.Synced:
+			current[state.Key] = t.now()
+		case wasPending:
+			t.observer.WithLabelValues(kind, state.Controller).Observe(t.now().Sub(started).Seconds())
+		}
+	}
+
+	if len(current) == 0 {
+		delete(t.pending, ownerKey)
+		return
+	}
+	t.pending[ownerKey] = current
+}
+
+// Forget drops the pending record sets of an owner of the given kind without observing them.
+// Call it when the owner is deleted or withdraws its records, so no start time is kept for it.
+func (t *DNSReadyTracker) Forget(kind, owner string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, kind+"/"+owner)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// observations returns the number and sum of the observations of a histogram
+func observations(t *testing.T, histogram *prometheus.HistogramVec, kind, controller string) (uint64, float64) {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(histogram)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["kind"] == kind && labels["controller"] == controller {
+				return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	return 0, 0
+}
+
+func TestDNSReadyTracker(t *testing.T) {
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_dns_ready_seconds"}, []string{"kind", "controller"})
+	tracker := NewDNSReadyTracker(histogram)
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+
+	weu := RecordSetState{Key: "default/api-external-dns-weu", Controller: "external-dns-weu"}
+	neu := RecordSetState{Key: "default/api-external-dns-neu", Controller: "external-dns-neu"}
+
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{weu, neu})
+
+	// The clock keeps running while a record set stays pending
+	now = now.Add(5 * time.Second)
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{weu, neu})
+
+	now = now.Add(5 * time.Second)
+	weu.Synced = true
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{weu, neu})
+	if count, sum := observations(t, histogram, "ServiceRoute", "external-dns-weu"); count != 1 || sum != 10 {
+		t.Errorf("got %d observations of %vs, want 1 of 10s", count, sum)
+	}
+
+	// A synced record set is only observed once
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{weu, neu})
+	if count, _ := observations(t, histogram, "ServiceRoute", "external-dns-weu"); count != 1 {
+		t.Errorf("got %d observations, want 1", count)
+	}
+
+	// A withdrawn pending record set is forgotten
+	tracker.Observe("ServiceRoute", "default/api", nil)
+	if len(tracker.pending) != 0 {
+		t.Errorf("expected no pending record sets, got %v", tracker.pending)
+	}
+	neu.Synced = true
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{neu})
+	if count, _ := observations(t, histogram, "ServiceRoute", "external-dns-neu"); count != 0 {
+		t.Errorf("got %d observations of a forgotten record set, want 0", count)
+	}
+
+	// A deleted owner is forgotten without an observation
+	neu.Synced = false
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{neu})
+	tracker.Forget("ServiceRoute", "default/api")
+	if len(tracker.pending) != 0 {
+		t.Errorf("expected no pending record sets after Forget, got %v", tracker.pending)
+	}
+	neu.Synced = true
+	tracker.Observe("ServiceRoute", "default/api", []RecordSetState{neu})
+	if count, _ := observations(t, histogram, "ServiceRoute", "external-dns-neu"); count != 0 {
+		t.Errorf("got %d observations of a forgotten owner, want 0", count)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Prometheus metrics of the operator.
+// The metrics are registered on the controller-runtime registry,
+// so they are served on the metrics endpoint of the manager.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// namespace prefixes the names of all metrics of the operator
+const namespace = "service_router"
+
+// DNSReadySeconds is the time the DNS provider takes to process a published record set,
+// from the first reconciliation that finds it pending until the provider has observed it
+var DNSReadySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Name:      "dns_ready_seconds",
+	Help:      "Time from publishing a DNS record set until the DNS provider has processed it.",
+	Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+}, []string{"kind", "controller"})
+
+// DNSReady tracks the record sets of the routing controllers into DNSReadySeconds
+var DNSReady = NewDNSReadyTracker(DNSReadySeconds)
+
+// StatusUpdateConflicts counts status updates rejected because the resource was modified
+// concurrently. The reconciliation is retried, a steady rate points at competing writers.
+var StatusUpdateConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "status_update_conflicts_total",
+	Help:      "Status updates rejected because the resource was modified concurrently.",
+}, []string{"kind"})
+
+// FailoverEvents counts the takeovers and handbacks of peer regions by Failover mode policies
+var FailoverEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "failover_events_total",
+	Help:      "Takeovers and handbacks of peer regions by Failover mode DNS policies.",
+}, []string{"kind", "namespace", "name", "region", "event"})
+
+// GatewayHosts is the number of hosts accepted by the generated gateway of a Gateway
+var GatewayHosts = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "gateway_hosts",
+	Help:      "Hosts accepted by the gateway generated for a Gateway.",
+}, []string{"namespace", "name"})
+
+const (
+	// FailoverEventTakeover is a peer region taken over
+	FailoverEventTakeover = "takeover"
+	// FailoverEventHandback is a peer region handed back
+	FailoverEventHandback = "handback"
+)
+
+func init() {
+	metrics.Registry.MustRegister(DNSReadySeconds, StatusUpdateConflicts, FailoverEvents, GatewayHosts)
+}
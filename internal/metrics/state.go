@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+var statelog = logf.Log.WithName("metrics")
+
+// stateListTimeout bounds the listing of resources on a scrape
+const stateListTimeout = 10 * time.Second
+
+var (
+	serviceRoutesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "serviceroutes"),
+		"ServiceRoutes by phase and reason of the Ready condition.",
+		[]string{"namespace", "environment", "phase", "reason"}, nil,
+	)
+	gatewaysDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "gateways"),
+		"Gateways by phase and reason of the Ready condition.",
+		[]string{"namespace", "phase", "reason"}, nil,
+	)
+	dnsPoliciesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dnspolicies"),
+		"DNSPolicies and ClusterDNSPolicies by phase and reason of the Ready condition.",
+		[]string{"kind", "namespace", "phase", "reason"}, nil,
+	)
+	dnsPolicyActiveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dnspolicy_active"),
+		"Whether a DNSPolicy or ClusterDNSPolicy is active for this cluster (1) or not (0).",
+		[]string{"kind", "namespace", "name", "mode"}, nil,
+	)
+	dnsPolicyActiveControllersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dnspolicy_active_controllers"),
+		"ExternalDNS controllers a DNSPolicy or ClusterDNSPolicy currently publishes to.",
+		[]string{"kind", "namespace", "name"}, nil,
+	)
+	dnsEndpointsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dnsendpoints"),
+		"Published DNS record sets by ExternalDNS controller and region.",
+		[]string{"controller", "region"}, nil,
+	)
+)
+
+// StateCollector reports the state of the routing resources on every scrape.
+// It reads from the informer cache of the manager, so a scrape does not reach the API server.
+type StateCollector struct {
+	reader  client.Reader
+	backend dnsbackend.DNSBackend
+}
+
+// NewStateCollector returns a collector reading the resources from reader
+// and the published record sets from backend
+func NewStateCollector(reader client.Reader, backend dnsbackend.DNSBackend) *StateCollector {
+	return &StateCollector{reader: reader, backend: backend}
+}
+
+// Describe implements prometheus.Collector
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- serviceRoutesDesc
+	ch <- gatewaysDesc
+	ch <- dnsPoliciesDesc
+	ch <- dnsPolicyActiveDesc
+	ch <- dnsPolicyActiveControllersDesc
+	ch <- dnsEndpointsDesc
+}
+
+// Collect implements prometheus.Collector. A resource type that cannot be listed is left out of the scrape.
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), stateListTimeout)
+	defer cancel()
+
+	if err := c.collectServiceRoutes(ctx, ch); err != nil {
+		statelog.Error(err, "failed to list ServiceRoutes")
+	}
+	if err := c.collectGateways(ctx, ch); err != nil {
+		statelog.Error(err, "failed to list Gateways")
+	}
+	if err := c.collectDNSPolicies(ctx, ch); err != nil {
+		statelog.Error(err, "failed to list DNSPolicies")
+	}
+	if err := c.collectDNSEndpoints(ctx, ch); err != nil {
+		statelog.Error(err, "failed to list DNS record sets")
+	}
+}
+
+// readyReason returns the reason of the Ready condition, empty without one
+func readyReason(conditions []metav1.Condition) string {
+	condition := meta.FindStatusCondition(conditions, consts.ConditionTypeReady)
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+// counter counts resources per combination of label values; emit reports the first labels values as a gauge
+type counter map[[4]string]float64
+
+func (c counter) emit(ch chan<- prometheus.Metric, desc *prometheus.Desc, labels int) {
+	for key, value := range c {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, key[:labels]...)
+	}
+}
+
+func (c *StateCollector) collectServiceRoutes(ctx context.Context, ch chan<- prometheus.Metric) error {
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := c.reader.List(ctx, &serviceRoutes); err != nil {
+		return err
+	}
+
+	counts := counter{}
+	for _, route := range serviceRoutes.Items {
+		counts[[4]string{route.Namespace, route.Spec.Environment, route.Status.Phase, readyReason(route.Status.Conditions)}]++
+	}
+	counts.emit(ch, serviceRoutesDesc, 4)
+	return nil
+}
+
+func (c *StateCollector) collectGateways(ctx context.Context, ch chan<- prometheus.Metric) error {
+	var gateways routingv1alpha1.GatewayList
+	if err := c.reader.List(ctx, &gateways); err != nil {
+		return err
+	}
+
+	counts := counter{}
+	for _, gateway := range gateways.Items {
+		counts[[4]string{gateway.Namespace, gateway.Status.Phase, readyReason(gateway.Status.Conditions)}]++
+	}
+	counts.emit(ch, gatewaysDesc, 3)
+	return nil
+}
+
+func (c *StateCollector) collectDNSPolicies(ctx context.Context, ch chan<- prometheus.Metric) error {
+	var dnsPolicies routingv1alpha1.DNSPolicyList
+	if err := c.reader.List(ctx, &dnsPolicies); err != nil {
+		return err
+	}
+	var clusterDNSPolicies routingv1alpha1.ClusterDNSPolicyList
+	if err := c.reader.List(ctx, &clusterDNSPolicies); err != nil {
+		return err
+	}
+
+	counts := counter{}
+	policy := func(kind, namespace, name, mode string, status *routingv1alpha1.DNSPolicyStatus) {
+		counts[[4]string{kind, namespace, status.Phase, readyReason(status.Conditions)}]++
+
+		active := 0.0
+		if status.Active {
+			active = 1
+		}
+		ch <- prometheus.MustNewConstMetric(dnsPolicyActiveDesc, prometheus.GaugeValue, active, kind, namespace, name, mode)
+		ch <- prometheus.MustNewConstMetric(dnsPolicyActiveControllersDesc, prometheus.GaugeValue,
+			float64(len(status.ActiveControllers)), kind, namespace, name)
+	}
+	for i := range dnsPolicies.Items {
+		dnsPolicy := &dnsPolicies.Items[i]
+		policy("DNSPolicy", dnsPolicy.Namespace, dnsPolicy.Name, dnsPolicy.Spec.Mode, &dnsPolicy.Status)
+	}
+	for i := range clusterDNSPolicies.Items {
+		clusterDNSPolicy := &clusterDNSPolicies.Items[i]
+		policy("ClusterDNSPolicy", "", clusterDNSPolicy.Name, clusterDNSPolicy.Spec.Mode, &clusterDNSPolicy.Status)
+	}
+	counts.emit(ch, dnsPoliciesDesc, 4)
+	return nil
+}
+
+func (c *StateCollector) collectDNSEndpoints(ctx context.Context, ch chan<- prometheus.Metric) error {
+	recordSets, err := c.backend.List(ctx, "", map[string]string{"app.kubernetes.io/managed-by": "service-router-operator"})
+	if err != nil {
+		return err
+	}
+
+	counts := counter{}
+	for _, set := range recordSets {
+		counts[[4]string{set.Labels["router.io/controller"], set.Labels["router.io/region"]}]++
+	}
+	counts.emit(ch, dnsEndpointsDesc, 2)
+	return nil
+}
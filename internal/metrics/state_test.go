@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	externaldnsv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsbackend"
+)
+
+// gauges returns the values of a gathered metric family keyed by their label values
+func gauges(t *testing.T, registry *prometheus.Registry, name string) map[string]float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			key := ""
+			for _, label := range metric.GetLabel() {
+				key += label.GetName() + "=" + label.GetValue() + ","
+			}
+			values[key] = metric.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func TestStateCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := routingv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := externaldnsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ready := func(reason string) []metav1.Condition {
+		return []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: reason}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec:       routingv1alpha1.ServiceRouteSpec{Environment: "prod"},
+			Status:     routingv1alpha1.ServiceRouteStatus{Phase: "Active", Conditions: ready("ReconciliationSucceeded")},
+		},
+		&routingv1alpha1.ServiceRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       routingv1alpha1.ServiceRouteSpec{Environment: "prod"},
+			Status:     routingv1alpha1.ServiceRouteStatus{Phase: "Active", Conditions: ready("ReconciliationSucceeded")},
+		},
+		&routingv1alpha1.DNSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "shop"},
+			Spec:       routingv1alpha1.DNSPolicySpec{Mode: "Active"},
+			Status: routingv1alpha1.DNSPolicyStatus{
+				Phase:             "Active",
+				Active:            true,
+				ActiveControllers: []string{"external-dns-weu", "external-dns-neu"},
+			},
+		},
+		&externaldnsv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-external-dns-weu",
+			Namespace: "shop",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "service-router-operator",
+				"router.io/controller":         "external-dns-weu",
+				"router.io/region":             "weu",
+			},
+		}},
+		&externaldnsv1alpha1.DNSEndpoint{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "shop"}},
+	).Build()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewStateCollector(c, dnsbackend.NewExternalDNS(c)))
+
+	tests := []struct {
+		name string
+		want map[string]float64
+	}{
+		{
+			name: "service_router_serviceroutes",
+			want: map[string]float64{"environment=prod,namespace=shop,phase=Active,reason=ReconciliationSucceeded,": 2},
+		},
+		{
+			name: "service_router_dnspolicies",
+			want: map[string]float64{"kind=DNSPolicy,namespace=shop,phase=Active,reason=,": 1},
+		},
+		{
+			name: "service_router_dnspolicy_active",
+			want: map[string]float64{"kind=DNSPolicy,mode=Active,name=policy,namespace=shop,": 1},
+		},
+		{
+			name: "service_router_dnspolicy_active_controllers",
+			want: map[string]float64{"kind=DNSPolicy,name=policy,namespace=shop,": 2},
+		},
+		{
+			name: "service_router_dnsendpoints",
+			want: map[string]float64{"controller=external-dns-weu,region=weu,": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gauges(t, registry, tt.name)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("got %v for %s, want %v", got[key], key, value)
+				}
+			}
+		})
+	}
+
+	if got := gauges(t, registry, "service_router_gateways"); len(got) != 0 {
+		t.Errorf("expected no gateway metrics without Gateways, got %v", got)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ownership implements the ownership TXT records the operator publishes next to
+// every CNAME, and the claim/release protocol clusters follow before publishing a name
+// in the zone of another region.
+//
+// An ownership record names the cluster and region publishing a hostname, the DNSPolicy
+// mode it is published under and a generation counter. A cluster claims a name by
+// publishing its ownership record and releases it by withdrawing its records. The
+// generation is incremented on every takeover, so when two clusters claim the same name
+// the most recent claim wins and the other cluster yields.
+package ownership
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Heritage identifies ownership records published by the operator
+	Heritage = "service-router"
+
+	// RecordPrefix is prepended to a hostname to name its ownership record.
+	// A CNAME cannot share its name with other records, so the TXT record lives next to it.
+	RecordPrefix = "_router-owner."
+)
+
+// Record is the content of an ownership TXT record
+type Record struct {
+	// Cluster and Region identify the cluster publishing the hostname
+	Cluster string
+	Region  string
+
+	// Mode is the mode of the DNSPolicy the hostname is published under
+	Mode string
+
+	// Generation is incremented every time the hostname is taken over by another cluster
+	Generation int64
+}
+
+// RecordName returns the name of the ownership record of a hostname
+func RecordName(host string) string {
+	return RecordPrefix + host
+}
+
+// String formats the record as TXT record value,
+// e.g. "heritage=service-router,cluster=aks,region=weu,mode=RegionBound,generation=2"
+func (r Record) String() string {
+	return fmt.Sprintf("heritage=%s,cluster=%s,region=%s,mode=%s,generation=%d",
+		Heritage, r.Cluster, r.Region, r.Mode, r.Generation)
+}
+
+// Owner describes the cluster holding the record, for condition messages
+func (r Record) Owner() string {
+	return fmt.Sprintf("cluster %s in region %s (generation %d)", r.Cluster, r.Region, r.Generation)
+}
+
+// SameOwner reports whether both records were published by the same cluster
+func (r Record) SameOwner(other Record) bool {
+	return r.Cluster == other.Cluster && r.Region == other.Region
+}
+
+// Parse parses a TXT record value. It returns false for values that are not ownership
+// records of the operator, such as the registry records of ExternalDNS.
+func Parse(value string) (Record, bool) {
+	var record Record
+	fields := make(map[string]string)
+	for _, field := range strings.Split(strings.Trim(value, `"`), ",") {
+		key, val, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !found {
+			return Record{}, false
+		}
+		fields[key] = val
+	}
+	if fields["heritage"] != Heritage || fields["cluster"] == "" || fields["region"] == "" {
+		return Record{}, false
+	}
+
+	generation, err := strconv.ParseInt(fields["generation"], 10, 64)
+	if err != nil || generation < 1 {
+		return Record{}, false
+	}
+
+	record.Cluster = fields["cluster"]
+	record.Region = fields["region"]
+	record.Mode = fields["mode"]
+	record.Generation = generation
+	return record, true
+}
+
+// ParseAll returns the ownership records among TXT record values
+func ParseAll(values []string) []Record {
+	var records []Record
+	for _, value := range values {
+		if record, ok := Parse(value); ok {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Claim is the outcome of a claim on a hostname
+type Claim struct {
+	// Allowed is true when the cluster may publish the hostname
+	Allowed bool
+
+	// Generation is the generation to publish when the claim is allowed
+	Generation int64
+
+	// Holder is the conflicting claim of another cluster, if any
+	Holder *Record
+}
+
+// Decide evaluates the claim of self on a hostname against the ownership records observed in DNS.
+//
+// self.Generation is the generation self currently publishes, 0 when it does not publish the
+// hostname yet. takeover reports whether self may take the hostname over from another cluster,
+// e.g. because the region of that cluster failed.
+//
+//   - Without claims of other clusters the hostname is free: self claims it with its current
+//     generation, or generation 1 for a new claim.
+//   - A hostname claimed by another cluster is only taken over when takeover allows it,
+//     with a generation one higher than the claim it replaces.
+//   - When self and another cluster both publish the hostname, the higher generation wins,
+//     equal generations are decided by cluster and region name. The loser yields and
+//     releases the hostname by withdrawing its records.
+func Decide(self Record, observed []Record, takeover func(holder Record) bool) Claim {
+	var others []Record
+	for _, record := range observed {
+		if record.SameOwner(self) {
+			// What self publishes is known from its own records, DNS may lag behind
+			continue
+		}
+		others = append(others, record)
+	}
+
+	if len(others) == 0 {
+		return Claim{Allowed: true, Generation: max(self.Generation, 1)}
+	}
+
+	// The holder is the most recent claim of another cluster
+	sort.Slice(others, func(i, j int) bool {
+		if others[i].Generation != others[j].Generation {
+			return others[i].Generation > others[j].Generation
+		}
+		return ownerKey(others[i]) < ownerKey(others[j])
+	})
+	holder := others[0]
+
+	if self.Generation > 0 {
+		if holder.Generation > self.Generation ||
+			(holder.Generation == self.Generation && ownerKey(holder) < ownerKey(self)) {
+			return Claim{Holder: &holder}
+		}
+		return Claim{Allowed: true, Generation: self.Generation}
+	}
+
+	if takeover != nil && takeover(holder) {
+		return Claim{Allowed: true, Generation: holder.Generation + 1}
+	}
+	return Claim{Holder: &holder}
+}
+
+// ownerKey orders clusters to decide between claims of equal generation
+func ownerKey(record Record) string {
+	return record.Cluster + "." + record.Region
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"testing"
+)
+
+func TestRecordFormatAndParse(t *testing.T) {
+	record := Record{Cluster: "aks", Region: "weu", Mode: "RegionBound", Generation: 2}
+
+	value := record.String()
+	if value != "heritage=service-router,cluster=aks,region=weu,mode=RegionBound,generation=2" {
+		t.Errorf("unexpected record value %q", value)
+	}
+
+	parsed, ok := Parse(`"` + value + `"`)
+	if !ok || parsed != record {
+		t.Errorf("got %+v, %v, want %+v", parsed, ok, record)
+	}
+
+	for _, value := range []string{
+		"heritage=external-dns,external-dns/owner=external-dns-weu",
+		"heritage=service-router,cluster=aks,region=weu,mode=Active",
+		"heritage=service-router,cluster=aks,region=weu,mode=Active,generation=0",
+		"heritage=service-router,region=weu,mode=Active,generation=1",
+		"not a record",
+	} {
+		if _, ok := Parse(value); ok {
+			t.Errorf("expected %q not to be parsed", value)
+		}
+	}
+
+	if name := RecordName("api.example.com"); name != "_router-owner.api.example.com" {
+		t.Errorf("unexpected record name %q", name)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	self := Record{Cluster: "aks", Region: "weu", Mode: "RegionBound"}
+	other := Record{Cluster: "aks", Region: "neu", Mode: "RegionBound", Generation: 1}
+	allowTakeover := func(Record) bool { return true }
+
+	tests := []struct {
+		name           string
+		generation     int64
+		observed       []Record
+		takeover       func(Record) bool
+		wantAllowed    bool
+		wantGeneration int64
+	}{
+		{
+			name:           "unclaimed hostname",
+			wantAllowed:    true,
+			wantGeneration: 1,
+		},
+		{
+			name:           "own claim is kept",
+			generation:     3,
+			observed:       []Record{{Cluster: "aks", Region: "weu", Generation: 2}},
+			wantAllowed:    true,
+			wantGeneration: 3,
+		},
+		{
+			name:     "hostname claimed by another cluster",
+			observed: []Record{other},
+		},
+		{
+			name:           "takeover of another cluster",
+			observed:       []Record{other},
+			takeover:       allowTakeover,
+			wantAllowed:    true,
+			wantGeneration: 2,
+		},
+		{
+			name:       "newer claim of another cluster wins",
+			generation: 1,
+			observed:   []Record{{Cluster: "aks02", Region: "weu", Generation: 2}},
+		},
+		{
+			name:           "older claim of another cluster yields",
+			generation:     2,
+			observed:       []Record{other},
+			wantAllowed:    true,
+			wantGeneration: 2,
+		},
+		{
+			name:       "equal generations are decided by name",
+			generation: 1,
+			observed:   []Record{other},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimant := self
+			claimant.Generation = tt.generation
+
+			claim := Decide(claimant, tt.observed, tt.takeover)
+			if claim.Allowed != tt.wantAllowed {
+				t.Fatalf("got allowed %v, want %v", claim.Allowed, tt.wantAllowed)
+			}
+			if claim.Allowed && claim.Generation != tt.wantGeneration {
+				t.Errorf("got generation %d, want %d", claim.Generation, tt.wantGeneration)
+			}
+			if !claim.Allowed && claim.Holder == nil {
+				t.Error("expected the holder of the conflicting claim")
+			}
+		})
+	}
+}
+
+func TestNameserverAddress(t *testing.T) {
+	if address := nameserverAddress("10.0.0.10"); address != "10.0.0.10:53" {
+		t.Errorf("unexpected address %q", address)
+	}
+	if address := nameserverAddress("dns.example.com:5353"); address != "dns.example.com:5353" {
+		t.Errorf("unexpected address %q", address)
+	}
+}
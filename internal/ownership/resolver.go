@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownership
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net"
+)
+
+// Resolver looks up the ownership records published for a hostname
+type Resolver interface {
+	// Lookup returns the ownership records of host, none when the hostname is not claimed
+	Lookup(ctx context.Context, host string) ([]Record, error)
+}
+
+// Config enables and configures the ownership check
+type Config struct {
+	// Enabled looks up the claims of other clusters before publishing
+	Enabled bool
+
+	// Nameserver is the host:port ownership records are looked up on, empty uses the pod resolvers
+	Nameserver string
+}
+
+// BindFlags registers the flags of the ownership check.
+// The manager and the commands reading its records share them.
+func (c *Config) BindFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "ownership-check", false,
+		"Look up the ownership TXT records of other clusters before publishing a hostname in the zone of another region. "+
+			"Without it the claim/release protocol is not followed: every hostname is published without looking at "+
+			"the claims of other clusters and no TakeoverConflict is reported.")
+	fs.StringVar(&c.Nameserver, "ownership-nameserver", "",
+		"The host:port of the DNS server ownership records are looked up on. Uses the pod resolvers when empty.")
+}
+
+// Resolver returns the Resolver of the ownership check, nil when it is disabled
+func (c *Config) Resolver() Resolver {
+	if !c.Enabled {
+		return nil
+	}
+	return NewDNSResolver(c.Nameserver)
+}
+
+// dnsResolver looks up ownership records in DNS
+type dnsResolver struct {
+	resolver *net.Resolver
+}
+
+// NewDNSResolver returns a Resolver querying nameserver (host or host:port).
+// An empty nameserver uses the resolvers of the operator pod.
+func NewDNSResolver(nameserver string) Resolver {
+	if nameserver == "" {
+		return &dnsResolver{resolver: net.DefaultResolver}
+	}
+
+	address := nameserverAddress(nameserver)
+	return &dnsResolver{resolver: &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}}
+}
+
+// Lookup implements Resolver
+func (r *dnsResolver) Lookup(ctx context.Context, host string) ([]Record, error) {
+	values, err := r.resolver.LookupTXT(ctx, RecordName(host))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ParseAll(values), nil
+}
+
+// nameserverAddress adds the default DNS port to a nameserver without port
+func nameserverAddress(nameserver string) string {
+	if _, _, err := net.SplitHostPort(nameserver); err == nil {
+		return nameserver
+	}
+	return net.JoinHostPort(nameserver, "53")
+}
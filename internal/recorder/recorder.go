@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recorder records Kubernetes events for the state transitions of the operator's resources.
+// Events are only recorded when something changes, so a resource in a steady state does not fill
+// the event stream on every reconcile.
+package recorder
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// ActionReconcile is the action of the events recording a Ready condition transition
+const ActionReconcile = "Reconcile"
+
+// Eventf records an event on regarding. A nil recorder records nothing,
+// so reconcilers constructed without one, as in tests, keep working.
+func Eventf(recorder events.EventRecorder, regarding runtime.Object, eventType, reason, action, note string, args ...any) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(regarding, nil, eventType, reason, action, note, args...)
+}
+
+// Ready returns a copy of the Ready condition, the zero condition without one.
+// Take it before the condition is updated and pass it to ReadyTransition.
+func Ready(conditions []metav1.Condition) metav1.Condition {
+	condition := meta.FindStatusCondition(conditions, consts.ConditionTypeReady)
+	if condition == nil {
+		return metav1.Condition{}
+	}
+	return *condition
+}
+
+// ReadyTransition records the Ready condition in conditions as an event of eventType when its status
+// or reason differs from previous. The event carries the reason and message of the condition.
+func ReadyTransition(
+	recorder events.EventRecorder,
+	regarding runtime.Object,
+	eventType string,
+	previous metav1.Condition,
+	conditions []metav1.Condition,
+) {
+	current := Ready(conditions)
+	if current.Type == "" || (current.Status == previous.Status && current.Reason == previous.Reason) {
+		return
+	}
+	Eventf(recorder, regarding, eventType, current.Reason, ActionReconcile, "%s", current.Message)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+)
+
+// recorded drains the events recorded so far
+func recorded(recorder *events.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+func TestReadyTransition(t *testing.T) {
+	fake := events.NewFakeRecorder(10)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	ready := func(status metav1.ConditionStatus, reason, message string) []metav1.Condition {
+		return []metav1.Condition{{Type: "Ready", Status: status, Reason: reason, Message: message}}
+	}
+
+	tests := []struct {
+		name       string
+		previous   []metav1.Condition
+		conditions []metav1.Condition
+		eventType  string
+		want       []string
+	}{
+		{
+			name:       "first Ready condition",
+			conditions: ready(metav1.ConditionTrue, "ReconciliationSucceeded", "ServiceRoute is active"),
+			eventType:  corev1.EventTypeNormal,
+			want:       []string{"Normal ReconciliationSucceeded ServiceRoute is active"},
+		},
+		{
+			name:       "unchanged condition",
+			previous:   ready(metav1.ConditionTrue, "ReconciliationSucceeded", "ServiceRoute is active"),
+			conditions: ready(metav1.ConditionTrue, "ReconciliationSucceeded", "ServiceRoute is active"),
+			eventType:  corev1.EventTypeNormal,
+		},
+		{
+			name:       "changed message only",
+			previous:   ready(metav1.ConditionFalse, "ValidationFailed", "spec.serviceName is required"),
+			conditions: ready(metav1.ConditionFalse, "ValidationFailed", "spec.environment is required"),
+			eventType:  corev1.EventTypeWarning,
+		},
+		{
+			name:       "changed reason",
+			previous:   ready(metav1.ConditionTrue, "ReconciliationSucceeded", "ServiceRoute is active"),
+			conditions: ready(metav1.ConditionFalse, "SingletonViolation", "another ClusterIdentity exists"),
+			eventType:  corev1.EventTypeWarning,
+			want:       []string{"Warning SingletonViolation another ClusterIdentity exists"},
+		},
+		{
+			name:      "no Ready condition",
+			previous:  ready(metav1.ConditionTrue, "ReconciliationSucceeded", "ServiceRoute is active"),
+			eventType: corev1.EventTypeNormal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ReadyTransition(fake, pod, tt.eventType, Ready(tt.previous), tt.conditions)
+			got := recorded(fake)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got events %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got event %q, want %q", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEventfWithoutRecorder(t *testing.T) {
+	// Reconcilers constructed without a recorder must not panic
+	Eventf(nil, &corev1.Pod{}, corev1.EventTypeNormal, "Reason", ActionReconcile, "note")
+	ReadyTransition(nil, &corev1.Pod{}, corev1.EventTypeNormal, metav1.Condition{},
+		[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconciliationSucceeded"}})
+}
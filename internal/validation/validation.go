@@ -0,0 +1,520 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation holds the rules applied to the operator's custom resources.
+// Both the reconcilers and the admission webhooks call into this package
+// so an object is judged the same way on admission and on reconciliation.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/hostname"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// targetPostfixPattern matches lowercase alphanumeric values separated by hyphens
+var targetPostfixPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ServiceRoute validates the ServiceRoute specification
+func ServiceRoute(serviceRoute *routingv1alpha1.ServiceRoute) error {
+	if serviceRoute.Spec.ServiceName == "" {
+		return fmt.Errorf("serviceName must be specified")
+	}
+	if serviceRoute.Spec.GatewayName == "" {
+		return fmt.Errorf("gatewayName must be specified")
+	}
+	if serviceRoute.Spec.Environment == "" {
+		return fmt.Errorf("environment must be specified")
+	}
+	if serviceRoute.Spec.Application == "" {
+		return fmt.Errorf("application must be specified")
+	}
+	if backend := serviceRoute.Spec.Backend; backend != nil {
+		if backend.ServiceName == "" {
+			return fmt.Errorf("backend.serviceName must be specified")
+		}
+		if backend.Port < 1 || backend.Port > 65535 {
+			return fmt.Errorf("backend.port must be between 1 and 65535, got %d", backend.Port)
+		}
+		for _, prefix := range backend.PathPrefixes {
+			if !strings.HasPrefix(prefix, "/") {
+				return fmt.Errorf("backend.pathPrefixes must start with '/': %s", prefix)
+			}
+		}
+	}
+	if serviceRoute.Spec.HealthGated && serviceRoute.Spec.Backend == nil {
+		return fmt.Errorf("healthGated requires a backend to check")
+	}
+	return nil
+}
+
+// ServiceRouteHosts renders the hostnames of a ServiceRoute, the generated hostname first
+func ServiceRouteHosts(serviceRoute *routingv1alpha1.ServiceRoute, identity *clusteridentity.ClusterIdentity) ([]string, error) {
+	sourceHost, err := hostname.SourceHost(identity,
+		serviceRoute.Spec.ServiceName,
+		serviceRoute.Spec.Environment,
+		serviceRoute.Spec.Application,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{sourceHost}, hostname.Aliases(identity, sourceHost, serviceRoute.Spec.Aliases)...), nil
+}
+
+// ServiceRouteHostnames renders the hostnames of a ServiceRoute and checks them against the DNS limits
+func ServiceRouteHostnames(serviceRoute *routingv1alpha1.ServiceRoute, identity *clusteridentity.ClusterIdentity) error {
+	hosts, err := ServiceRouteHosts(serviceRoute, identity)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if err := hostname.CheckLength(host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServiceRouteHostnameClaim ensures a ServiceRoute only claims hostnames that no ServiceRoute in
+// another namespace uses. A claim wins every hostname conflict, so it would otherwise let any
+// namespace take over the hostnames of another namespace.
+func ServiceRouteHostnameClaim(
+	ctx context.Context,
+	c client.Reader,
+	serviceRoute *routingv1alpha1.ServiceRoute,
+	identity *clusteridentity.ClusterIdentity,
+) error {
+	if serviceRoute.Annotations[consts.AnnotationHostnameClaim] != "true" {
+		return nil
+	}
+
+	hosts, err := ServiceRouteHosts(serviceRoute, identity)
+	if err != nil {
+		return err
+	}
+
+	var serviceRoutes routingv1alpha1.ServiceRouteList
+	if err := c.List(ctx, &serviceRoutes); err != nil {
+		return fmt.Errorf("failed to list ServiceRoutes: %w", err)
+	}
+
+	for i := range serviceRoutes.Items {
+		other := &serviceRoutes.Items[i]
+		if other.Namespace == serviceRoute.Namespace || other.DeletionTimestamp != nil {
+			continue
+		}
+		otherHosts, err := ServiceRouteHosts(other, identity)
+		if err != nil {
+			continue
+		}
+		for _, host := range otherHosts {
+			if slices.Contains(hosts, host) {
+				return fmt.Errorf("%s cannot claim hostname %s, it is used by ServiceRoute %s/%s in another namespace",
+					consts.AnnotationHostnameClaim, host, other.Namespace, other.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxGatewayAPIDurationValue is the largest number a Gateway API Duration holds per unit
+const maxGatewayAPIDurationValue = 99999
+
+// ServiceRouteGateway checks the ServiceRoute backend against the implementation of its Gateway.
+// HTTPRoutes have no retry policy, so a Gateway API Gateway cannot honour backend.retries,
+// and their timeouts only take whole units, see GatewayAPIDuration.
+func ServiceRouteGateway(serviceRoute *routingv1alpha1.ServiceRoute, gateway *routingv1alpha1.Gateway) error {
+	backend := serviceRoute.Spec.Backend
+	if backend == nil || gateway.Spec.Implementation != consts.ImplementationGatewayAPI {
+		return nil
+	}
+	if backend.Retries != nil {
+		return fmt.Errorf("backend.retries is not supported by Gateway %s/%s, implementation %s has no retry policy",
+			gateway.Namespace, gateway.Name, consts.ImplementationGatewayAPI)
+	}
+	if backend.Timeout != nil {
+		if _, err := GatewayAPIDuration(backend.Timeout.Duration); err != nil {
+			return fmt.Errorf("backend.timeout is not supported by Gateway %s/%s: %w", gateway.Namespace, gateway.Name, err)
+		}
+	}
+	return nil
+}
+
+// GatewayAPIDuration writes d as a Gateway API Duration, a whole number of hours, minutes, seconds
+// or milliseconds of at most five digits. Negative durations and durations with a fraction
+// of a millisecond cannot be written that way.
+func GatewayAPIDuration(d time.Duration) (string, error) {
+	if d < 0 {
+		return "", fmt.Errorf("duration %s is negative", d)
+	}
+	if d == 0 {
+		return "0s", nil
+	}
+
+	for _, unit := range []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+	} {
+		if d%unit.size == 0 && d/unit.size <= maxGatewayAPIDurationValue {
+			return fmt.Sprintf("%d%s", d/unit.size, unit.suffix), nil
+		}
+	}
+	return "", fmt.Errorf("duration %s is not a whole number of at most %d hours, minutes, seconds or milliseconds",
+		d, maxGatewayAPIDurationValue)
+}
+
+// Gateway validates the Gateway specification.
+// gatewayAPIEnabled reports whether the operator runs with Gateway API support.
+func Gateway(gateway *routingv1alpha1.Gateway, gatewayAPIEnabled bool) error {
+	// Validate controller is not empty
+	if gateway.Spec.Controller == "" {
+		return fmt.Errorf("controller must be specified")
+	}
+
+	// Validate credentialName is not empty
+	if gateway.Spec.CredentialName == "" {
+		return fmt.Errorf("credentialName must be specified")
+	}
+
+	// Validate targetPostfix is not empty
+	if gateway.Spec.TargetPostfix == "" {
+		return fmt.Errorf("targetPostfix must be specified")
+	}
+
+	// Validate targetPostfix format (should be lowercase alphanumeric with hyphens)
+	if !targetPostfixPattern.MatchString(gateway.Spec.TargetPostfix) {
+		return fmt.Errorf("targetPostfix must be lowercase alphanumeric with hyphens: %s", gateway.Spec.TargetPostfix)
+	}
+
+	if gateway.Spec.Implementation == consts.ImplementationGatewayAPI {
+		if !gatewayAPIEnabled {
+			return fmt.Errorf("implementation %s requires the operator to run with --enable-gateway-api",
+				consts.ImplementationGatewayAPI)
+		}
+		if gateway.Spec.GatewayClassName == "" {
+			return fmt.Errorf("gatewayClassName must be specified when implementation is %s",
+				consts.ImplementationGatewayAPI)
+		}
+	}
+
+	return nil
+}
+
+// GatewayHostnames renders the target hostname of a Gateway and checks it against the DNS limits
+func GatewayHostnames(gateway *routingv1alpha1.Gateway, identity *clusteridentity.ClusterIdentity) error {
+	targetHost, err := hostname.TargetHost(identity, gateway.Spec.TargetPostfix)
+	if err != nil {
+		return err
+	}
+	return hostname.CheckLength(targetHost)
+}
+
+// DNSPolicy validates the DNSPolicy specification.
+// The DNSConfiguration checks are skipped when dnsConfig is nil.
+func DNSPolicy(dnsPolicy *routingv1alpha1.DNSPolicy, dnsConfig *dnsconfiguration.DNSConfiguration) error {
+	// Validate mode
+	switch dnsPolicy.Spec.Mode {
+	case "Active", "RegionBound":
+		if dnsPolicy.Spec.Failover != nil {
+			return fmt.Errorf("failover is only used in Failover mode")
+		}
+	case "Failover":
+		if dnsPolicy.Spec.Failover == nil {
+			return fmt.Errorf("failover must be specified in Failover mode")
+		}
+	default:
+		return fmt.Errorf("invalid mode: %s, must be Active, RegionBound or Failover", dnsPolicy.Spec.Mode)
+	}
+
+	// Validate controllers are defined in DNSConfiguration
+	if dnsConfig != nil && len(dnsConfig.ExternalDNSControllers) == 0 {
+		return fmt.Errorf("at least one ExternalDNS controller must be defined in DNSConfiguration")
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(dnsPolicy.Spec.RouteSelector); err != nil {
+		return fmt.Errorf("invalid routeSelector: %w", err)
+	}
+
+	if err := dnsPolicyControllerSelection(dnsPolicy, dnsConfig); err != nil {
+		return err
+	}
+
+	if dnsPolicy.Spec.Failover != nil {
+		return dnsPolicyFailover(dnsPolicy.Spec.Failover, dnsPolicy.Namespace, dnsConfig)
+	}
+
+	return nil
+}
+
+// ClusterDNSPolicy validates the cluster scoped parts of a ClusterDNSPolicy.
+// The policy settings it shares with a DNSPolicy are validated by DNSPolicy.
+func ClusterDNSPolicy(policy *routingv1alpha1.ClusterDNSPolicy) error {
+	if _, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	if policy.Spec.RouteSelector != nil {
+		return fmt.Errorf("routeSelector is not supported in a ClusterDNSPolicy, use a DNSPolicy in the namespace")
+	}
+
+	// There is no policy namespace to default the Lease namespace to
+	if policy.Spec.Failover != nil {
+		for _, peer := range policy.Spec.Failover.Peers {
+			if peer.Lease != nil && peer.Lease.Namespace == "" {
+				return fmt.Errorf("failover peer %s: lease.namespace is required in a ClusterDNSPolicy", peer.Region)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dnsPolicyControllerSelection validates the explicit controllers and the region selector of a DNSPolicy
+func dnsPolicyControllerSelection(dnsPolicy *routingv1alpha1.DNSPolicy, dnsConfig *dnsconfiguration.DNSConfiguration) error {
+	spec := dnsPolicy.Spec
+	if len(spec.Controllers) > 0 && len(spec.RegionSelector) > 0 {
+		return fmt.Errorf("controllers and regionSelector are mutually exclusive")
+	}
+	if len(spec.Controllers) > 0 && spec.Mode == "Failover" {
+		return fmt.Errorf("controllers cannot be used in Failover mode, use regionSelector instead")
+	}
+
+	controllers := make(map[string]bool)
+	regions := make(map[string]bool)
+	if dnsConfig != nil {
+		for _, controller := range dnsConfig.ExternalDNSControllers {
+			controllers[controller.Name] = true
+			regions[controller.Region] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(spec.Controllers))
+	for _, name := range spec.Controllers {
+		if name == "" {
+			return fmt.Errorf("controller name cannot be empty")
+		}
+		if seen[name] {
+			return fmt.Errorf("controller %s is listed more than once", name)
+		}
+		seen[name] = true
+
+		if dnsConfig != nil && !controllers[name] {
+			return fmt.Errorf("controller %s is not defined in DNSConfiguration", name)
+		}
+	}
+
+	seen = make(map[string]bool, len(spec.RegionSelector))
+	for _, region := range spec.RegionSelector {
+		if region == "" {
+			return fmt.Errorf("regionSelector region cannot be empty")
+		}
+		if seen[region] {
+			return fmt.Errorf("regionSelector region %s is listed more than once", region)
+		}
+		seen[region] = true
+
+		if dnsConfig != nil && !regions[region] {
+			return fmt.Errorf("regionSelector region %s has no ExternalDNS controller in DNSConfiguration", region)
+		}
+	}
+
+	return nil
+}
+
+// dnsPolicyFailover validates the peers and health signals of a Failover mode DNSPolicy
+func dnsPolicyFailover(
+	failover *routingv1alpha1.DNSPolicyFailover,
+	namespace string,
+	dnsConfig *dnsconfiguration.DNSConfiguration,
+) error {
+	if len(failover.Peers) == 0 {
+		return fmt.Errorf("failover.peers must contain at least one peer region")
+	}
+	if failover.FailureThreshold < 0 || failover.SuccessThreshold < 0 {
+		return fmt.Errorf("failover thresholds must be at least 1")
+	}
+	if failover.ProbeInterval != nil && failover.ProbeInterval.Duration <= 0 {
+		return fmt.Errorf("failover.probeInterval must be positive")
+	}
+	if failover.MinHoldTime != nil && failover.MinHoldTime.Duration < 0 {
+		return fmt.Errorf("failover.minHoldTime cannot be negative")
+	}
+
+	regions := make(map[string]bool)
+	if dnsConfig != nil {
+		for _, controller := range dnsConfig.ExternalDNSControllers {
+			regions[controller.Region] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(failover.Peers))
+	for _, peer := range failover.Peers {
+		if peer.Region == "" {
+			return fmt.Errorf("failover peer region cannot be empty")
+		}
+		if seen[peer.Region] {
+			return fmt.Errorf("failover peer region %s is listed more than once", peer.Region)
+		}
+		seen[peer.Region] = true
+
+		if dnsConfig != nil && !regions[peer.Region] {
+			return fmt.Errorf("failover peer region %s has no ExternalDNS controller in DNSConfiguration", peer.Region)
+		}
+
+		if (peer.HTTPGet == nil) == (peer.Lease == nil) {
+			return fmt.Errorf("failover peer %s must set exactly one of httpGet and lease", peer.Region)
+		}
+		if peer.HTTPGet != nil {
+			if !strings.HasPrefix(peer.HTTPGet.URL, "http://") && !strings.HasPrefix(peer.HTTPGet.URL, "https://") {
+				return fmt.Errorf("failover peer %s: httpGet.url must be an http or https URL: %s", peer.Region, peer.HTTPGet.URL)
+			}
+		}
+		if peer.Lease != nil && peer.Lease.Name == "" {
+			return fmt.Errorf("failover peer %s: lease.name cannot be empty", peer.Region)
+		}
+
+		// A namespaced policy belongs to a tenant: it must not make the operator send requests
+		// to arbitrary URLs or read Leases in other namespaces. Only a ClusterDNSPolicy, which
+		// has no namespace, may probe URLs or Leases anywhere in the cluster.
+		if namespace != "" {
+			if peer.HTTPGet != nil {
+				return fmt.Errorf("failover peer %s: httpGet probes are only supported in a ClusterDNSPolicy", peer.Region)
+			}
+			if peer.Lease.Namespace != "" && peer.Lease.Namespace != namespace {
+				return fmt.Errorf("failover peer %s: lease must be in the namespace of the DNSPolicy (%s)", peer.Region, namespace)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ClusterIdentity validates the ClusterIdentity spec fields
+func ClusterIdentity(cr *clusterv1alpha1.ClusterIdentity) error {
+	if cr.Spec.Region == "" {
+		return fmt.Errorf("region cannot be empty")
+	}
+	if cr.Spec.Cluster == "" {
+		return fmt.Errorf("cluster cannot be empty")
+	}
+	if cr.Spec.Domain == "" {
+		return fmt.Errorf("domain cannot be empty")
+	}
+	if cr.Spec.EnvironmentLetter == "" {
+		return fmt.Errorf("environmentLetter cannot be empty")
+	}
+	if err := hostname.ValidateSource(cr.Spec.SourceHostTemplate); err != nil {
+		return fmt.Errorf("sourceHostTemplate: %w", err)
+	}
+	if err := hostname.ValidateTarget(cr.Spec.TargetHostTemplate); err != nil {
+		return fmt.Errorf("targetHostTemplate: %w", err)
+	}
+	if cr.Spec.MigrationGracePeriod != nil && cr.Spec.MigrationGracePeriod.Duration < 0 {
+		return fmt.Errorf("migrationGracePeriod cannot be negative")
+	}
+	return nil
+}
+
+// ClusterIdentitySingleton ensures no ClusterIdentity other than current exists
+func ClusterIdentitySingleton(ctx context.Context, c client.Reader, current *clusterv1alpha1.ClusterIdentity) error {
+	var clusterIdentities clusterv1alpha1.ClusterIdentityList
+	if err := c.List(ctx, &clusterIdentities); err != nil {
+		return fmt.Errorf("failed to list ClusterIdentities: %w", err)
+	}
+
+	count := 1
+	for _, item := range clusterIdentities.Items {
+		if item.Name != current.Name {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return fmt.Errorf("only one ClusterIdentity resource is allowed per cluster, found %d", count)
+	}
+
+	return nil
+}
+
+// DNSConfiguration validates the DNSConfiguration spec fields
+func DNSConfiguration(cr *clusterv1alpha1.DNSConfiguration) error {
+	if len(cr.Spec.ExternalDNSControllers) == 0 {
+		return fmt.Errorf("externalDNSControllers cannot be empty")
+	}
+	for i, c := range cr.Spec.ExternalDNSControllers {
+		if c.Name == "" {
+			return fmt.Errorf("externalDNSControllers[%d].name cannot be empty", i)
+		}
+		if c.Region == "" {
+			return fmt.Errorf("externalDNSControllers[%d].region cannot be empty", i)
+		}
+		for _, domain := range c.DomainFilters {
+			if errs := k8svalidation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+				return fmt.Errorf("externalDNSControllers[%d].domainFilters: invalid domain %q: %s", i, domain, strings.Join(errs, ", "))
+			}
+		}
+		seen := make(map[string]bool)
+		for _, recordType := range c.RecordTypes {
+			if seen[recordType] {
+				return fmt.Errorf("externalDNSControllers[%d].recordTypes: %s is listed more than once", i, recordType)
+			}
+			seen[recordType] = true
+		}
+	}
+	return nil
+}
+
+// DNSConfigurationSingleton ensures no DNSConfiguration other than current exists
+func DNSConfigurationSingleton(ctx context.Context, c client.Reader, current *clusterv1alpha1.DNSConfiguration) error {
+	var dnsConfigs clusterv1alpha1.DNSConfigurationList
+	if err := c.List(ctx, &dnsConfigs); err != nil {
+		return fmt.Errorf("failed to list DNSConfigurations: %w", err)
+	}
+
+	count := 1
+	for _, item := range dnsConfigs.Items {
+		if item.Name != current.Name {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return fmt.Errorf("only one DNSConfiguration resource is allowed per cluster, found %d", count)
+	}
+
+	return nil
+}
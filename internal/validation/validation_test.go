@@ -0,0 +1,457 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+func testServiceRoute() *routingv1alpha1.ServiceRoute {
+	return &routingv1alpha1.ServiceRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: routingv1alpha1.ServiceRouteSpec{
+			ServiceName: "api",
+			GatewayName: "default-gateway",
+			Environment: "dev",
+			Application: "shop",
+		},
+	}
+}
+
+func testGateway() *routingv1alpha1.Gateway {
+	return &routingv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"},
+		Spec: routingv1alpha1.GatewaySpec{
+			Controller:     "aks-istio-ingress",
+			CredentialName: "cert-aks-ingress",
+			TargetPostfix:  "external",
+		},
+	}
+}
+
+func testIdentity() *clusteridentity.ClusterIdentity {
+	return &clusteridentity.ClusterIdentity{
+		Region:            "neu",
+		Cluster:           "aks",
+		Domain:            "example.com",
+		EnvironmentLetter: "d",
+	}
+}
+
+func TestServiceRoute(t *testing.T) {
+	if err := ServiceRoute(testServiceRoute()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	missing := testServiceRoute()
+	missing.Spec.Application = ""
+	if err := ServiceRoute(missing); err == nil {
+		t.Error("expected error for missing application")
+	}
+
+	badPrefix := testServiceRoute()
+	badPrefix.Spec.Backend = &routingv1alpha1.ServiceRouteBackend{
+		ServiceName:  "api",
+		Port:         8080,
+		PathPrefixes: []string{"api"},
+	}
+	if err := ServiceRoute(badPrefix); err == nil {
+		t.Error("expected error for path prefix without leading slash")
+	}
+
+	gatedWithoutBackend := testServiceRoute()
+	gatedWithoutBackend.Spec.HealthGated = true
+	if err := ServiceRoute(gatedWithoutBackend); err == nil {
+		t.Error("expected error for healthGated without backend")
+	}
+}
+
+func TestServiceRouteHostnames(t *testing.T) {
+	if err := ServiceRouteHostnames(testServiceRoute(), testIdentity()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	long := testServiceRoute()
+	long.Spec.ServiceName = strings.Repeat("a", 64)
+	if err := ServiceRouteHostnames(long, testIdentity()); err == nil {
+		t.Error("expected error for label longer than 63 characters")
+	}
+}
+
+func TestServiceRouteGateway(t *testing.T) {
+	withRetries := testServiceRoute()
+	withRetries.Spec.Backend = &routingv1alpha1.ServiceRouteBackend{
+		ServiceName: "api",
+		Port:        8080,
+		Retries:     &routingv1alpha1.ServiceRouteRetries{Attempts: 3},
+	}
+	if err := ServiceRouteGateway(withRetries, testGateway()); err != nil {
+		t.Errorf("unexpected error for an Istio Gateway: %v", err)
+	}
+
+	gatewayAPI := testGateway()
+	gatewayAPI.Spec.Implementation = consts.ImplementationGatewayAPI
+	if err := ServiceRouteGateway(withRetries, gatewayAPI); err == nil {
+		t.Error("expected error for retries on a Gateway API Gateway")
+	}
+
+	withRetries.Spec.Backend.Retries = nil
+	if err := ServiceRouteGateway(withRetries, gatewayAPI); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	withRetries.Spec.Backend.Timeout = &metav1.Duration{Duration: -time.Second}
+	if err := ServiceRouteGateway(withRetries, gatewayAPI); err == nil {
+		t.Error("expected error for a negative timeout on a Gateway API Gateway")
+	}
+	withRetries.Spec.Backend.Timeout = &metav1.Duration{Duration: 1500 * time.Microsecond}
+	if err := ServiceRouteGateway(withRetries, gatewayAPI); err == nil {
+		t.Error("expected error for a fraction of a millisecond on a Gateway API Gateway")
+	}
+	withRetries.Spec.Backend.Timeout = &metav1.Duration{Duration: 1500 * time.Millisecond}
+	if err := ServiceRouteGateway(withRetries, gatewayAPI); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGatewayAPIDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{0, "0s", false},
+		{30 * time.Second, "30s", false},
+		{1500 * time.Millisecond, "1500ms", false},
+		{250 * time.Microsecond, "", true},
+		{90 * time.Minute, "90m", false},
+		{2 * time.Hour, "2h", false},
+		{100*time.Second + time.Millisecond, "", true},
+		{-time.Second, "", true},
+	}
+	for _, tt := range tests {
+		got, err := GatewayAPIDuration(tt.duration)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: unexpected error %v", tt.duration, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	if err := Gateway(testGateway(), false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	badPostfix := testGateway()
+	badPostfix.Spec.TargetPostfix = "External_1"
+	if err := Gateway(badPostfix, false); err == nil {
+		t.Error("expected error for invalid targetPostfix")
+	}
+
+	gatewayAPI := testGateway()
+	gatewayAPI.Spec.Implementation = consts.ImplementationGatewayAPI
+	gatewayAPI.Spec.GatewayClassName = "istio"
+	if err := Gateway(gatewayAPI, false); err == nil {
+		t.Error("expected error when Gateway API support is disabled")
+	}
+	if err := Gateway(gatewayAPI, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	gatewayAPI.Spec.GatewayClassName = ""
+	if err := Gateway(gatewayAPI, true); err == nil {
+		t.Error("expected error for missing gatewayClassName")
+	}
+}
+
+func TestDNSPolicy(t *testing.T) {
+	policy := &routingv1alpha1.DNSPolicy{
+		Spec: routingv1alpha1.DNSPolicySpec{Mode: "Active"},
+	}
+	if err := DNSPolicy(policy, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := DNSPolicy(policy, &dnsconfiguration.DNSConfiguration{}); err == nil {
+		t.Error("expected error for DNSConfiguration without controllers")
+	}
+
+	policy.Spec.Mode = "Passive"
+	if err := DNSPolicy(policy, nil); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
+
+func TestDNSPolicyFailover(t *testing.T) {
+	dnsConfig := &dnsconfiguration.DNSConfiguration{
+		ExternalDNSControllers: []dnsconfiguration.ExternalDNSController{
+			{Name: "external-dns-neu", Region: "neu"},
+			{Name: "external-dns-weu", Region: "weu"},
+		},
+	}
+	failoverPolicy := func() *routingv1alpha1.DNSPolicy {
+		return &routingv1alpha1.DNSPolicy{
+			Spec: routingv1alpha1.DNSPolicySpec{
+				Mode: "Failover",
+				Failover: &routingv1alpha1.DNSPolicyFailover{
+					Peers: []routingv1alpha1.DNSPolicyFailoverPeer{
+						{Region: "weu", HTTPGet: &routingv1alpha1.DNSPolicyHTTPGetProbe{URL: "https://health.weu.example.com/healthz"}},
+					},
+				},
+			},
+		}
+	}
+
+	if err := DNSPolicy(failoverPolicy(), dnsConfig); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*routingv1alpha1.DNSPolicy)
+	}{
+		{"missing failover", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Failover = nil }},
+		{"failover in Active mode", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Mode = "Active" }},
+		{"no peers", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Failover.Peers = nil }},
+		{"unknown region", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Failover.Peers[0].Region = "eus" }},
+		{"two signals", func(p *routingv1alpha1.DNSPolicy) {
+			p.Spec.Failover.Peers[0].Lease = &routingv1alpha1.DNSPolicyLeaseProbe{Name: "weu-heartbeat"}
+		}},
+		{"no signal", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Failover.Peers[0].HTTPGet = nil }},
+		{"invalid URL", func(p *routingv1alpha1.DNSPolicy) { p.Spec.Failover.Peers[0].HTTPGet.URL = "health.weu.example.com" }},
+		{"duplicate region", func(p *routingv1alpha1.DNSPolicy) {
+			p.Spec.Failover.Peers = append(p.Spec.Failover.Peers, p.Spec.Failover.Peers[0])
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := failoverPolicy()
+			tt.mutate(policy)
+			if err := DNSPolicy(policy, dnsConfig); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+
+	// A namespaced DNSPolicy may only probe a Lease in its own namespace
+	namespaced := failoverPolicy()
+	namespaced.Namespace = "myapp"
+	if err := DNSPolicy(namespaced, dnsConfig); err == nil || !strings.Contains(err.Error(), "only supported in a ClusterDNSPolicy") {
+		t.Errorf("expected httpGet to be rejected in a namespaced DNSPolicy, got %v", err)
+	}
+	namespaced.Spec.Failover.Peers[0].HTTPGet = nil
+	namespaced.Spec.Failover.Peers[0].Lease = &routingv1alpha1.DNSPolicyLeaseProbe{Name: "weu-heartbeat"}
+	if err := DNSPolicy(namespaced, dnsConfig); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	namespaced.Spec.Failover.Peers[0].Lease.Namespace = "kube-system"
+	if err := DNSPolicy(namespaced, dnsConfig); err == nil {
+		t.Error("expected a Lease in another namespace to be rejected")
+	}
+}
+
+func TestDNSPolicyControllerSelection(t *testing.T) {
+	dnsConfig := &dnsconfiguration.DNSConfiguration{
+		ExternalDNSControllers: []dnsconfiguration.ExternalDNSController{
+			{Name: "external-dns-neu", Region: "neu"},
+			{Name: "external-dns-weu", Region: "weu"},
+			{Name: "external-dns-frc", Region: "frc"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		spec    routingv1alpha1.DNSPolicySpec
+		wantErr bool
+	}{
+		{"controllers", routingv1alpha1.DNSPolicySpec{Mode: "RegionBound", Controllers: []string{"external-dns-neu", "external-dns-weu"}}, false},
+		{"region selector", routingv1alpha1.DNSPolicySpec{Mode: "RegionBound", RegionSelector: []string{"neu", "weu"}}, false},
+		{"unknown controller", routingv1alpha1.DNSPolicySpec{Mode: "Active", Controllers: []string{"external-dns-eus"}}, true},
+		{"duplicate controller", routingv1alpha1.DNSPolicySpec{Mode: "Active", Controllers: []string{"external-dns-neu", "external-dns-neu"}}, true},
+		{"unknown region", routingv1alpha1.DNSPolicySpec{Mode: "Active", RegionSelector: []string{"eus"}}, true},
+		{"both", routingv1alpha1.DNSPolicySpec{Mode: "Active", Controllers: []string{"external-dns-neu"}, RegionSelector: []string{"neu"}}, true},
+		{"controllers in Failover mode", routingv1alpha1.DNSPolicySpec{
+			Mode:        "Failover",
+			Controllers: []string{"external-dns-neu"},
+			Failover: &routingv1alpha1.DNSPolicyFailover{
+				Peers: []routingv1alpha1.DNSPolicyFailoverPeer{
+					{Region: "weu", Lease: &routingv1alpha1.DNSPolicyLeaseProbe{Name: "weu-heartbeat"}},
+				},
+			},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DNSPolicy(&routingv1alpha1.DNSPolicy{Spec: tt.spec}, dnsConfig)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DNSPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClusterDNSPolicy(t *testing.T) {
+	policy := &routingv1alpha1.ClusterDNSPolicy{
+		Spec: routingv1alpha1.ClusterDNSPolicySpec{
+			DNSPolicySpec: routingv1alpha1.DNSPolicySpec{Mode: "Active"},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"router.io/tenant": "true"},
+			},
+		},
+	}
+	if err := ClusterDNSPolicy(policy); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	policy.Spec.NamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+		{Key: "team", Operator: "Matches"},
+	}
+	if err := ClusterDNSPolicy(policy); err == nil {
+		t.Error("expected error for invalid namespaceSelector")
+	}
+
+	policy.Spec.NamespaceSelector = nil
+	policy.Spec.Mode = "Failover"
+	policy.Spec.Failover = &routingv1alpha1.DNSPolicyFailover{
+		Peers: []routingv1alpha1.DNSPolicyFailoverPeer{
+			{Region: "weu", Lease: &routingv1alpha1.DNSPolicyLeaseProbe{Name: "weu-heartbeat"}},
+		},
+	}
+	if err := ClusterDNSPolicy(policy); err == nil {
+		t.Error("expected error for a Lease without namespace")
+	}
+
+	policy.Spec.Mode = "Active"
+	policy.Spec.Failover = nil
+	policy.Spec.RouteSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}}
+	if err := ClusterDNSPolicy(policy); err == nil {
+		t.Error("expected error for routeSelector in a ClusterDNSPolicy")
+	}
+}
+
+func TestDNSPolicyRouteSelector(t *testing.T) {
+	policy := &routingv1alpha1.DNSPolicy{
+		Spec: routingv1alpha1.DNSPolicySpec{
+			Mode:          "RegionBound",
+			RouteSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+		},
+	}
+	if err := DNSPolicy(policy, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	policy.Spec.RouteSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+		{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+	}
+	if err := DNSPolicy(policy, nil); err == nil {
+		t.Error("expected error for invalid routeSelector")
+	}
+}
+
+func TestClusterIdentity(t *testing.T) {
+	cr := &clusterv1alpha1.ClusterIdentity{
+		Spec: clusterv1alpha1.ClusterIdentitySpec{
+			Region:            "neu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		},
+	}
+	if err := ClusterIdentity(cr); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cr.Spec.MigrationGracePeriod = &metav1.Duration{Duration: -time.Minute}
+	if err := ClusterIdentity(cr); err == nil {
+		t.Error("expected error for negative migrationGracePeriod")
+	}
+	cr.Spec.MigrationGracePeriod = nil
+
+	cr.Spec.SourceHostTemplate = "{{.Unknown}}.{{.Domain}}"
+	if err := ClusterIdentity(cr); err == nil {
+		t.Error("expected error for invalid sourceHostTemplate")
+	}
+}
+
+func TestClusterIdentitySingleton(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	existing := &clusterv1alpha1.ClusterIdentity{ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+	// The existing object itself passes, e.g. when it is reconciled or updated
+	if err := ClusterIdentitySingleton(context.Background(), c, existing); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	second := &clusterv1alpha1.ClusterIdentity{ObjectMeta: metav1.ObjectMeta{Name: "second"}}
+	if err := ClusterIdentitySingleton(context.Background(), c, second); err == nil {
+		t.Error("expected error for a second ClusterIdentity")
+	}
+}
+
+func TestDNSConfiguration(t *testing.T) {
+	cr := &clusterv1alpha1.DNSConfiguration{}
+	if err := DNSConfiguration(cr); err == nil {
+		t.Error("expected error for empty externalDNSControllers")
+	}
+
+	cr.Spec.ExternalDNSControllers = []clusterv1alpha1.ExternalDNSController{{Name: "external-dns-neu"}}
+	if err := DNSConfiguration(cr); err == nil {
+		t.Error("expected error for controller without region")
+	}
+
+	cr.Spec.ExternalDNSControllers[0].Region = "neu"
+	if err := DNSConfiguration(cr); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cr.Spec.ExternalDNSControllers[0].DomainFilters = []string{"internal.example.com", "example.com"}
+	cr.Spec.ExternalDNSControllers[0].RecordTypes = []string{"A", "CNAME"}
+	if err := DNSConfiguration(cr); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cr.Spec.ExternalDNSControllers[0].DomainFilters = []string{"*.example.com"}
+	if err := DNSConfiguration(cr); err == nil {
+		t.Error("expected error for invalid domain filter")
+	}
+
+	cr.Spec.ExternalDNSControllers[0].DomainFilters = nil
+	cr.Spec.ExternalDNSControllers[0].RecordTypes = []string{"A", "A"}
+	if err := DNSConfiguration(cr); err == nil {
+		t.Error("expected error for duplicate record type")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// clusteridentitylog is for logging in this package.
+var clusteridentitylog = logf.Log.WithName("clusteridentity-resource")
+
+// SetupClusterIdentityWebhookWithManager registers the webhook for ClusterIdentity in the manager.
+func SetupClusterIdentityWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &clusterv1alpha1.ClusterIdentity{}).
+		WithValidator(&ClusterIdentityCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-cluster-router-io-v1alpha1-clusteridentity,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster.router.io,resources=clusteridentities,verbs=create;update,versions=v1alpha1,name=vclusteridentity-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterIdentityCustomValidator validates ClusterIdentities when they are created or updated.
+type ClusterIdentityCustomValidator struct {
+	Client client.Client
+}
+
+// ValidateCreate rejects invalid specs and any ClusterIdentity beyond the first one.
+func (v *ClusterIdentityCustomValidator) ValidateCreate(ctx context.Context, clusterIdentity *clusterv1alpha1.ClusterIdentity) (admission.Warnings, error) {
+	clusteridentitylog.Info("Validation for ClusterIdentity upon creation", "name", clusterIdentity.GetName())
+
+	if err := validation.ClusterIdentitySingleton(ctx, v.Client, clusterIdentity); err != nil {
+		return nil, err
+	}
+
+	return nil, validation.ClusterIdentity(clusterIdentity)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ClusterIdentityCustomValidator) ValidateUpdate(_ context.Context, _, clusterIdentity *clusterv1alpha1.ClusterIdentity) (admission.Warnings, error) {
+	clusteridentitylog.Info("Validation for ClusterIdentity upon update", "name", clusterIdentity.GetName())
+	return nil, validation.ClusterIdentity(clusterIdentity)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *ClusterIdentityCustomValidator) ValidateDelete(_ context.Context, _ *clusterv1alpha1.ClusterIdentity) (admission.Warnings, error) {
+	return nil, nil
+}
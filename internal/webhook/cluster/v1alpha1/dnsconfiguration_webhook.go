@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// dnsconfigurationlog is for logging in this package.
+var dnsconfigurationlog = logf.Log.WithName("dnsconfiguration-resource")
+
+// SetupDNSConfigurationWebhookWithManager registers the webhook for DNSConfiguration in the manager.
+func SetupDNSConfigurationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &clusterv1alpha1.DNSConfiguration{}).
+		WithValidator(&DNSConfigurationCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-cluster-router-io-v1alpha1-dnsconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster.router.io,resources=dnsconfigurations,verbs=create;update,versions=v1alpha1,name=vdnsconfiguration-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DNSConfigurationCustomValidator validates DNSConfigurations when they are created or updated.
+type DNSConfigurationCustomValidator struct {
+	Client client.Client
+}
+
+// ValidateCreate rejects invalid specs and any DNSConfiguration beyond the first one.
+func (v *DNSConfigurationCustomValidator) ValidateCreate(ctx context.Context, dnsConfig *clusterv1alpha1.DNSConfiguration) (admission.Warnings, error) {
+	dnsconfigurationlog.Info("Validation for DNSConfiguration upon creation", "name", dnsConfig.GetName())
+
+	if err := validation.DNSConfigurationSingleton(ctx, v.Client, dnsConfig); err != nil {
+		return nil, err
+	}
+
+	return nil, validation.DNSConfiguration(dnsConfig)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *DNSConfigurationCustomValidator) ValidateUpdate(_ context.Context, _, dnsConfig *clusterv1alpha1.DNSConfiguration) (admission.Warnings, error) {
+	dnsconfigurationlog.Info("Validation for DNSConfiguration upon update", "name", dnsConfig.GetName())
+	return nil, validation.DNSConfiguration(dnsConfig)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *DNSConfigurationCustomValidator) ValidateDelete(_ context.Context, _ *clusterv1alpha1.DNSConfiguration) (admission.Warnings, error) {
+	return nil, nil
+}
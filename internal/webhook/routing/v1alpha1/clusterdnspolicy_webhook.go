@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// clusterdnspolicylog is for logging in this package.
+var clusterdnspolicylog = logf.Log.WithName("clusterdnspolicy-resource")
+
+// SetupClusterDNSPolicyWebhookWithManager registers the webhook for ClusterDNSPolicy in the manager.
+func SetupClusterDNSPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &routingv1alpha1.ClusterDNSPolicy{}).
+		WithValidator(&ClusterDNSPolicyCustomValidator{
+			Config: dnsconfiguration.NewProvider(mgr.GetCache()),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-routing-router-io-v1alpha1-clusterdnspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=routing.router.io,resources=clusterdnspolicies,verbs=create;update,versions=v1alpha1,name=vclusterdnspolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterDNSPolicyCustomValidator validates ClusterDNSPolicies when they are created or updated.
+type ClusterDNSPolicyCustomValidator struct {
+	Config dnsconfiguration.ConfigProvider
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *ClusterDNSPolicyCustomValidator) ValidateCreate(ctx context.Context, policy *routingv1alpha1.ClusterDNSPolicy) (admission.Warnings, error) {
+	clusterdnspolicylog.Info("Validation for ClusterDNSPolicy upon creation", "name", policy.GetName())
+	return v.validate(ctx, policy)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ClusterDNSPolicyCustomValidator) ValidateUpdate(ctx context.Context, _, policy *routingv1alpha1.ClusterDNSPolicy) (admission.Warnings, error) {
+	clusterdnspolicylog.Info("Validation for ClusterDNSPolicy upon update", "name", policy.GetName())
+	return v.validate(ctx, policy)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *ClusterDNSPolicyCustomValidator) ValidateDelete(_ context.Context, _ *routingv1alpha1.ClusterDNSPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the same rules as the ClusterDNSPolicy reconciler.
+// A missing DNSConfiguration is not an error, so policies can be applied before it.
+func (v *ClusterDNSPolicyCustomValidator) validate(ctx context.Context, policy *routingv1alpha1.ClusterDNSPolicy) (admission.Warnings, error) {
+	if err := validation.ClusterDNSPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	dnsConfig, err := v.Config.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, validation.DNSPolicy(&routingv1alpha1.DNSPolicy{Spec: policy.Spec.DNSPolicySpec}, dnsConfig)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/dnsconfiguration"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// dnspolicylog is for logging in this package.
+var dnspolicylog = logf.Log.WithName("dnspolicy-resource")
+
+// SetupDNSPolicyWebhookWithManager registers the webhook for DNSPolicy in the manager.
+func SetupDNSPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &routingv1alpha1.DNSPolicy{}).
+		WithValidator(&DNSPolicyCustomValidator{
+			Config: dnsconfiguration.NewProvider(mgr.GetCache()),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-routing-router-io-v1alpha1-dnspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=routing.router.io,resources=dnspolicies,verbs=create;update,versions=v1alpha1,name=vdnspolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DNSPolicyCustomValidator validates DNSPolicies when they are created or updated.
+type DNSPolicyCustomValidator struct {
+	Config dnsconfiguration.ConfigProvider
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *DNSPolicyCustomValidator) ValidateCreate(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) (admission.Warnings, error) {
+	dnspolicylog.Info("Validation for DNSPolicy upon creation", "name", dnsPolicy.GetName())
+	return v.validate(ctx, dnsPolicy)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *DNSPolicyCustomValidator) ValidateUpdate(ctx context.Context, _, dnsPolicy *routingv1alpha1.DNSPolicy) (admission.Warnings, error) {
+	dnspolicylog.Info("Validation for DNSPolicy upon update", "name", dnsPolicy.GetName())
+	return v.validate(ctx, dnsPolicy)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *DNSPolicyCustomValidator) ValidateDelete(_ context.Context, _ *routingv1alpha1.DNSPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the same rules as the DNSPolicy reconciler.
+// A missing DNSConfiguration is not an error, so policies can be applied before it.
+func (v *DNSPolicyCustomValidator) validate(ctx context.Context, dnsPolicy *routingv1alpha1.DNSPolicy) (admission.Warnings, error) {
+	dnsConfig, err := v.Config.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, validation.DNSPolicy(dnsPolicy, dnsConfig)
+}
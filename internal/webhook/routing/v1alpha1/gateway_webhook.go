@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// gatewaylog is for logging in this package.
+var gatewaylog = logf.Log.WithName("gateway-resource")
+
+// SetupGatewayWebhookWithManager registers the webhook for Gateway in the manager.
+func SetupGatewayWebhookWithManager(mgr ctrl.Manager, gatewayAPIEnabled bool) error {
+	return ctrl.NewWebhookManagedBy(mgr, &routingv1alpha1.Gateway{}).
+		WithValidator(&GatewayCustomValidator{
+			Identity:          clusteridentity.NewProvider(mgr.GetCache()),
+			GatewayAPIEnabled: gatewayAPIEnabled,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-routing-router-io-v1alpha1-gateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=routing.router.io,resources=gateways,verbs=create;update,versions=v1alpha1,name=vgateway-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// GatewayCustomValidator validates Gateways when they are created or updated.
+type GatewayCustomValidator struct {
+	Identity          clusteridentity.IdentityProvider
+	GatewayAPIEnabled bool
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *GatewayCustomValidator) ValidateCreate(ctx context.Context, gateway *routingv1alpha1.Gateway) (admission.Warnings, error) {
+	gatewaylog.Info("Validation for Gateway upon creation", "name", gateway.GetName())
+	return v.validate(ctx, gateway)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *GatewayCustomValidator) ValidateUpdate(ctx context.Context, _, gateway *routingv1alpha1.Gateway) (admission.Warnings, error) {
+	gatewaylog.Info("Validation for Gateway upon update", "name", gateway.GetName())
+	return v.validate(ctx, gateway)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *GatewayCustomValidator) ValidateDelete(_ context.Context, _ *routingv1alpha1.Gateway) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the same rules as the Gateway reconciler
+func (v *GatewayCustomValidator) validate(ctx context.Context, gateway *routingv1alpha1.Gateway) (admission.Warnings, error) {
+	if err := validation.Gateway(gateway, v.GatewayAPIEnabled); err != nil {
+		return nil, err
+	}
+
+	// The target hostname can only be rendered once the cluster identity is known
+	identity, err := v.Identity.Identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		return admission.Warnings{"ClusterIdentity is not available, hostnames were not validated"}, nil
+	}
+
+	return nil, validation.GatewayHostnames(gateway, identity)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/validation"
+)
+
+// serviceroutelog is for logging in this package.
+var serviceroutelog = logf.Log.WithName("serviceroute-resource")
+
+// SetupServiceRouteWebhookWithManager registers the webhooks for ServiceRoute in the manager.
+func SetupServiceRouteWebhookWithManager(mgr ctrl.Manager, defaultRouterGatewayNamespace string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &routingv1alpha1.ServiceRoute{}).
+		WithDefaulter(&ServiceRouteCustomDefaulter{
+			DefaultRouterGatewayNamespace: defaultRouterGatewayNamespace,
+		}).
+		WithValidator(&ServiceRouteCustomValidator{
+			Client:   mgr.GetClient(),
+			Identity: clusteridentity.NewProvider(mgr.GetCache()),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-routing-router-io-v1alpha1-serviceroute,mutating=true,failurePolicy=fail,sideEffects=None,groups=routing.router.io,resources=serviceroutes,verbs=create;update,versions=v1alpha1,name=mserviceroute-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ServiceRouteCustomDefaulter sets default values on ServiceRoutes when they are created or updated.
+type ServiceRouteCustomDefaulter struct {
+	DefaultRouterGatewayNamespace string
+}
+
+// Default sets gatewayNamespace to the operator's default router gateway namespace when it is empty.
+func (d *ServiceRouteCustomDefaulter) Default(_ context.Context, serviceRoute *routingv1alpha1.ServiceRoute) error {
+	serviceroutelog.Info("Defaulting for ServiceRoute", "name", serviceRoute.GetName())
+
+	if serviceRoute.Spec.GatewayNamespace == "" {
+		serviceRoute.Spec.GatewayNamespace = d.DefaultRouterGatewayNamespace
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-routing-router-io-v1alpha1-serviceroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=routing.router.io,resources=serviceroutes,verbs=create;update,versions=v1alpha1,name=vserviceroute-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ServiceRouteCustomValidator validates ServiceRoutes when they are created or updated.
+type ServiceRouteCustomValidator struct {
+	Client   client.Reader
+	Identity clusteridentity.IdentityProvider
+}
+
+// ValidateCreate implements admission.Validator.
+func (v *ServiceRouteCustomValidator) ValidateCreate(ctx context.Context, serviceRoute *routingv1alpha1.ServiceRoute) (admission.Warnings, error) {
+	serviceroutelog.Info("Validation for ServiceRoute upon creation", "name", serviceRoute.GetName())
+	return v.validate(ctx, serviceRoute)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ServiceRouteCustomValidator) ValidateUpdate(ctx context.Context, _, serviceRoute *routingv1alpha1.ServiceRoute) (admission.Warnings, error) {
+	serviceroutelog.Info("Validation for ServiceRoute upon update", "name", serviceRoute.GetName())
+	return v.validate(ctx, serviceRoute)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *ServiceRouteCustomValidator) ValidateDelete(_ context.Context, _ *routingv1alpha1.ServiceRoute) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the same rules as the ServiceRoute reconciler
+func (v *ServiceRouteCustomValidator) validate(ctx context.Context, serviceRoute *routingv1alpha1.ServiceRoute) (admission.Warnings, error) {
+	if err := validation.ServiceRoute(serviceRoute); err != nil {
+		return nil, err
+	}
+
+	// The Gateway may be created after the ServiceRoute, the reconciler checks it again
+	var gateway routingv1alpha1.Gateway
+	if err := v.Client.Get(ctx, client.ObjectKey{
+		Name:      serviceRoute.Spec.GatewayName,
+		Namespace: serviceRoute.Spec.GatewayNamespace,
+	}, &gateway); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else if err := validation.ServiceRouteGateway(serviceRoute, &gateway); err != nil {
+		return nil, err
+	}
+
+	// Hostnames can only be rendered once the cluster identity is known
+	identity, err := v.Identity.Identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		return admission.Warnings{"ClusterIdentity is not available, hostnames were not validated"}, nil
+	}
+
+	if err := validation.ServiceRouteHostnames(serviceRoute, identity); err != nil {
+		return nil, err
+	}
+	return nil, validation.ServiceRouteHostnameClaim(ctx, v.Client, serviceRoute, identity)
+}
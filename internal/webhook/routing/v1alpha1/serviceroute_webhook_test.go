@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/cluster/v1alpha1"
+	routingv1alpha1 "github.com/AshwinSarimin/service-router-operator/api/routing/v1alpha1"
+	"github.com/AshwinSarimin/service-router-operator/internal/clusteridentity"
+	"github.com/AshwinSarimin/service-router-operator/internal/testutil"
+	"github.com/AshwinSarimin/service-router-operator/pkg/consts"
+)
+
+// testSchemes are the API groups of the fake clients of the tests
+var testSchemes = runtime.SchemeBuilder{clusterv1alpha1.AddToScheme, routingv1alpha1.AddToScheme}
+
+func testServiceRoute() *routingv1alpha1.ServiceRoute {
+	return &routingv1alpha1.ServiceRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: routingv1alpha1.ServiceRouteSpec{
+			ServiceName: "api",
+			GatewayName: "default-gateway",
+			Environment: "dev",
+			Application: "shop",
+		},
+	}
+}
+
+func TestServiceRouteDefaulter(t *testing.T) {
+	defaulter := &ServiceRouteCustomDefaulter{DefaultRouterGatewayNamespace: "istio-system"}
+
+	serviceRoute := testServiceRoute()
+	if err := defaulter.Default(context.Background(), serviceRoute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serviceRoute.Spec.GatewayNamespace != "istio-system" {
+		t.Errorf("gatewayNamespace mismatch: got %s", serviceRoute.Spec.GatewayNamespace)
+	}
+
+	serviceRoute.Spec.GatewayNamespace = "custom"
+	if err := defaulter.Default(context.Background(), serviceRoute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if serviceRoute.Spec.GatewayNamespace != "custom" {
+		t.Errorf("explicit gatewayNamespace was overwritten: got %s", serviceRoute.Spec.GatewayNamespace)
+	}
+}
+
+func TestServiceRouteValidatorWithoutIdentity(t *testing.T) {
+	c := testutil.NewFakeClient(t, testSchemes)
+	validator := &ServiceRouteCustomValidator{Client: c, Identity: clusteridentity.NewReaderProvider(c)}
+
+	warnings, err := validator.ValidateCreate(context.Background(), testServiceRoute())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected a warning about the missing ClusterIdentity, got %v", warnings)
+	}
+
+	invalid := testServiceRoute()
+	invalid.Spec.ServiceName = ""
+	if _, err := validator.ValidateCreate(context.Background(), invalid); err == nil {
+		t.Error("expected error for missing serviceName")
+	}
+}
+
+func TestServiceRouteValidatorHostnameLength(t *testing.T) {
+	identity := &clusterv1alpha1.ClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+		Spec: clusterv1alpha1.ClusterIdentitySpec{
+			Region:            "neu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		},
+	}
+	c := testutil.NewFakeClient(t, testSchemes, identity)
+	validator := &ServiceRouteCustomValidator{Client: c, Identity: clusteridentity.NewReaderProvider(c)}
+
+	old := testServiceRoute()
+	serviceRoute := testServiceRoute()
+	serviceRoute.Spec.ServiceName = strings.Repeat("a", 64)
+	if _, err := validator.ValidateUpdate(context.Background(), old, serviceRoute); err == nil {
+		t.Error("expected error for hostname label longer than 63 characters")
+	}
+
+	if _, err := validator.ValidateUpdate(context.Background(), serviceRoute, old); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestServiceRouteValidatorHostnameClaim(t *testing.T) {
+	identity := &clusterv1alpha1.ClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-identity"},
+		Spec: clusterv1alpha1.ClusterIdentitySpec{
+			Region:            "neu",
+			Cluster:           "aks",
+			Domain:            "example.com",
+			EnvironmentLetter: "d",
+		},
+	}
+	owner := testServiceRoute()
+	owner.Namespace = "team-a"
+	owner.Spec.Aliases = []string{"shop"}
+	c := testutil.NewFakeClient(t, testSchemes, identity, owner)
+	validator := &ServiceRouteCustomValidator{Client: c, Identity: clusteridentity.NewReaderProvider(c)}
+
+	claiming := testServiceRoute()
+	claiming.Namespace = "team-b"
+	claiming.Spec.ServiceName = "web"
+	claiming.Spec.Aliases = []string{"shop.example.com."}
+	claiming.Annotations = map[string]string{consts.AnnotationHostnameClaim: "true"}
+	_, err := validator.ValidateCreate(context.Background(), claiming)
+	if err == nil || !strings.Contains(err.Error(), "hostname shop.example.com, it is used by ServiceRoute team-a/api") {
+		t.Errorf("expected the claim on a hostname of another namespace to be rejected, got %v", err)
+	}
+
+	// A claim within the namespace of the other ServiceRoute is allowed
+	claiming.Namespace = "team-a"
+	if _, err := validator.ValidateCreate(context.Background(), claiming); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Without the claim the conflict is left to the reconciler
+	claiming.Namespace = "team-b"
+	claiming.Annotations = nil
+	if _, err := validator.ValidateCreate(context.Background(), claiming); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestServiceRouteValidatorGatewayAPIRetries(t *testing.T) {
+	gateway := &routingv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-gateway", Namespace: "istio-system"},
+		Spec: routingv1alpha1.GatewaySpec{
+			Controller:       "aks-istio-ingress",
+			CredentialName:   "cert",
+			TargetPostfix:    "external",
+			Implementation:   consts.ImplementationGatewayAPI,
+			GatewayClassName: "istio",
+		},
+	}
+	c := testutil.NewFakeClient(t, testSchemes, gateway)
+	validator := &ServiceRouteCustomValidator{Client: c, Identity: clusteridentity.NewReaderProvider(c)}
+
+	serviceRoute := testServiceRoute()
+	serviceRoute.Spec.GatewayNamespace = "istio-system"
+	serviceRoute.Spec.Backend = &routingv1alpha1.ServiceRouteBackend{
+		ServiceName: "api",
+		Port:        8080,
+		Retries:     &routingv1alpha1.ServiceRouteRetries{Attempts: 3},
+	}
+	if _, err := validator.ValidateCreate(context.Background(), serviceRoute); err == nil {
+		t.Error("expected error for retries on a Gateway API Gateway")
+	}
+
+	// The Gateway is checked again by the reconciler once it exists
+	serviceRoute.Spec.GatewayName = "missing"
+	if _, err := validator.ValidateCreate(context.Background(), serviceRoute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -7,29 +7,76 @@ const (
 	PhaseFailed   = "Failed"
 	PhaseInactive = "Inactive"
 
+	// Annotations
+	// AnnotationHostnameClaim marks a ServiceRoute as the explicit owner of its hostnames.
+	// A claiming ServiceRoute wins a hostname conflict over any route without the claim.
+	AnnotationHostnameClaim = "router.io/hostname-claim"
+
+	// Gateway implementations
+	ImplementationIstio      = "Istio"
+	ImplementationGatewayAPI = "GatewayAPI"
+
 	// Condition Types
-	ConditionTypeReady               = "Ready"
-	ConditionTypeDNSReady            = "DNSReady"
-	ConditionTypeAdoptedRegionsValid = "AdoptedRegionsValid"
+	ConditionTypeReady                  = "Ready"
+	ConditionTypeDNSReady               = "DNSReady"
+	ConditionTypeAdoptedRegionsValid    = "AdoptedRegionsValid"
+	ConditionTypeBackendReady           = "BackendReady"
+	ConditionTypeRouteSelectorConflict  = "RouteSelectorConflict"
+	ConditionTypeIdentityDiscovered     = "IdentityDiscovered"
+	ConditionTypeHostnameMigration      = "HostnameMigration"
+	ConditionTypePreFailover            = "PreFailover"
+	ConditionTypeInfrastructureDNSReady = "InfrastructureDNSReady"
+	ConditionTypeTakeoverConflict       = "TakeoverConflict"
 
 	// Condition Reasons
-	ReasonReconciliationSucceeded      = "ReconciliationSucceeded"
-	ReasonValidationFailed             = "ValidationFailed"
-	ReasonSingletonViolation           = "SingletonViolation"
-	ReasonInvalidSpec                  = "InvalidSpec"
-	ReasonNoAdoptedRegions             = "NoAdoptedRegions"
-	ReasonAdoptedRegionNotFound        = "AdoptedRegionNotFound"
-	ReasonAllAdoptedRegionsValid       = "AllAdoptedRegionsValid"
-	ReasonPolicyInactive               = "PolicyInactive"
-	ReasonClusterIdentityNotAvailable  = "ClusterIdentityNotAvailable"
-	ReasonDNSConfigurationNotAvailable = "DNSConfigurationNotAvailable" // Unified
-	ReasonDNSPolicyNotFound            = "DNSPolicyNotFound"
-	ReasonDNSPolicyInactive            = "DNSPolicyInactive"
-	ReasonGatewayNotFound              = "GatewayNotFound"
-	ReasonNoServiceRoutes              = "NoServiceRoutes"
-	ReasonDNSEndpointGenerationFailed  = "DNSEndpointGenerationFailed"
-	ReasonIstioGatewayGenerationFailed = "IstioGatewayGenerationFailed"
-	ReasonDNSEndpointsCreated          = "DNSEndpointsCreated"
-	ReasonLoadBalancerIPPending        = "LoadBalancerIPPending"
-	ReasonDNSNotReady                  = "DNSNotReady"
+	ReasonReconciliationSucceeded        = "ReconciliationSucceeded"
+	ReasonValidationFailed               = "ValidationFailed"
+	ReasonSingletonViolation             = "SingletonViolation"
+	ReasonInvalidSpec                    = "InvalidSpec"
+	ReasonNoAdoptedRegions               = "NoAdoptedRegions"
+	ReasonAdoptedRegionNotFound          = "AdoptedRegionNotFound"
+	ReasonAllAdoptedRegionsValid         = "AllAdoptedRegionsValid"
+	ReasonPolicyInactive                 = "PolicyInactive"
+	ReasonClusterIdentityNotAvailable    = "ClusterIdentityNotAvailable"
+	ReasonDNSConfigurationNotAvailable   = "DNSConfigurationNotAvailable" // Unified
+	ReasonDNSPolicyNotFound              = "DNSPolicyNotFound"
+	ReasonDNSPolicyInactive              = "DNSPolicyInactive"
+	ReasonGatewayNotFound                = "GatewayNotFound"
+	ReasonNoServiceRoutes                = "NoServiceRoutes"
+	ReasonDNSEndpointGenerationFailed    = "DNSEndpointGenerationFailed"
+	ReasonIstioGatewayGenerationFailed   = "IstioGatewayGenerationFailed"
+	ReasonVirtualServiceGenerationFailed = "VirtualServiceGenerationFailed"
+	ReasonGatewayAPIGenerationFailed     = "GatewayAPIGenerationFailed"
+	ReasonHTTPRouteGenerationFailed      = "HTTPRouteGenerationFailed"
+	ReasonDNSRecordsObserved             = "DNSRecordsObserved"
+	ReasonLoadBalancerIPPending          = "LoadBalancerIPPending"
+	ReasonDNSNotReady                    = "DNSNotReady"
+	ReasonHostnameConflict               = "HostnameConflict"
+	ReasonBackendNotReady                = "BackendNotReady"
+	ReasonEndpointsReady                 = "EndpointsReady"
+	ReasonNoReadyEndpoints               = "NoReadyEndpoints"
+	ReasonGatewayDNSNotReady             = "GatewayDNSNotReady"
+	ReasonOverlappingRouteSelector       = "OverlappingRouteSelector"
+	ReasonDiscoveredIdentityMatches      = "DiscoveredIdentityMatches"
+	ReasonDiscoveredIdentityMismatch     = "DiscoveredIdentityMismatch"
+	ReasonIdentityDiscoveryFailed        = "IdentityDiscoveryFailed"
+	ReasonMigrationInProgress            = "MigrationInProgress"
+	ReasonPreFailoverEnabled             = "PreFailoverEnabled"
+	ReasonARecordsPublished              = "ARecordsPublished"
+	ReasonNoExternalDNSController        = "NoExternalDNSController"
+	ReasonClaimedByOtherCluster          = "ClaimedByOtherCluster"
+	ReasonNotControlled                  = "NotControlled"
+
+	// Event Reasons, next to the condition reasons of Ready transitions
+	ReasonDNSEndpointsCreated      = "DNSEndpointsCreated"
+	ReasonDNSEndpointsUpdated      = "DNSEndpointsUpdated"
+	ReasonDNSEndpointsDeleted      = "DNSEndpointsDeleted"
+	ReasonIstioGatewayCreated      = "IstioGatewayCreated"
+	ReasonIstioGatewayHostsChanged = "IstioGatewayHostsChanged"
+	ReasonIstioGatewayDeleted      = "IstioGatewayDeleted"
+	ReasonPolicyActivated          = "PolicyActivated"
+	ReasonActiveControllersChanged = "ActiveControllersChanged"
+	ReasonPeerRegionTakenOver      = "PeerRegionTakenOver"
+	ReasonPeerRegionHandedBack     = "PeerRegionHandedBack"
+	ReasonIdentityUpdated          = "IdentityUpdated"
 )
@@ -120,10 +120,10 @@ var _ = Describe("ClusterIdentity Integration", func() {
 				return k8sClient.Update(ctx, &cr)
 			}, timeout, interval).Should(Succeed())
 
-			// Verify cluster identity cache is updated
+			// Verify the updated cluster identity is visible to the reconcilers
 			Eventually(func() string {
-				identity := clusteridentity.Get()
-				if identity == nil {
+				identity, err := clusteridentity.NewReaderProvider(k8sClient).Identity(ctx)
+				if err != nil || identity == nil {
 					return ""
 				}
 				return identity.Region
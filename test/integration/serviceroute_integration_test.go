@@ -150,7 +150,7 @@ var _ = Describe("ServiceRoute Full Stack Integration", func() {
 				Fail(err.Error())
 			}
 
-			Expect(dnsEndpoint.Spec.Endpoints).To(HaveLen(1))
+			Expect(dnsEndpoint.Spec.Endpoints).To(HaveLen(2))
 			expectedSourceHost := "my-service-ns-d-dev-myapp.example.com"
 			expectedTargetHost := "aks-neu-external.example.com"
 
@@ -159,6 +159,11 @@ var _ = Describe("ServiceRoute Full Stack Integration", func() {
 			Expect(dnsEndpoint.Spec.Endpoints[0].Targets).To(HaveLen(1))
 			Expect(dnsEndpoint.Spec.Endpoints[0].Targets[0]).To(Equal(expectedTargetHost))
 
+			// The ownership record of the CNAME
+			Expect(dnsEndpoint.Spec.Endpoints[1].DNSName).To(Equal("_router-owner." + expectedSourceHost))
+			Expect(dnsEndpoint.Spec.Endpoints[1].RecordType).To(Equal("TXT"))
+			Expect(dnsEndpoint.Spec.Endpoints[1].Targets[0]).To(HavePrefix("heritage=service-router,"))
+
 			// Cleanup
 			DeleteObject(serviceRoute)
 		})